@@ -0,0 +1,110 @@
+package linq
+
+import "sync"
+
+// Shares a single enumeration of a source between a fixed number of branches.
+// Every branch receives every element. Elements are buffered only until the slowest active branch has received them.
+// With a capacity, a branch that would pull past a full buffer waits until the buffer has room.
+type broadcast[TSource any] struct {
+	mutex     sync.Mutex
+	room      sync.Cond
+	source    *puller[TSource]
+	buffer    []TSource
+	offset    int
+	positions []int
+	capacity  int
+}
+
+const detached = -1
+
+// Returns a broadcast whose buffer holds at most capacity elements, or any number of them if capacity is 0.
+func newBroadcast[TSource any](source Iterator[TSource], branches int, capacity int) *broadcast[TSource] {
+	b := &broadcast[TSource]{
+		source:    newPuller(source),
+		positions: make([]int, branches),
+		capacity:  capacity,
+	}
+	b.room.L = &b.mutex
+	return b
+}
+
+func (b *broadcast[TSource]) branches() (result []Iterator[TSource]) {
+	result = make([]Iterator[TSource], len(b.positions))
+	for i := range result {
+		result[i] = b.branch(i)
+	}
+	return result
+}
+
+func (b *broadcast[TSource]) branch(i int) Iterator[TSource] {
+	return func(yield func(value TSource) bool) {
+		for {
+			value, ok := b.pull(i)
+			if !ok {
+				return
+			}
+			if !yield(value) {
+				b.detach(i)
+				return
+			}
+		}
+	}
+}
+
+func (b *broadcast[TSource]) pull(i int) (value TSource, ok bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	position := b.positions[i]
+	for b.capacity > 0 && position == b.offset+len(b.buffer) && len(b.buffer) >= b.capacity {
+		b.room.Wait()
+		position = b.positions[i]
+	}
+	if position == detached {
+		return value, false
+	}
+	if position < b.offset+len(b.buffer) {
+		value = b.buffer[position-b.offset]
+		b.positions[i]++
+		b.trim()
+		return value, true
+	}
+	value, ok = b.source.pull()
+	if !ok {
+		return value, false
+	}
+	b.buffer = append(b.buffer, value)
+	b.positions[i]++
+	b.trim()
+	return value, true
+}
+
+func (b *broadcast[TSource]) detach(i int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.positions[i] = detached
+	for _, position := range b.positions {
+		if position != detached {
+			b.trim()
+			return
+		}
+	}
+	b.buffer = nil
+	b.source.close()
+	b.room.Broadcast()
+}
+
+// Drops the buffered elements that every active branch has already received.
+func (b *broadcast[TSource]) trim() {
+	slowest := b.offset + len(b.buffer)
+	for _, position := range b.positions {
+		if position != detached && position < slowest {
+			slowest = position
+		}
+	}
+	if drop := slowest - b.offset; drop > 0 {
+		clear(b.buffer[:drop])
+		b.buffer = b.buffer[drop:]
+		b.offset = slowest
+		b.room.Broadcast()
+	}
+}
@@ -0,0 +1,150 @@
+package linq
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+// Returns the natural order of a type.
+//
+// # Returns
+//
+//	result generic.Comparison[TSource]
+//
+// A function that returns a negative number, zero or a positive number when its first argument precedes, is equal to or follows the second.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrTypeIsNotOrdered - When TSource has no natural order, for example a struct without a Compare method, a bool or an interface type.
+//
+// # Remarks
+//
+// The natural order is, in this order of precedence:
+//
+//   - the Compare method of generic.IComparable[TSource], for example that of time.Time;
+//   - the order of the underlying kind of an integer, floating-point or string type, including named types such as type UserID int64 or time.Duration;
+//   - for a pointer, or a pointer to a pointer and so on, to a type of an integer, floating-point or string kind, the order of
+//     the values pointed to, with nil before every other pointer.
+//
+// A pointer to a type that is ordered only by its Compare method, such as *time.Time, has no natural order: pass a comparison
+// that dereferences it.
+//
+// Order, OrderDescending, Min, Max and MinMax use the natural order when no comparison is passed. Call DefaultComparison
+// to find out in advance whether a type can be ordered, or the Try form of an operator, such as TryOrder or TryTopK, to have
+// the error returned instead of a panic; use the package-level Order, Min and Max, which require generic.Comparable,
+// to have it checked at compile time.
+//
+// The order is resolved once, when DefaultComparison is called: the returned function does not use reflection or allocate.
+func DefaultComparison[TSource any]() (result generic.Comparison[TSource], err error) {
+	t := reflect.TypeFor[TSource]()
+	if method, ok := t.MethodByName("Compare"); ok && method.Func.IsValid() {
+		if compare, ok := method.Func.Interface().(func(x, y TSource) int); ok {
+			return compare, nil
+		}
+	}
+	depth, kind := 0, t
+	for kind.Kind() == reflect.Pointer {
+		depth, kind = depth+1, kind.Elem()
+	}
+	if compare, ok := kindComparison[TSource](kind.Kind(), depth); ok {
+		return compare, nil
+	}
+	return nil, fmt.Errorf("%w: %v", ErrTypeIsNotOrdered, t)
+}
+
+// Returns the order of TSource if its underlying type, or the type it points to through depth pointers, is of an ordered kind.
+func kindComparison[TSource any](kind reflect.Kind, depth int) (result generic.Comparison[TSource], ok bool) {
+	switch kind {
+	case reflect.Int:
+		return underlyingComparison[TSource, int](depth), true
+	case reflect.Int8:
+		return underlyingComparison[TSource, int8](depth), true
+	case reflect.Int16:
+		return underlyingComparison[TSource, int16](depth), true
+	case reflect.Int32:
+		return underlyingComparison[TSource, int32](depth), true
+	case reflect.Int64:
+		return underlyingComparison[TSource, int64](depth), true
+	case reflect.Uint:
+		return underlyingComparison[TSource, uint](depth), true
+	case reflect.Uint8:
+		return underlyingComparison[TSource, uint8](depth), true
+	case reflect.Uint16:
+		return underlyingComparison[TSource, uint16](depth), true
+	case reflect.Uint32:
+		return underlyingComparison[TSource, uint32](depth), true
+	case reflect.Uint64:
+		return underlyingComparison[TSource, uint64](depth), true
+	case reflect.Uintptr:
+		return underlyingComparison[TSource, uintptr](depth), true
+	case reflect.Float32:
+		return underlyingComparison[TSource, float32](depth), true
+	case reflect.Float64:
+		return underlyingComparison[TSource, float64](depth), true
+	case reflect.String:
+		return underlyingComparison[TSource, string](depth), true
+	}
+	return nil, false
+}
+
+// Returns the order of TSource, whose underlying type is TKind, such as type UserID int64, or, if depth is positive, which
+// points to such a type through depth pointers, with nil before every other pointer at each level.
+// kindComparison has checked the kinds, so that the values of TSource can be read as TKind or as pointers.
+func underlyingComparison[TSource any, TKind cmp.Ordered](depth int) generic.Comparison[TSource] {
+	if depth == 0 {
+		return func(x, y TSource) int {
+			return cmp.Compare(*(*TKind)(unsafe.Pointer(&x)), *(*TKind)(unsafe.Pointer(&y)))
+		}
+	}
+	return func(x, y TSource) int {
+		p, q := *(*unsafe.Pointer)(unsafe.Pointer(&x)), *(*unsafe.Pointer)(unsafe.Pointer(&y))
+		for level := 1; ; level++ {
+			switch {
+			case p == q:
+				return 0
+			case p == nil:
+				return -1
+			case q == nil:
+				return 1
+			case level == depth:
+				return cmp.Compare(*(*TKind)(p), *(*TKind)(q))
+			}
+			p, q = *(*unsafe.Pointer)(p), *(*unsafe.Pointer)(q)
+		}
+	}
+}
+
+// Returns compare[0] if passed; otherwise, the natural order of TSource.
+func resolveComparison[TSource any](compare ...generic.Comparison[TSource]) (result generic.Comparison[TSource], err error) {
+	if len(compare) > 0 && compare[0] != nil {
+		return compare[0], nil
+	}
+	return DefaultComparison[TSource]()
+}
+
+// Returns the comparison Min, Max and MinMax rank elements by. A passed compare is read in reverse, as these methods
+// have always read it; the natural order is not.
+func extremeComparison[TSource any](compare ...generic.Comparison[TSource]) (result generic.Comparison[TSource], err error) {
+	if len(compare) > 0 && compare[0] != nil {
+		Compare := compare[0]
+		return func(x, y TSource) int {
+			return Compare(y, x)
+		}, nil
+	}
+	return DefaultComparison[TSource]()
+}
+
+// Returns result, or panics with err. The operators that take an optional comparison call it with the result of their
+// Try form, such as Order with TryOrder, and document the panic.
+func must[TResult any](result TResult, err error) TResult {
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
@@ -0,0 +1,179 @@
+package linq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type userID int64
+
+type status string
+
+type point struct {
+	X, Y int
+}
+
+func TestIterator_Order_namedTypes(t *testing.T) {
+	if got, want := FromSlice([]userID{30, 10, 20}).Order().ToSlice(), []userID{10, 20, 30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator.Order() = %v, want %v", got, want)
+	}
+	if got, want := FromSlice([]status{"b", "c", "a"}).OrderDescending().ToSlice(), []status{"c", "b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator.OrderDescending() = %v, want %v", got, want)
+	}
+	if got, want := FromSlice([]time.Duration{time.Hour, time.Second, time.Minute}).Order().ToSlice(), []time.Duration{time.Second, time.Minute, time.Hour}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator.Order() = %v, want %v", got, want)
+	}
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	times := []time.Time{base.Add(time.Hour), base, base.Add(time.Minute)}
+	if got, want := FromSlice(times).Order().ToSlice(), []time.Time{times[1], times[2], times[0]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator.Order() = %v, want %v", got, want)
+	}
+	one, two := 1, 2
+	if got, want := FromSlice([]*int{&two, nil, &one}).Order().ToSlice(), []*int{nil, &one, &two}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator.Order() = %v, want %v", got, want)
+	}
+}
+
+func TestIterator_Order_notOrdered(t *testing.T) {
+	points := FromSlice([]point{{1, 2}})
+	byPoint := func(item point) point {
+		return item
+	}
+	for name, call := range map[string]func(){
+		"Iterator.Order":           func() { points.Order() },
+		"Iterator.OrderDescending": func() { points.OrderDescending() },
+		"TopK":                     func() { TopK(points, 1) },
+		"BottomK":                  func() { BottomK(points, 1) },
+		"TopKBy":                   func() { TopKBy(points, 1, byPoint) },
+		"BottomKBy":                func() { BottomKBy(points, 1, byPoint) },
+		"AsOrdered":                func() { AsOrdered(points) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, ErrTypeIsNotOrdered) {
+					t.Errorf("%s() panicked with %v, want %v", name, err, ErrTypeIsNotOrdered)
+				}
+			}()
+			call()
+		})
+	}
+}
+
+func TestIterator_TryOrder(t *testing.T) {
+	points := FromSlice([]point{{1, 2}})
+	byPoint := func(item point) point {
+		return item
+	}
+	for name, call := range map[string]func() error{
+		"Iterator.TryOrder":           func() (err error) { _, err = points.TryOrder(); return },
+		"Iterator.TryOrderDescending": func() (err error) { _, err = points.TryOrderDescending(); return },
+		"TryTopK":                     func() (err error) { _, err = TryTopK(points, 1); return },
+		"TryBottomK":                  func() (err error) { _, err = TryBottomK(points, 1); return },
+		"TryTopKBy":                   func() (err error) { _, err = TryTopKBy(points, 1, byPoint); return },
+		"TryBottomKBy":                func() (err error) { _, err = TryBottomKBy(points, 1, byPoint); return },
+		"TryAsOrdered":                func() (err error) { _, err = TryAsOrdered(points); return },
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := call(); !errors.Is(err, ErrTypeIsNotOrdered) {
+				t.Errorf("%s() error = %v, want %v", name, err, ErrTypeIsNotOrdered)
+			}
+		})
+	}
+	ids := FromSlice([]userID{30, 10, 20})
+	if sorted, err := ids.TryOrder(); err != nil || !reflect.DeepEqual(sorted.ToSlice(), []userID{10, 20, 30}) {
+		t.Errorf("Iterator.TryOrder() = %v, %v, want [10 20 30], nil", sorted.ToSlice(), err)
+	}
+	if sorted, err := ids.TryOrderDescending(); err != nil || !reflect.DeepEqual(sorted.ToSlice(), []userID{30, 20, 10}) {
+		t.Errorf("Iterator.TryOrderDescending() = %v, %v, want [30 20 10], nil", sorted.ToSlice(), err)
+	}
+	if top, err := TryTopK(ids, 2); err != nil || !reflect.DeepEqual(top.ToSlice(), []userID{30, 20}) {
+		t.Errorf("TryTopK() = %v, %v, want [30 20], nil", top.ToSlice(), err)
+	}
+	if bottom, err := TryBottomKBy(points, 1, byPoint, func(x, y point) int { return x.X - y.X }); err != nil || !reflect.DeepEqual(bottom.ToSlice(), []point{{1, 2}}) {
+		t.Errorf("TryBottomKBy() = %v, %v, want [{1 2}], nil", bottom.ToSlice(), err)
+	}
+}
+
+func TestIterator_MinMax(t *testing.T) {
+	ids := []userID{20, 10, 30, 10}
+	if got, err := FromSlice(ids).Min(); got != 10 || err != nil {
+		t.Errorf("Iterator.Min() = %v, %v, want 10, nil", got, err)
+	}
+	if got, err := FromSlice(ids).Max(); got != 30 || err != nil {
+		t.Errorf("Iterator.Max() = %v, %v, want 30, nil", got, err)
+	}
+	if min, max, err := FromSlice(ids).MinMax(); min != 10 || max != 30 || err != nil {
+		t.Errorf("Iterator.MinMax() = %v, %v, %v, want 10, 30, nil", min, max, err)
+	}
+	byLength := func(x, y string) int {
+		return len(x) - len(y)
+	}
+	words := []string{"ccc", "a", "bb", "dd", "e"}
+	if got, err := FromSlice(words).Max(byLength); got != "a" || err != nil {
+		t.Errorf("Iterator.Max() = %v, %v, want a, nil", got, err)
+	}
+	if got, err := FromSlice(words).Min(byLength); got != "ccc" || err != nil {
+		t.Errorf("Iterator.Min() = %v, %v, want ccc, nil", got, err)
+	}
+	if min, max, err := FromSlice(words).MinMax(byLength); min != "ccc" || max != "a" || err != nil {
+		t.Errorf("Iterator.MinMax() = %v, %v, %v, want ccc, a, nil", min, max, err)
+	}
+	if _, err := FromSlice([]status{}).Max(); !errors.Is(err, ErrSourceContainsNoElements) {
+		t.Errorf("Iterator.Max() error = %v, want %v", err, ErrSourceContainsNoElements)
+	}
+	if _, err := FromSlice([]point{{1, 2}}).Min(); !errors.Is(err, ErrTypeIsNotOrdered) {
+		t.Errorf("Iterator.Min() error = %v, want %v", err, ErrTypeIsNotOrdered)
+	}
+	if _, _, err := FromSlice([]bool{true}).MinMax(); !errors.Is(err, ErrTypeIsNotOrdered) {
+		t.Errorf("Iterator.MinMax() error = %v, want %v", err, ErrTypeIsNotOrdered)
+	}
+}
+
+func TestDefaultComparison(t *testing.T) {
+	if _, err := DefaultComparison[float32](); err != nil {
+		t.Errorf("DefaultComparison[float32]() error = %v, want nil", err)
+	}
+	a, b := status("a"), status("b")
+	pa, pb := &a, &b
+	if compare, err := DefaultComparison[**status](); err != nil || compare(&pa, &pb) >= 0 || compare(&pb, &pa) <= 0 || compare(nil, &pa) >= 0 {
+		t.Errorf("DefaultComparison[**status]() does not order by the values pointed to, error = %v", err)
+	}
+	if compare, err := DefaultComparison[userID](); err != nil || compare(2, 10) >= 0 || compare(10, 10) != 0 {
+		t.Errorf("DefaultComparison[userID]() does not order by value, error = %v", err)
+	}
+	for name, err := range map[string]error{
+		"point":      second(DefaultComparison[point]()),
+		"*point":     second(DefaultComparison[*point]()),
+		"*time.Time": second(DefaultComparison[*time.Time]()),
+		"any":        second(DefaultComparison[any]()),
+		"bool":       second(DefaultComparison[bool]()),
+	} {
+		if !errors.Is(err, ErrTypeIsNotOrdered) {
+			t.Errorf("DefaultComparison[%s]() error = %v, want %v", name, err, ErrTypeIsNotOrdered)
+		}
+	}
+}
+
+func BenchmarkDefaultComparison(b *testing.B) {
+	ints := Select(Range(0, 10000), func(i int) int { return (i * 7919) % 10000 })
+	b.Run("int", func(b *testing.B) {
+		for b.Loop() {
+			ints.Order().Count()
+		}
+	})
+	ids := Select(ints, func(i int) userID { return userID(i) })
+	b.Run("named", func(b *testing.B) {
+		for b.Loop() {
+			ids.Order().Count()
+		}
+	})
+	pointers := Select(ints, func(i int) *int { return &i }).ToSlice()
+	b.Run("pointer", func(b *testing.B) {
+		for b.Loop() {
+			FromSlice(pointers).Order().Count()
+		}
+	})
+}
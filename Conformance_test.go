@@ -0,0 +1,806 @@
+package linq_test
+
+import (
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/thereisnoplanb/generic"
+	"github.com/thereisnoplanb/linq"
+	"github.com/thereisnoplanb/linq/linqtest"
+)
+
+type group struct {
+	Key      int
+	Elements []int
+}
+
+// Returns result; a non-nil err fails the case by panicking.
+func must[T any](result T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func groups[TSource any](source linq.Iterator[generic.KeyValuePair[int, linq.Iterator[TSource]]]) linq.Iterator[group] {
+	return linq.Select(source, func(pair generic.KeyValuePair[int, linq.Iterator[TSource]]) group {
+		elements := make([]int, 0)
+		for item := range pair.Value {
+			elements = append(elements, any(item).(int))
+		}
+		return group{Key: pair.Key, Elements: elements}
+	})
+}
+
+func values[TSource any](t *testing.T, source linq.ErrorIterator[TSource]) linq.Iterator[TSource] {
+	result, err := source.Values()
+	return func(yield func(value TSource) bool) {
+		result(yield)
+		if err := err(); err != nil {
+			t.Errorf("ErrorIterator error = %v, want nil", err)
+		}
+	}
+}
+
+func TestConformance_sources(t *testing.T) {
+	t.Run("FromSlice", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.FromSlice([]int{1, 2, 3})
+		}, []int{1, 2, 3})
+	})
+	t.Run("FromIterator", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.FromIterator(linq.FromSlice([]int{1, 2, 3}))
+		}, []int{1, 2, 3})
+	})
+	t.Run("FromMap", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[generic.KeyValuePair[string, int]] {
+			return linq.FromMap(map[string]int{"a": 1, "b": 2, "c": 3})
+		}, []generic.KeyValuePair[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}}, linqtest.Unordered())
+	})
+	t.Run("FromString", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[rune] {
+			return linq.FromString("añb")
+		}, []rune{'a', 'ñ', 'b'})
+	})
+	t.Run("FromEnumerator", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.FromEnumerator(linq.FromSlice([]int{1, 2, 3}).GetEnumerator())
+		}, []int{1, 2, 3}, linqtest.SinglePass())
+	})
+	t.Run("Enumerator.Remaining", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			enumerator := linq.FromSlice([]int{1, 2, 3}).GetEnumerator()
+			enumerator.PushBack(0)
+			return enumerator.Remaining()
+		}, []int{0, 1, 2, 3}, linqtest.SinglePass())
+	})
+	t.Run("Repeat", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.Repeat(7, 3)
+		}, []int{7, 7, 7})
+	})
+	t.Run("Range", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.Range(5, 4)
+		}, []int{5, 6, 7, 8})
+	})
+	t.Run("Range, empty", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.Range(5, 0)
+		}, []int{})
+	})
+	t.Run("Empty", func(t *testing.T) {
+		linqtest.CheckIterator(t, linq.Empty[int], []int{})
+	})
+	t.Run("Iterate", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.Iterate(1, func(value int) int { return value * 3 }).Take(4)
+		}, []int{1, 3, 9, 27})
+	})
+	t.Run("Unfold", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.Unfold(3, func(state int) (int, int, bool) { return state * 10, state - 1, state > 0 })
+		}, []int{30, 20, 10})
+	})
+	t.Run("Cycle", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.Cycle(linq.FromSlice([]int{1, 2}).Where(func(int) bool { return true })).Take(5)
+		}, []int{1, 2, 1, 2, 1})
+	})
+	t.Run("RangeStep", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[float64] {
+			return linq.RangeStep(1, 0, -0.25)
+		}, []float64{1, 0.75, 0.5, 0.25})
+	})
+	t.Run("Generate", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.Generate(func() int { return 7 }).Take(3)
+		}, []int{7, 7, 7})
+	})
+	from := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+	t.Run("DateRange", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[time.Time] {
+			return linq.DateRange(from, from.Add(36*time.Hour), 12*time.Hour)
+		}, []time.Time{from, from.Add(12 * time.Hour), from.Add(24 * time.Hour)})
+	})
+	t.Run("MonthRange", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[time.Time] {
+			return linq.MonthRange(from, time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC), 1)
+		}, []time.Time{
+			from,
+			time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC),
+		})
+	})
+	fsys := fstest.MapFS{
+		"a.txt":       {},
+		"logs/b.log":  {},
+		"logs/c.log":  {},
+		"logs/d/e.go": {},
+	}
+	paths := func(source linq.ErrorIterator[linq.FileEntry]) linq.Iterator[string] {
+		return linq.Select(values(t, source), func(entry linq.FileEntry) string {
+			return entry.Path
+		})
+	}
+	t.Run("FromFS", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[string] {
+			return paths(linq.FromFS(fsys, "."))
+		}, []string{".", "a.txt", "logs", "logs/b.log", "logs/c.log", "logs/d", "logs/d/e.go"})
+	})
+	t.Run("FromDirRecursive", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[string] {
+			return paths(linq.FromDirRecursive(fsys, "logs", linq.WalkOptions{FilesOnly: true}))
+		}, []string{"logs/b.log", "logs/c.log", "logs/d/e.go"})
+	})
+	t.Run("FromGlob", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[string] {
+			return paths(linq.FromGlob(fsys, "**/*.log"))
+		}, []string{"logs/b.log", "logs/c.log"})
+	})
+}
+
+func TestConformance_operators(t *testing.T) {
+	numbers := func() linq.Iterator[int] {
+		return linq.FromSlice([]int{5, 3, 8, 3, 1, 8})
+	}
+	isEven := func(item int) bool {
+		return item%2 == 0
+	}
+	tests := []struct {
+		name      string
+		newSource func() linq.Iterator[int]
+		want      []int
+		options   []linqtest.Option
+	}{
+		{
+			name: "Append",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Append(9, 10)
+			},
+			want: []int{5, 3, 8, 3, 1, 8, 9, 10},
+		},
+		{
+			name: "Prepend",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Prepend(9, 10)
+			},
+			want: []int{9, 10, 5, 3, 8, 3, 1, 8},
+		},
+		{
+			name: "Cast",
+			newSource: func() linq.Iterator[int] {
+				return linq.Cast[any, int](linq.FromSlice([]any{1, 2}))
+			},
+			want: []int{1, 2},
+		},
+		{
+			name: "Concat",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Concat(linq.Range(1, 2))
+			},
+			want: []int{5, 3, 8, 3, 1, 8, 1, 2},
+		},
+		{
+			name: "Distinct",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Distinct()
+			},
+			want: []int{5, 3, 8, 1},
+		},
+		{
+			name: "Except",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Except(linq.Range(1, 5))
+			},
+			want: []int{8},
+		},
+		{
+			name: "Except, duplicates on both sides",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Except(linq.FromSlice([]int{3, 1, 3, 3}))
+			},
+			want: []int{5, 8},
+		},
+		{
+			name: "Intersect",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Intersect(linq.Range(1, 5))
+			},
+			want: []int{5, 3, 1},
+		},
+		{
+			name: "Union",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Union(linq.Range(1, 3))
+			},
+			want: []int{5, 3, 8, 1, 2},
+		},
+		{
+			name: "Join",
+			newSource: func() linq.Iterator[int] {
+				return linq.Join(numbers(), linq.Range(1, 5), func(item int) int {
+					return item
+				}, func(item int) int {
+					return item
+				}, func(outer int, inner int) int {
+					return outer * inner
+				})
+			},
+			want: []int{25, 9, 9, 1},
+		},
+		{
+			name: "Order",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Order()
+			},
+			want: []int{1, 3, 3, 5, 8, 8},
+		},
+		{
+			name: "Order, package function",
+			newSource: func() linq.Iterator[int] {
+				return linq.Order(numbers())
+			},
+			want: []int{1, 3, 3, 5, 8, 8},
+		},
+		{
+			name: "OrderDescending",
+			newSource: func() linq.Iterator[int] {
+				return numbers().OrderDescending()
+			},
+			want: []int{8, 8, 5, 3, 3, 1},
+		},
+		{
+			name: "OrderDescending, package function",
+			newSource: func() linq.Iterator[int] {
+				return linq.OrderDescending(numbers())
+			},
+			want: []int{8, 8, 5, 3, 3, 1},
+		},
+		{
+			name: "OrderBy",
+			newSource: func() linq.Iterator[int] {
+				return linq.OrderBy(numbers(), func(item int) int {
+					return -item
+				})
+			},
+			want: []int{8, 8, 5, 3, 3, 1},
+		},
+		{
+			name: "OrderByDescending",
+			newSource: func() linq.Iterator[int] {
+				return linq.OrderByDescending(numbers(), func(item int) int {
+					return -item
+				})
+			},
+			want: []int{1, 3, 3, 5, 8, 8},
+		},
+		{
+			name: "Reverse",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Reverse()
+			},
+			want: []int{8, 1, 3, 8, 3, 5},
+		},
+		{
+			name: "Reverse, opaque source",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Where(isEven).Reverse()
+			},
+			want: []int{8, 8},
+		},
+		{
+			name: "Select",
+			newSource: func() linq.Iterator[int] {
+				return linq.Select(numbers(), func(item int) int {
+					return item * 10
+				})
+			},
+			want: []int{50, 30, 80, 30, 10, 80},
+		},
+		{
+			name: "SelectMany",
+			newSource: func() linq.Iterator[int] {
+				return linq.SelectMany(linq.Range(1, 3), func(item int) []int {
+					return []int{item, -item}
+				})
+			},
+			want: []int{1, -1, 2, -2, 3, -3},
+		},
+		{
+			name: "Skip",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Skip(2)
+			},
+			want: []int{8, 3, 1, 8},
+		},
+		{
+			name: "Skip, opaque source",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Where(isEven).Skip(1)
+			},
+			want: []int{8},
+		},
+		{
+			name: "SkipLast",
+			newSource: func() linq.Iterator[int] {
+				return numbers().SkipLast(2)
+			},
+			want: []int{5, 3, 8, 3},
+		},
+		{
+			name: "SkipLast, opaque source",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Concat(linq.Range(1, 2)).SkipLast(3)
+			},
+			want: []int{5, 3, 8, 3, 1},
+		},
+		{
+			name: "SkipWhile",
+			newSource: func() linq.Iterator[int] {
+				return numbers().SkipWhile(isEven)
+			},
+			want: []int{8, 3, 1, 8},
+		},
+		{
+			name: "Take",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Take(4)
+			},
+			want: []int{5, 3, 8, 3},
+		},
+		{
+			name: "Take, opaque source",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Where(isEven).Take(1)
+			},
+			want: []int{8},
+		},
+		{
+			name: "TakeLast",
+			newSource: func() linq.Iterator[int] {
+				return numbers().TakeLast(2)
+			},
+			want: []int{1, 8},
+		},
+		{
+			name: "TakeLast, opaque source",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Concat(linq.Range(1, 2)).TakeLast(3)
+			},
+			want: []int{8, 1, 2},
+		},
+		{
+			name: "TakeWhile",
+			newSource: func() linq.Iterator[int] {
+				return numbers().TakeWhile(isEven)
+			},
+			want: []int{5, 3},
+		},
+		{
+			name: "Where",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Where(isEven)
+			},
+			want: []int{8, 8},
+		},
+		{
+			name: "ZipWith",
+			newSource: func() linq.Iterator[int] {
+				return linq.ZipWith(numbers(), linq.Range(1, 3), func(first int, second int) int {
+					return first + second
+				})
+			},
+			want: []int{6, 5, 11},
+		},
+		{
+			name: "MergeSorted",
+			newSource: func() linq.Iterator[int] {
+				return linq.MergeSorted(func(x, y int) int {
+					return x - y
+				}, linq.FromSlice([]int{1, 4, 6}), linq.Range(2, 3))
+			},
+			want: []int{1, 2, 3, 4, 4, 6},
+		},
+		{
+			name: "Interleave",
+			newSource: func() linq.Iterator[int] {
+				return linq.Interleave(linq.FromSlice([]int{1, 2, 3}), linq.FromSlice([]int{10}))
+			},
+			want: []int{1, 10, 2, 3},
+		},
+		{
+			name: "Alternate",
+			newSource: func() linq.Iterator[int] {
+				return linq.Alternate(linq.FromSlice([]int{1, 2, 3}), linq.FromSlice([]int{10, 20}))
+			},
+			want: []int{1, 10, 2, 20, 3},
+		},
+		{
+			name: "Memoize",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Where(isEven).Memoize()
+			},
+			want: []int{8, 8},
+		},
+		{
+			name: "Share",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Share()
+			},
+			want:    []int{5, 3, 8, 3, 1, 8},
+			options: []linqtest.Option{linqtest.SinglePass()},
+		},
+		{
+			name: "Publish",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Publish(2)[1]
+			},
+			want:    []int{5, 3, 8, 3, 1, 8},
+			options: []linqtest.Option{linqtest.SinglePass()},
+		},
+		{
+			name: "Tee",
+			newSource: func() linq.Iterator[int] {
+				return linq.Tee(numbers(), 2)[0]
+			},
+			want:    []int{5, 3, 8, 3, 1, 8},
+			options: []linqtest.Option{linqtest.SinglePass()},
+		},
+		{
+			name: "Partition",
+			newSource: func() linq.Iterator[int] {
+				_, unmatched := linq.Partition(numbers(), isEven)
+				return unmatched
+			},
+			want:    []int{5, 3, 3, 1},
+			options: []linqtest.Option{linqtest.SinglePass()},
+		},
+		{
+			name: "UnzipLazy",
+			newSource: func() linq.Iterator[int] {
+				_, second := linq.UnzipLazy(linq.Zip(numbers(), linq.Range(1, 3)))
+				return second
+			},
+			want:    []int{1, 2, 3},
+			options: []linqtest.Option{linqtest.SinglePass()},
+		},
+		{
+			name: "Tap",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Tap(func(int) {})
+			},
+			want: []int{5, 3, 8, 3, 1, 8},
+		},
+		{
+			name: "Trace",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Trace("numbers", slog.New(slog.NewTextHandler(io.Discard, nil)))
+			},
+			want: []int{5, 3, 8, 3, 1, 8},
+		},
+		{
+			name: "Measure",
+			newSource: func() linq.Iterator[int] {
+				return linq.Measure(&linq.Metrics{}, "evens", numbers(), func(source linq.Iterator[int]) linq.Iterator[int] {
+					return source.Where(isEven)
+				})
+			},
+			want: []int{8, 8},
+		},
+		{
+			name: "Shuffle",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Shuffle(rand.New(rand.NewPCG(1, 2)))
+			},
+			want:    []int{5, 3, 8, 3, 1, 8},
+			options: []linqtest.Option{linqtest.Unordered()},
+		},
+		{
+			name: "Sample",
+			newSource: func() linq.Iterator[int] {
+				return numbers().Sample(6, rand.New(rand.NewPCG(1, 2)))
+			},
+			want:    []int{5, 3, 8, 3, 1, 8},
+			options: []linqtest.Option{linqtest.Unordered()},
+		},
+		{
+			name: "WeightedSample",
+			newSource: func() linq.Iterator[int] {
+				return numbers().WeightedSample(6, func(item int) float64 {
+					return float64(item)
+				}, rand.New(rand.NewPCG(1, 2)))
+			},
+			want:    []int{5, 3, 8, 3, 1, 8},
+			options: []linqtest.Option{linqtest.Unordered()},
+		},
+		{
+			name: "TopK",
+			newSource: func() linq.Iterator[int] {
+				return linq.TopK(numbers(), 3)
+			},
+			want: []int{8, 8, 5},
+		},
+		{
+			name: "BottomK",
+			newSource: func() linq.Iterator[int] {
+				return linq.BottomK(numbers(), 3)
+			},
+			want: []int{1, 3, 3},
+		},
+		{
+			name: "TopKBy",
+			newSource: func() linq.Iterator[int] {
+				return linq.TopKBy(numbers(), 2, func(item int) int {
+					return -item
+				})
+			},
+			want: []int{1, 3},
+		},
+		{
+			name: "BottomKBy",
+			newSource: func() linq.Iterator[int] {
+				return linq.BottomKBy(numbers(), 2, func(item int) int {
+					return -item
+				})
+			},
+			want: []int{8, 8},
+		},
+		{
+			name: "OrderedIterator.Iterator",
+			newSource: func() linq.Iterator[int] {
+				return linq.AsOrdered(linq.FromSlice([]int{1, 3, 3, 5})).Iterator()
+			},
+			want: []int{1, 3, 3, 5},
+		},
+		{
+			name: "OrderedIterator.BottomK",
+			newSource: func() linq.Iterator[int] {
+				return linq.AsOrdered(linq.FromSlice([]int{1, 3, 3, 5})).BottomK(2)
+			},
+			want: []int{1, 3},
+		},
+		{
+			name: "OrderedIterator.TopK",
+			newSource: func() linq.Iterator[int] {
+				return linq.AsOrdered(linq.FromSlice([]int{1, 3, 3, 5})).TopK(2)
+			},
+			want: []int{5, 3},
+		},
+		{
+			name: "OrderDescending, Where, Reverse, Take",
+			newSource: func() linq.Iterator[int] {
+				return numbers().OrderDescending().Where(isEven).Reverse().Take(3)
+			},
+			want: []int{8, 8},
+		},
+		{
+			name: "Flatten",
+			newSource: func() linq.Iterator[int] {
+				return linq.Flatten(linq.FromSlice([]linq.Iterator[int]{linq.Range(1, 2), nil, linq.Repeat(0, 2)}))
+			},
+			want: []int{1, 2, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linqtest.CheckIterator(t, tt.newSource, tt.want, tt.options...)
+		})
+	}
+}
+
+func TestConformance_pairs(t *testing.T) {
+	t.Run("Zip", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[generic.ValuePair[int, string]] {
+			return linq.Zip(linq.Range(1, 3), linq.FromSlice([]string{"a", "b"}))
+		}, []generic.ValuePair[int, string]{{Item1: 1, Item2: "a"}, {Item1: 2, Item2: "b"}})
+	})
+	t.Run("ZipLongest", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[linq.ZipLongestPair[int, string]] {
+			return linq.ZipLongest(linq.Range(1, 2), linq.FromSlice([]string{"a"}))
+		}, []linq.ZipLongestPair[int, string]{{Item1: 1, Item2: "a", HasItem1: true, HasItem2: true}, {Item1: 2, HasItem1: true}})
+	})
+	t.Run("ZipStrict", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[generic.ValuePair[int, string]] {
+			return values(t, linq.ZipStrict(linq.Range(1, 2), linq.FromSlice([]string{"a", "b"})))
+		}, []generic.ValuePair[int, string]{{Item1: 1, Item2: "a"}, {Item1: 2, Item2: "b"}})
+	})
+	t.Run("Zip3", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[linq.ValueTriple[int, int, int]] {
+			return linq.Zip3(linq.Range(1, 2), linq.Range(10, 2), linq.Range(100, 3))
+		}, []linq.ValueTriple[int, int, int]{{Item1: 1, Item2: 10, Item3: 100}, {Item1: 2, Item2: 11, Item3: 101}})
+	})
+	t.Run("ZipN", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[[]int] {
+			return linq.ZipN(linq.Range(1, 2), linq.Range(10, 2))
+		}, [][]int{{1, 10}, {2, 11}})
+	})
+	t.Run("Chunk", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[[]int] {
+			return linq.Chunk(linq.Range(1, 5), 2)
+		}, [][]int{{1, 2}, {3, 4}, {5}})
+	})
+	t.Run("GroupBy", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[group] {
+			return groups(linq.GroupBy(linq.Range(1, 5), func(item int) int {
+				return item % 2
+			}))
+		}, []group{{Key: 1, Elements: []int{1, 3, 5}}, {Key: 0, Elements: []int{2, 4}}}, linqtest.Unordered())
+	})
+}
+
+func TestConformance_traversals(t *testing.T) {
+	edges := map[int][]int{1: {2, 3}, 2: {4}, 3: {4}}
+	children := func(node int) linq.Iterator[int] {
+		return linq.FromSlice(edges[node])
+	}
+	identity := func(node int) int {
+		return node
+	}
+	t.Run("TraverseDepthFirst", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.TraverseDepthFirst(1, children)
+		}, []int{1, 2, 4, 3, 4})
+	})
+	t.Run("TraverseDepthFirstBy", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.TraverseDepthFirstBy(1, children, identity)
+		}, []int{1, 2, 4, 3})
+	})
+	t.Run("TraverseDepthFirstWithPath", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[linq.TraversalNode[int]] {
+			return linq.TraverseDepthFirstWithPath(1, children)
+		}, []linq.TraversalNode[int]{
+			{Value: 1, Depth: 0, Path: []int{1}},
+			{Value: 2, Depth: 1, Path: []int{1, 2}},
+			{Value: 4, Depth: 2, Path: []int{1, 2, 4}},
+			{Value: 3, Depth: 1, Path: []int{1, 3}},
+			{Value: 4, Depth: 2, Path: []int{1, 3, 4}},
+		})
+	})
+	t.Run("TraverseDepthFirstByWithPath", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[linq.TraversalNode[int]] {
+			return linq.TraverseDepthFirstByWithPath(1, children, identity)
+		}, []linq.TraversalNode[int]{
+			{Value: 1, Depth: 0, Path: []int{1}},
+			{Value: 2, Depth: 1, Path: []int{1, 2}},
+			{Value: 4, Depth: 2, Path: []int{1, 2, 4}},
+			{Value: 3, Depth: 1, Path: []int{1, 3}},
+		})
+	})
+	t.Run("TraverseBreadthFirst", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.TraverseBreadthFirst(1, children)
+		}, []int{1, 2, 3, 4, 4})
+	})
+	t.Run("TraverseBreadthFirstBy", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.TraverseBreadthFirstBy(1, children, identity)
+		}, []int{1, 2, 3, 4})
+	})
+	t.Run("TraverseBreadthFirstWithPath", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[linq.TraversalNode[int]] {
+			return linq.TraverseBreadthFirstWithPath(1, children)
+		}, []linq.TraversalNode[int]{
+			{Value: 1, Depth: 0, Path: []int{1}},
+			{Value: 2, Depth: 1, Path: []int{1, 2}},
+			{Value: 3, Depth: 1, Path: []int{1, 3}},
+			{Value: 4, Depth: 2, Path: []int{1, 2, 4}},
+			{Value: 4, Depth: 2, Path: []int{1, 3, 4}},
+		})
+	})
+	t.Run("TraverseBreadthFirstByWithPath", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[linq.TraversalNode[int]] {
+			return linq.TraverseBreadthFirstByWithPath(1, children, identity)
+		}, []linq.TraversalNode[int]{
+			{Value: 1, Depth: 0, Path: []int{1}},
+			{Value: 2, Depth: 1, Path: []int{1, 2}},
+			{Value: 3, Depth: 1, Path: []int{1, 3}},
+			{Value: 4, Depth: 2, Path: []int{1, 2, 4}},
+		})
+	})
+	t.Run("TopologicalSort", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			result, err := linq.TopologicalSort(linq.Range(1, 4), identity, children)
+			if err != nil {
+				t.Fatalf("TopologicalSort() error = %v, want nil", err)
+			}
+			return result
+		}, []int{4, 2, 3, 1})
+	})
+	t.Run("TransitiveClosure", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[group] {
+			return groups(must(linq.TransitiveClosure(linq.Range(1, 4), identity, children)))
+		}, []group{{Key: 1, Elements: []int{2, 3, 4}}, {Key: 2, Elements: []int{4}}, {Key: 3, Elements: []int{4}}, {Key: 4, Elements: []int{}}})
+	})
+	t.Run("ConnectedComponents", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[[]int] {
+			return linq.Select(must(linq.ConnectedComponents(linq.Range(1, 5), identity, children)), linq.Iterator[int].ToSlice)
+		}, [][]int{{1, 2, 3, 4}, {5}})
+	})
+	t.Run("ShortestPathBFS", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			result, err := linq.ShortestPathBFS(linq.Range(1, 4), identity, children, 1, 4)
+			if err != nil {
+				t.Fatalf("ShortestPathBFS() error = %v, want nil", err)
+			}
+			return result
+		}, []int{1, 2, 4})
+	})
+}
+
+func TestConformance_external(t *testing.T) {
+	options := func(t *testing.T) linq.SpillOptions[int] {
+		return linq.SpillOptions[int]{
+			MaxBytesInMemory: 16,
+			TempDir:          t.TempDir(),
+		}
+	}
+	numbers := linq.FromSlice([]int{5, 3, 8, 3, 1, 8})
+	t.Run("OrderExternal", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return values(t, linq.OrderExternal(numbers, options(t)))
+		}, []int{1, 3, 3, 5, 8, 8})
+	})
+	t.Run("OrderByExternal", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return values(t, linq.OrderByExternal(numbers, func(item int) int {
+				return -item
+			}, options(t)))
+		}, []int{8, 8, 5, 3, 3, 1})
+	})
+	t.Run("GroupByExternal", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.Select(values(t, linq.GroupByExternal(numbers, func(item int) int {
+				return item
+			}, options(t))), func(group generic.KeyValuePair[int, linq.Iterator[int]]) int {
+				return group.Value.Count()
+			})
+		}, []int{1, 2, 1, 2})
+	})
+	t.Run("DistinctExternal", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return values(t, linq.DistinctExternal(numbers, options(t)))
+		}, []int{1, 3, 5, 8})
+	})
+	t.Run("GroupBy, spilled", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.Select(linq.GroupBy(numbers, func(item int) int {
+				return item
+			}, options(t)), func(group generic.KeyValuePair[int, linq.Iterator[int]]) int {
+				return group.Value.Count()
+			})
+		}, []int{1, 2, 1, 2})
+	})
+	t.Run("Distinct, spilled", func(t *testing.T) {
+		linqtest.CheckIterator(t, func() linq.Iterator[int] {
+			return linq.Distinct(numbers, options(t))
+		}, []int{1, 3, 5, 8})
+	})
+}
@@ -0,0 +1,143 @@
+package linq
+
+import "slices"
+
+// A cursor over the elements of an Iterator, with lookahead.
+//
+// An Enumerator is created by Iterator.GetEnumerator. It enumerates the source once, pulling elements on demand.
+// Call Close when the Enumerator is no longer needed, to release the source before it is exhausted.
+// An Enumerator must not be used from several goroutines at the same time.
+type Enumerator[TSource any] struct {
+	source  *puller[TSource]
+	current TSource
+	ahead   []TSource
+}
+
+// Returns an Enumerator that pulls the elements of a sequence one by one.
+//
+// # Returns
+//
+//	result *Enumerator[TSource]
+//
+// An Enumerator positioned before the first element of the source sequence.
+func (source Iterator[TSource]) GetEnumerator() (result *Enumerator[TSource]) {
+	return &Enumerator[TSource]{
+		source: newPuller(source),
+	}
+}
+
+// Pulls the next element from the source and appends it to the lookahead buffer.
+func (enumerator *Enumerator[TSource]) fill() bool {
+	value, ok := enumerator.source.pull()
+	if ok {
+		enumerator.ahead = append(enumerator.ahead, value)
+	}
+	return ok
+}
+
+// Advances the Enumerator to the next element.
+//
+// # Returns
+//
+//	result bool
+//
+// True if the Enumerator was advanced to the next element; false if it has passed the end of the sequence or has been closed.
+func (enumerator *Enumerator[TSource]) MoveNext() (result bool) {
+	if len(enumerator.ahead) == 0 && !enumerator.fill() {
+		enumerator.current = *new(TSource)
+		return false
+	}
+	enumerator.current = enumerator.ahead[0]
+	enumerator.ahead[0] = *new(TSource)
+	enumerator.ahead = enumerator.ahead[1:]
+	return true
+}
+
+// Returns the element at the current position of the Enumerator.
+//
+// # Returns
+//
+//	result TSource
+//
+// The element the last successful call to MoveNext advanced to, or the zero value before the first call and after the end of the sequence.
+func (enumerator *Enumerator[TSource]) Current() (result TSource) {
+	return enumerator.current
+}
+
+// Returns the element that the next call to MoveNext will advance to, without advancing.
+//
+// # Returns
+//
+//	result TSource
+//
+// The next element.
+//
+//	ok bool
+//
+// True if there is a next element; otherwise, false.
+func (enumerator *Enumerator[TSource]) Peek() (result TSource, ok bool) {
+	if len(enumerator.ahead) == 0 && !enumerator.fill() {
+		return result, false
+	}
+	return enumerator.ahead[0], true
+}
+
+// Returns the next k elements, without advancing.
+//
+// # Parameters
+//
+//	k int
+//
+// The number of elements to look ahead.
+//
+// # Returns
+//
+//	result []TSource
+//
+// The next k elements, or fewer if the sequence ends before. The slice is a copy and may be modified.
+func (enumerator *Enumerator[TSource]) PeekN(k int) (result []TSource) {
+	for len(enumerator.ahead) < k && enumerator.fill() {
+	}
+	return slices.Clone(enumerator.ahead[:min(max(k, 0), len(enumerator.ahead))])
+}
+
+// Returns a value to the Enumerator, so that the next call to MoveNext advances to it.
+//
+// # Parameters
+//
+//	value TSource
+//
+// The value to push back. It does not have to be an element of the sequence.
+//
+// # Remarks
+//
+// Values pushed back one after another are returned in reverse order, like a stack.
+// The current element is not changed.
+func (enumerator *Enumerator[TSource]) PushBack(value TSource) {
+	enumerator.ahead = slices.Insert(enumerator.ahead, 0, value)
+}
+
+// Releases the source and discards the elements that were looked ahead or pushed back.
+// After Close, MoveNext returns false until a value is pushed back.
+func (enumerator *Enumerator[TSource]) Close() {
+	enumerator.source.close()
+	enumerator.ahead = nil
+	enumerator.current = *new(TSource)
+}
+
+// Returns the elements the Enumerator has not advanced to yet, typed as Iterator[TSource].
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that advances the Enumerator and yields each element it advances to.
+//
+// # Remarks
+//
+// The elements that were looked ahead or pushed back come first.
+// Enumerating the result consumes the Enumerator. When the enumeration stops early, the Enumerator stays positioned at the last yielded element,
+// so a later enumeration of the result, or a call to MoveNext, continues from there.
+func (enumerator *Enumerator[TSource]) Remaining() (result Iterator[TSource]) {
+	return FromEnumerator(enumerator)
+}
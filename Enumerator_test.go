@@ -0,0 +1,92 @@
+package linq
+
+import (
+	"reflect"
+	"testing"
+	"unicode"
+)
+
+func TestEnumerator(t *testing.T) {
+	enumerator := FromSlice([]int{1, 2, 3, 4, 5}).GetEnumerator()
+	defer enumerator.Close()
+	if got := enumerator.Current(); got != 0 {
+		t.Errorf("Enumerator.Current() before MoveNext = %v, want 0", got)
+	}
+	if got, ok := enumerator.Peek(); !ok || got != 1 {
+		t.Errorf("Enumerator.Peek() = %v, %v, want 1, true", got, ok)
+	}
+	if !enumerator.MoveNext() || enumerator.Current() != 1 {
+		t.Errorf("Enumerator.MoveNext() did not advance to 1")
+	}
+	if got, want := enumerator.PeekN(3), []int{2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Enumerator.PeekN() = %v, want %v", got, want)
+	}
+	if got, want := enumerator.PeekN(10), []int{2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Enumerator.PeekN() = %v, want %v", got, want)
+	}
+	enumerator.PushBack(10)
+	enumerator.PushBack(20)
+	if got, want := enumerator.Remaining().Take(3).ToSlice(), []int{20, 10, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Enumerator.Remaining() = %v, want %v", got, want)
+	}
+	if got := enumerator.Current(); got != 2 {
+		t.Errorf("Enumerator.Current() = %v, want 2", got)
+	}
+	if got, want := enumerator.Remaining().ToSlice(), []int{3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Enumerator.Remaining() = %v, want %v", got, want)
+	}
+	if enumerator.MoveNext() {
+		t.Errorf("Enumerator.MoveNext() after the end = true, want false")
+	}
+	if _, ok := enumerator.Peek(); ok {
+		t.Errorf("Enumerator.Peek() after the end = true, want false")
+	}
+}
+
+func TestEnumerator_Close(t *testing.T) {
+	stopped := false
+	source := Iterator[int](func(yield func(int) bool) {
+		defer func() {
+			stopped = true
+		}()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	})
+	enumerator := source.GetEnumerator()
+	enumerator.MoveNext()
+	enumerator.PeekN(2)
+	enumerator.Close()
+	if !stopped {
+		t.Errorf("Enumerator.Close() did not stop the source")
+	}
+	if enumerator.MoveNext() {
+		t.Errorf("Enumerator.MoveNext() after Close = true, want false")
+	}
+}
+
+func TestEnumerator_Tokenizer(t *testing.T) {
+	enumerator := FromString("x1 = 42+y").GetEnumerator()
+	defer enumerator.Close()
+	tokens := make([]string, 0)
+	for enumerator.MoveNext() {
+		r := enumerator.Current()
+		switch {
+		case unicode.IsSpace(r):
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			token := []rune{r}
+			for next, ok := enumerator.Peek(); ok && (unicode.IsLetter(next) || unicode.IsDigit(next)); next, ok = enumerator.Peek() {
+				enumerator.MoveNext()
+				token = append(token, next)
+			}
+			tokens = append(tokens, string(token))
+		default:
+			tokens = append(tokens, string(r))
+		}
+	}
+	if want := []string{"x1", "=", "42", "+", "y"}; !reflect.DeepEqual(tokens, want) {
+		t.Errorf("tokens = %v, want %v", tokens, want)
+	}
+}
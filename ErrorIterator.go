@@ -0,0 +1,62 @@
+package linq
+
+import "iter"
+
+// An iterator over a sequence that may fail while it is enumerated.
+//
+// Every element is yielded together with a nil error. When the enumeration fails,
+// a zero element is yielded together with the non-nil error, and the enumeration ends.
+type ErrorIterator[TSource any] iter.Seq2[TSource, error]
+
+// Creates a slice of TSource from an ErrorIterator[TSource].
+//
+// # Returns
+//
+//	result []TSource
+//
+// A slice of TSource that contains the elements yielded before the enumeration ended or failed.
+//
+// # Error
+//
+//	err error
+//
+// The error the enumeration failed with, or nil.
+func (source ErrorIterator[TSource]) ToSlice() (result []TSource, err error) {
+	result = make([]TSource, 0)
+	for item, err := range source {
+		if err != nil {
+			return result, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// Returns the elements of an ErrorIterator[TSource] typed as Iterator[TSource] and a function that reports the error.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains the elements yielded before the enumeration ended or failed.
+//
+//	err func() error
+//
+// A function that returns the error the last enumeration of result failed with, or nil.
+func (source ErrorIterator[TSource]) Values() (result Iterator[TSource], err func() error) {
+	var failure error
+	return func(yield func(value TSource) bool) {
+			failure = nil
+			for item, err := range source {
+				if err != nil {
+					failure = err
+					return
+				}
+				if !yield(item) {
+					return
+				}
+			}
+		}, func() error {
+			return failure
+		}
+}
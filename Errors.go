@@ -8,3 +8,12 @@ var ErrMoreThanOneElementSatisfiesTheConditionInPredicate = errors.New("more tha
 var ErrSourceHasMoreThanOneElement = errors.New("the source has more than one element")
 var ErrSizeIsBelowOne = errors.New("size is below 1")
 var ErrIndexOutOfRange = errors.New("index out of range")
+var ErrCycleDetected = errors.New("cycle detected")
+var ErrDuplicateKey = errors.New("duplicate key")
+var ErrKeyNotFound = errors.New("key not found")
+var ErrNoPathFound = errors.New("no path found")
+var ErrLengthMismatch = errors.New("the sequences have different lengths")
+var ErrTypeIsNotOrdered = errors.New("the type is not ordered")
+var ErrStepIsZero = errors.New("step is zero")
+var ErrPageIsBelowOne = errors.New("page is below 1")
+var ErrInvalidCursor = errors.New("invalid cursor")
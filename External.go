@@ -0,0 +1,599 @@
+package linq
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+// Encodes and decodes the elements that are spilled to disk.
+//
+// NewEncoder and NewDecoder are called once per file. The function returned by NewDecoder returns io.EOF after the last element.
+type Codec[TSource any] struct {
+	NewEncoder func(w io.Writer) func(value TSource) error
+	NewDecoder func(r io.Reader) func(value *TSource) error
+}
+
+// Returns a Codec that uses encoding/gob.
+//
+// # Returns
+//
+//	result Codec[TSource]
+//
+// A Codec that writes each file as a single gob stream.
+func GobCodec[TSource any]() (result Codec[TSource]) {
+	return Codec[TSource]{
+		NewEncoder: func(w io.Writer) func(value TSource) error {
+			encoder := gob.NewEncoder(w)
+			return func(value TSource) error {
+				return encoder.Encode(value)
+			}
+		},
+		NewDecoder: func(r io.Reader) func(value *TSource) error {
+			decoder := gob.NewDecoder(r)
+			return func(value *TSource) error {
+				return decoder.Decode(value)
+			}
+		},
+	}
+}
+
+// The memory budget, in bytes, when SpillOptions.MaxBytesInMemory is not set.
+const DefaultMaxBytesInMemory = 256 << 20
+
+// The number of sorted runs merged at once.
+const spillFanIn = 64
+
+// Configures the operators that spill to disk: OrderExternal, OrderByExternal, GroupByExternal and DistinctExternal,
+// and GroupBy and the package-level Distinct when it is passed to them.
+//
+// The external operators yield the error of a temporary file. GroupBy and Distinct return an Iterator, which cannot report
+// it, so they panic with it instead. Order and OrderBy do not spill; call OrderExternal and OrderByExternal where the
+// elements may not fit in memory.
+type SpillOptions[TSource any] struct {
+
+	// The memory budget, in bytes, of the elements held in memory before they are spilled to disk, as estimated by SizeOf.
+	// If not positive, DefaultMaxBytesInMemory is used.
+	MaxBytesInMemory int
+
+	// Returns the estimated number of bytes an element takes in memory. If nil, the estimate is the size of TSource plus
+	// the bytes of the strings and slices it holds, directly or in nested arrays and structs; what pointers, maps and
+	// interfaces refer to is not counted, so pass SizeOf for elements that hold data through them.
+	SizeOf func(value TSource) int
+
+	// The directory to create temporary files in. If empty, os.TempDir() is used.
+	TempDir string
+
+	// The codec of the elements. If NewEncoder or NewDecoder is nil, GobCodec is used.
+	Codec Codec[TSource]
+}
+
+// The temporary files of one enumeration of an operator that spills to disk.
+type spill[TSource any] struct {
+	limit   int
+	sizeOf  func(value TSource) int
+	tempDir string
+	codec   Codec[TSource]
+	dir     string
+	err     error
+}
+
+func newSpill[TSource any](options SpillOptions[TSource]) *spill[TSource] {
+	files := &spill[TSource]{
+		limit:   options.MaxBytesInMemory,
+		sizeOf:  options.SizeOf,
+		tempDir: options.TempDir,
+		codec:   options.Codec,
+	}
+	if files.limit <= 0 {
+		files.limit = DefaultMaxBytesInMemory
+	}
+	if files.sizeOf == nil {
+		files.sizeOf = defaultSizeOf[TSource]()
+	}
+	if files.codec.NewEncoder == nil || files.codec.NewDecoder == nil {
+		files.codec = GobCodec[TSource]()
+	}
+	return files
+}
+
+// Returns the default SpillOptions.SizeOf of TSource. The type is inspected once; the returned function reads only the
+// strings and slices of an element, and returns a constant if TSource holds none.
+func defaultSizeOf[TSource any]() func(value TSource) int {
+	t := reflect.TypeFor[TSource]()
+	size := int(t.Size())
+	if !holdsBytes(t) {
+		return func(value TSource) int {
+			return size
+		}
+	}
+	return func(value TSource) int {
+		return size + heldBytes(reflect.ValueOf(&value).Elem())
+	}
+}
+
+// Reports whether a value of type t can hold strings or slices outside of its own size, other than through pointers,
+// maps and interfaces.
+func holdsBytes(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Slice:
+		return true
+	case reflect.Array:
+		return t.Len() > 0 && holdsBytes(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if holdsBytes(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns the bytes of the strings and slices a value holds, not counting its own size.
+func heldBytes(value reflect.Value) (result int) {
+	switch value.Kind() {
+	case reflect.String:
+		return value.Len()
+	case reflect.Slice:
+		result = value.Cap() * int(value.Type().Elem().Size())
+		if holdsBytes(value.Type().Elem()) {
+			for i := range value.Len() {
+				result += heldBytes(value.Index(i))
+			}
+		}
+	case reflect.Array:
+		if holdsBytes(value.Type().Elem()) {
+			for i := range value.Len() {
+				result += heldBytes(value.Index(i))
+			}
+		}
+	case reflect.Struct:
+		for i := range value.NumField() {
+			result += heldBytes(value.Field(i))
+		}
+	}
+	return result
+}
+
+// Records the first error. Returns true if err is nil.
+func (files *spill[TSource]) check(err error) bool {
+	if err != nil && files.err == nil {
+		files.err = err
+	}
+	return err == nil
+}
+
+// Removes every file of the enumeration.
+func (files *spill[TSource]) close() {
+	if files.dir != "" {
+		files.check(os.RemoveAll(files.dir))
+		files.dir = ""
+	}
+}
+
+type spillWriter[TSource any] struct {
+	path   string
+	file   *os.File
+	buffer *bufio.Writer
+	encode func(value TSource) error
+}
+
+func (files *spill[TSource]) create() (writer *spillWriter[TSource], err error) {
+	if files.dir == "" {
+		if files.dir, err = os.MkdirTemp(files.tempDir, "linq-spill-"); err != nil {
+			return nil, err
+		}
+	}
+	file, err := os.CreateTemp(files.dir, "run-")
+	if err != nil {
+		return nil, err
+	}
+	buffer := bufio.NewWriter(file)
+	return &spillWriter[TSource]{
+		path:   file.Name(),
+		file:   file,
+		buffer: buffer,
+		encode: files.codec.NewEncoder(buffer),
+	}, nil
+}
+
+func (writer *spillWriter[TSource]) write(value TSource) error {
+	return writer.encode(value)
+}
+
+// Flushes and closes the file. Closing a closed writer does nothing.
+func (writer *spillWriter[TSource]) close() error {
+	if writer == nil || writer.file == nil {
+		return nil
+	}
+	err := writer.buffer.Flush()
+	err = errors.Join(err, writer.file.Close())
+	writer.file = nil
+	return err
+}
+
+// Writes a sequence to a new file and returns its path.
+func (files *spill[TSource]) write(source Iterator[TSource]) (path string, ok bool) {
+	writer, err := files.create()
+	if !files.check(err) {
+		return "", false
+	}
+	defer writer.close()
+	for item := range source {
+		if !files.check(writer.write(item)) {
+			return "", false
+		}
+	}
+	return writer.path, files.check(writer.close())
+}
+
+// Returns the elements of a file. The file is removed after it has been read to the end.
+func (files *spill[TSource]) read(path string) Iterator[TSource] {
+	return func(yield func(value TSource) bool) {
+		file, err := os.Open(path)
+		if !files.check(err) {
+			return
+		}
+		defer file.Close()
+		decode := files.codec.NewDecoder(bufio.NewReader(file))
+		for {
+			var value TSource
+			if err := decode(&value); err != nil {
+				if err == io.EOF {
+					file.Close()
+					files.check(os.Remove(path))
+				} else {
+					files.check(err)
+				}
+				return
+			}
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Merges sorted runs, fanIn at a time, until at most fanIn are left. The runs are merged in order, so ties stay in source order.
+func (files *spill[TSource]) reduce(paths []string, compare generic.Comparison[TSource]) ([]string, bool) {
+	for len(paths) > spillFanIn {
+		runs := make([]Iterator[TSource], spillFanIn)
+		for i, path := range paths[:spillFanIn] {
+			runs[i] = files.read(path)
+		}
+		path, ok := files.write(MergeSorted(compare, runs...))
+		if !ok || files.err != nil {
+			return nil, false
+		}
+		paths = append([]string{path}, paths[spillFanIn:]...)
+	}
+	return paths, true
+}
+
+// Sorts source stably by compare, spilling sorted runs to disk. If unique is true, only the first, in source order, of the
+// elements that compare equal is yielded, and the duplicates are dropped from each run before it is written.
+func orderExternal[TSource any](source Iterator[TSource], options SpillOptions[TSource], compare generic.Comparison[TSource], unique bool) ErrorIterator[TSource] {
+	equal := func(x, y TSource) bool {
+		return compare(x, y) == 0
+	}
+	return func(yield func(value TSource, err error) bool) {
+		files := newSpill(options)
+		defer files.close()
+		run := make([]TSource, 0)
+		paths := make([]string, 0)
+		held := 0
+		for item := range source {
+			run = append(run, item)
+			held += files.sizeOf(item)
+			if held < files.limit {
+				continue
+			}
+			slices.SortStableFunc(run, compare)
+			if unique {
+				run = slices.CompactFunc(run, equal)
+			}
+			path, ok := files.write(FromSlice(run))
+			if !ok {
+				yield(*new(TSource), files.err)
+				return
+			}
+			paths = append(paths, path)
+			clear(run)
+			run, held = run[:0], 0
+		}
+		slices.SortStableFunc(run, compare)
+		if unique {
+			run = slices.CompactFunc(run, equal)
+		}
+		paths, ok := files.reduce(paths, compare)
+		if !ok {
+			yield(*new(TSource), files.err)
+			return
+		}
+		runs := make([]Iterator[TSource], 0, len(paths)+1)
+		for _, path := range paths {
+			runs = append(runs, files.read(path))
+		}
+		runs = append(runs, FromSlice(run))
+		previous, any := *new(TSource), false
+		for item := range MergeSorted(compare, runs...) {
+			if unique && any && equal(previous, item) {
+				continue
+			}
+			previous, any = item, true
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if files.err != nil {
+			yield(*new(TSource), files.err)
+		}
+	}
+}
+
+// Returns a sequence that yields only an error.
+func failed[TSource any](err error) ErrorIterator[TSource] {
+	return func(yield func(value TSource, err error) bool) {
+		yield(*new(TSource), err)
+	}
+}
+
+// Sorts the elements of a sequence in ascending order, spilling sorted runs to disk when they do not fit in memory.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to sort.
+//
+//	options SpillOptions[TSource]
+//
+// The memory limit, the temporary directory and the codec.
+//
+//	compare generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	result ErrorIterator[TSource]
+//
+// The sorted elements. The sort is stable. If writing or reading a temporary file fails, the error is yielded last,
+// and the elements yielded before it may be incomplete. If compare is omitted and TSource has no natural order,
+// linq.ErrTypeIsNotOrdered is the only thing yielded (see DefaultComparison).
+//
+// # Example
+//
+//	sorted, err := linq.OrderExternal(records, linq.SpillOptions[Record]{
+//		MaxBytesInMemory: 1 << 30,
+//		TempDir:          "/var/tmp",
+//	}, byTimestamp).ToSlice()
+//
+// # Remarks
+//
+// The source is read in runs of about MaxBytesInMemory bytes, as estimated by SizeOf. Each run is sorted in memory and written
+// to a temporary file, and the runs are merged lazily while the result is enumerated, so at most one run plus one element per
+// run are held in memory. The slice that holds a run may take up to twice its estimated size while it grows.
+// If there are many runs, they are first merged into longer runs, 64 at a time, to limit the number of open files.
+// If the whole source fits in memory, nothing is written to disk.
+//
+// The temporary files are removed when the enumeration ends, including when the consumer stops early or panics.
+func OrderExternal[TSource any](source Iterator[TSource], options SpillOptions[TSource], compare ...generic.Comparison[TSource]) (result ErrorIterator[TSource]) {
+	Compare, err := resolveComparison(compare...)
+	if err != nil {
+		return failed[TSource](err)
+	}
+	return orderExternal(source, options, Compare, false)
+}
+
+// Sorts the elements of a sequence in ascending order according to a value, spilling sorted runs to disk when they do not fit in memory.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to sort.
+//
+//	valueSelector generic.ValueSelector[TSource, TValue]
+//
+// A function to extract the value to sort by from an element. It is called on every comparison, so it should be cheap.
+//
+//	options SpillOptions[TSource]
+//
+// The memory limit, the temporary directory and the codec.
+//
+//	compare generic.Comparison[TValue]
+//
+// A function to compare values. [OPTIONAL]
+//
+// # Returns
+//
+//	result ErrorIterator[TSource]
+//
+// The sorted elements. See OrderExternal.
+func OrderByExternal[TSource any, TValue any](source Iterator[TSource], valueSelector generic.ValueSelector[TSource, TValue], options SpillOptions[TSource], compare ...generic.Comparison[TValue]) (result ErrorIterator[TSource]) {
+	Compare, err := resolveComparison(compare...)
+	if err != nil {
+		return failed[TSource](err)
+	}
+	return orderExternal(source, options, func(x, y TSource) int {
+		return Compare(valueSelector(x), valueSelector(y))
+	}, false)
+}
+
+// Groups the elements of a sequence according to a key, spilling sorted runs to disk when they do not fit in memory.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to group.
+//
+//	keySelector generic.KeySelector[TSource, TKey]
+//
+// A function to extract the key of an element. It is called on every comparison, so it should be cheap.
+//
+//	options SpillOptions[TSource]
+//
+// The memory budget, the temporary directory and the codec.
+//
+//	compare generic.Comparison[TKey]
+//
+// A function to compare keys. Elements whose keys compare equal are in the same group. [OPTIONAL]
+//
+// # Returns
+//
+//	result ErrorIterator[generic.KeyValuePair[TKey, Iterator[TSource]]]
+//
+// The groups in ascending order of key, each with the key of its first element and its elements in source order.
+// If writing or reading a temporary file fails, the error is yielded last, and the groups yielded before it may be incomplete.
+// If compare is omitted and TKey has no natural order, linq.ErrTypeIsNotOrdered is the only thing yielded (see DefaultComparison).
+//
+// # Remarks
+//
+// The elements are sorted by key as by OrderByExternal, and the groups are cut from the merged runs, so the elements of one
+// group are held in memory while it is yielded, in addition to the budget of the sort. A single group must fit in memory.
+//
+// Unlike GroupBy without options, which yields the groups in no particular order, GroupByExternal yields them in order of key.
+// GroupBy with options groups as GroupByExternal does, and panics with the error this function yields.
+func GroupByExternal[TSource any, TKey comparable](source Iterator[TSource], keySelector generic.KeySelector[TSource, TKey], options SpillOptions[TSource], compare ...generic.Comparison[TKey]) (result ErrorIterator[generic.KeyValuePair[TKey, Iterator[TSource]]]) {
+	Compare, err := resolveComparison(compare...)
+	if err != nil {
+		return failed[generic.KeyValuePair[TKey, Iterator[TSource]]](err)
+	}
+	sorted := orderExternal(source, options, func(x, y TSource) int {
+		return Compare(keySelector(x), keySelector(y))
+	}, false)
+	return func(yield func(value generic.KeyValuePair[TKey, Iterator[TSource]], err error) bool) {
+		var key TKey
+		group := make([]TSource, 0)
+		for item, err := range sorted {
+			if err != nil {
+				yield(generic.KeyValuePair[TKey, Iterator[TSource]]{}, err)
+				return
+			}
+			itemKey := keySelector(item)
+			if len(group) > 0 && Compare(key, itemKey) != 0 {
+				if !yield(generic.KeyValuePair[TKey, Iterator[TSource]]{
+					Key:   key,
+					Value: FromSlice(group),
+				}, nil) {
+					return
+				}
+				group = make([]TSource, 0)
+			}
+			if len(group) == 0 {
+				key = itemKey
+			}
+			group = append(group, item)
+		}
+		if len(group) > 0 {
+			yield(generic.KeyValuePair[TKey, Iterator[TSource]]{
+				Key:   key,
+				Value: FromSlice(group),
+			}, nil)
+		}
+	}
+}
+
+// Returns the elements of an ErrorIterator as an Iterator that panics with the error the enumeration fails with.
+// GroupBy and Distinct spill through it when they are passed SpillOptions.
+func spilled[TSource any](source ErrorIterator[TSource]) Iterator[TSource] {
+	return func(yield func(value TSource) bool) {
+		for item, err := range source {
+			if err != nil {
+				panic(err)
+			}
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the distinct elements of a sequence, spilling sorted runs to disk when they exceed a memory budget.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to remove duplicate elements from.
+//
+//	options SpillOptions[TSource]
+//
+// The memory budget, the temporary directory and the codec. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// Without options, the distinct elements in source order, as by Iterator.Distinct. With options, the distinct elements
+// in ascending order, as by DistinctExternal.
+//
+// # Panics
+//
+// With linq.ErrTypeIsNotOrdered, when Distinct is called, if options are passed and TSource has no natural order, because
+// the spilled elements are sorted. With the error of a temporary file, while the result is enumerated, if writing or reading
+// it fails. Distinct with options is a convenience: call DistinctExternal to have these errors yielded instead.
+//
+// # Remarks
+//
+// With options, the elements are kept in memory while they fit in the budget, and only then sorted in runs on disk.
+func Distinct[TSource any](source Iterator[TSource], options ...SpillOptions[TSource]) (result Iterator[TSource]) {
+	if len(options) == 0 {
+		return source.Distinct()
+	}
+	distinct := DistinctExternal(source, options[0], must(DefaultComparison[TSource]()))
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Distinct", true, "O(n log n)", []string{parameter("spill", true)}, askPlan(source))
+		},
+	}, spilled(distinct))
+}
+
+// Returns the distinct elements of a sequence, spilling sorted runs to disk when they do not fit in memory.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to remove duplicate elements from.
+//
+//	options SpillOptions[TSource]
+//
+// The memory budget, the temporary directory and the codec.
+//
+//	compare generic.Comparison[TSource]
+//
+// A function to compare elements. Elements that compare equal are duplicates. [OPTIONAL]
+//
+// # Returns
+//
+//	result ErrorIterator[TSource]
+//
+// The distinct elements in ascending order; of the elements that compare equal, the first in source order.
+// If writing or reading a temporary file fails, the error is yielded last, and the elements yielded before it may be incomplete.
+// If compare is omitted and TSource has no natural order, linq.ErrTypeIsNotOrdered is the only thing yielded (see DefaultComparison).
+//
+// # Remarks
+//
+// The elements are sorted as by OrderExternal, and the duplicates are dropped from each run before it is written and while
+// the runs are merged, so a source with many duplicates writes little to disk.
+//
+// Unlike Iterator.Distinct, which yields the elements in source order and compares them for equality, DistinctExternal needs
+// an order. The package-level Distinct with options removes duplicates as DistinctExternal does, and panics with the error
+// this function yields.
+func DistinctExternal[TSource any](source Iterator[TSource], options SpillOptions[TSource], compare ...generic.Comparison[TSource]) (result ErrorIterator[TSource]) {
+	Compare, err := resolveComparison(compare...)
+	if err != nil {
+		return failed[TSource](err)
+	}
+	return orderExternal(source, options, Compare, true)
+}
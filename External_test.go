@@ -0,0 +1,327 @@
+package linq
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+func assertNoSpillFiles(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("temporary files left in %s: %v", dir, entries)
+	}
+}
+
+// Returns options that hold elements elements in memory, each estimated at one byte, and that count the files written to spilled.
+func spillEvery[TSource any](t *testing.T, elements int, spilled *int) SpillOptions[TSource] {
+	codec := GobCodec[TSource]()
+	newEncoder := codec.NewEncoder
+	codec.NewEncoder = func(w io.Writer) func(value TSource) error {
+		*spilled++
+		return newEncoder(w)
+	}
+	return SpillOptions[TSource]{
+		MaxBytesInMemory: elements,
+		SizeOf: func(value TSource) int {
+			return 1
+		},
+		TempDir: t.TempDir(),
+		Codec:   codec,
+	}
+}
+
+func spilledScores(n int) []scored {
+	result := make([]scored, n)
+	for i := range result {
+		result[i] = scored{Name: string(rune('a' + i%26)), Score: (i * 37) % 11}
+	}
+	return result
+}
+
+func TestOrderExternal(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		elements int
+		spilled  int
+	}{
+		{name: "OrderExternal in memory", count: 10, elements: 100, spilled: 0},
+		{name: "OrderExternal with a few runs", count: 50, elements: 7, spilled: 7},
+		{name: "OrderExternal with more runs than are merged at once", count: 300, elements: 2, spilled: 150 + 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spilled := 0
+			options := spillEvery[scored](t, tt.elements, &spilled)
+			source := spilledScores(tt.count)
+			got, err := OrderExternal(oneShot(source...), options, byScore).ToSlice()
+			if err != nil {
+				t.Fatalf("OrderExternal() error = %v", err)
+			}
+			if want := sortedStable(source, byScore); !reflect.DeepEqual(got, want) {
+				t.Errorf("OrderExternal() = %v, want %v", got, want)
+			}
+			if spilled != tt.spilled {
+				t.Errorf("OrderExternal() wrote %d files, want %d", spilled, tt.spilled)
+			}
+			assertNoSpillFiles(t, options.TempDir)
+		})
+	}
+}
+
+func TestOrderByExternal(t *testing.T) {
+	source := spilledScores(40)
+	got, err := OrderByExternal(FromSlice(source), func(item scored) int {
+		return item.Score
+	}, SpillOptions[scored]{
+		MaxBytesInMemory: 6 * int(reflect.TypeFor[scored]().Size()),
+		TempDir:          t.TempDir(),
+	}).ToSlice()
+	if err != nil {
+		t.Fatalf("OrderByExternal() error = %v", err)
+	}
+	if want := sortedStable(source, byScore); !reflect.DeepEqual(got, want) {
+		t.Errorf("OrderByExternal() = %v, want %v", got, want)
+	}
+}
+
+func TestExternal_cleanup(t *testing.T) {
+	t.Run("OrderExternal stopped early", func(t *testing.T) {
+		dir := t.TempDir()
+		for range OrderExternal(Range(0, 100), SpillOptions[int]{MaxBytesInMemory: 80, TempDir: dir}) {
+			break
+		}
+		assertNoSpillFiles(t, dir)
+	})
+	t.Run("OrderExternal consumer panics", func(t *testing.T) {
+		dir := t.TempDir()
+		func() {
+			defer func() {
+				if r := recover(); r != "boom" {
+					t.Errorf("recovered %v, want boom", r)
+				}
+			}()
+			for range OrderExternal(Range(0, 100), SpillOptions[int]{MaxBytesInMemory: 80, TempDir: dir}) {
+				panic("boom")
+			}
+		}()
+		assertNoSpillFiles(t, dir)
+	})
+}
+
+func TestExternal_codecError(t *testing.T) {
+	failure := errors.New("disk full")
+	codec := Codec[int]{
+		NewEncoder: func(w io.Writer) func(value int) error {
+			return func(value int) error {
+				return failure
+			}
+		},
+		NewDecoder: GobCodec[int]().NewDecoder,
+	}
+	dir := t.TempDir()
+	_, err := OrderExternal(Range(0, 10), SpillOptions[int]{MaxBytesInMemory: 24, TempDir: dir, Codec: codec}).ToSlice()
+	if !errors.Is(err, failure) {
+		t.Errorf("OrderExternal() error = %v, want %v", err, failure)
+	}
+	assertNoSpillFiles(t, dir)
+}
+
+func TestGroupByExternal(t *testing.T) {
+	source := spilledScores(60)
+	score := func(item scored) int {
+		return item.Score
+	}
+	want := make([]generic.KeyValuePair[int, []scored], 0)
+	for _, item := range sortedStable(source, byScore) {
+		if last := len(want) - 1; last >= 0 && want[last].Key == item.Score {
+			want[last].Value = append(want[last].Value, item)
+		} else {
+			want = append(want, generic.KeyValuePair[int, []scored]{Key: item.Score, Value: []scored{item}})
+		}
+	}
+	for _, elements := range []int{100, 7, 2} {
+		spilled := 0
+		options := spillEvery[scored](t, elements, &spilled)
+		groups, err := GroupByExternal(oneShot(source...), score, options).ToSlice()
+		if err != nil {
+			t.Fatalf("GroupByExternal() error = %v", err)
+		}
+		got := make([]generic.KeyValuePair[int, []scored], len(groups))
+		for i, group := range groups {
+			got[i] = generic.KeyValuePair[int, []scored]{Key: group.Key, Value: group.Value.ToSlice()}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GroupByExternal() with %d elements in memory = %v, want %v", elements, got, want)
+		}
+		if wantSpilled := len(source) / elements; spilled < wantSpilled {
+			t.Errorf("GroupByExternal() with %d elements in memory wrote %d files, want at least %d", elements, spilled, wantSpilled)
+		}
+		assertNoSpillFiles(t, options.TempDir)
+	}
+}
+
+func TestDistinctExternal(t *testing.T) {
+	source := make([]scored, 0)
+	for i := range 200 {
+		source = append(source, scored{Name: string(rune('a' + i%26)), Score: i % 13})
+	}
+	first := make(map[int]scored)
+	for _, item := range source {
+		if _, ok := first[item.Score]; !ok {
+			first[item.Score] = item
+		}
+	}
+	want := make([]scored, 0, len(first))
+	for score := range 13 {
+		want = append(want, first[score])
+	}
+	for _, elements := range []int{1000, 20, 3} {
+		spilled := 0
+		options := spillEvery[scored](t, elements, &spilled)
+		got, err := DistinctExternal(oneShot(source...), options, byScore).ToSlice()
+		if err != nil {
+			t.Fatalf("DistinctExternal() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("DistinctExternal() with %d elements in memory = %v, want %v", elements, got, want)
+		}
+		if wantSpilled := len(source) / elements; spilled < wantSpilled {
+			t.Errorf("DistinctExternal() with %d elements in memory wrote %d files, want at least %d", elements, spilled, wantSpilled)
+		}
+		assertNoSpillFiles(t, options.TempDir)
+	}
+	t.Run("DistinctExternal of a type that is not ordered", func(t *testing.T) {
+		_, err := DistinctExternal(FromSlice([]bool{true, false}), SpillOptions[bool]{}).ToSlice()
+		if !errors.Is(err, ErrTypeIsNotOrdered) {
+			t.Errorf("DistinctExternal() error = %v, want %v", err, ErrTypeIsNotOrdered)
+		}
+	})
+}
+
+func TestGroupByDistinct_spill(t *testing.T) {
+	source := make([]int, 0)
+	for i := range 100 {
+		source = append(source, (i*37)%11)
+	}
+	byValue := func(item int) int {
+		return item
+	}
+	for _, elements := range []int{1000, 7} {
+		spilled := 0
+		options := spillEvery[int](t, elements, &spilled)
+		keys := make([]int, 0)
+		for group := range GroupBy(oneShot(source...), byValue, options) {
+			if group.Value.Any(func(item int) bool { return item != group.Key }) {
+				t.Errorf("GroupBy() group %v = %v, want only its key", group.Key, group.Value.ToSlice())
+			}
+			keys = append(keys, group.Key)
+		}
+		if want := Range(0, 11).ToSlice(); !reflect.DeepEqual(keys, want) {
+			t.Errorf("GroupBy() with %d elements in memory keys = %v, want %v", elements, keys, want)
+		}
+		if got := Distinct(oneShot(source...), options).ToSlice(); !reflect.DeepEqual(got, Range(0, 11).ToSlice()) {
+			t.Errorf("Distinct() with %d elements in memory = %v, want 0 to 10", elements, got)
+		}
+		if wantSpilled := 0; elements > len(source) && spilled != wantSpilled {
+			t.Errorf("GroupBy() and Distinct() within the budget wrote %d files, want %d", spilled, wantSpilled)
+		}
+		if elements < len(source) && spilled == 0 {
+			t.Errorf("GroupBy() and Distinct() beyond the budget wrote no files")
+		}
+		assertNoSpillFiles(t, options.TempDir)
+	}
+	t.Run("Distinct without options", func(t *testing.T) {
+		if got, want := Distinct(FromSlice([]int{3, 1, 3, 2, 1})).ToSlice(), []int{3, 1, 2}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Distinct() = %v, want %v", got, want)
+		}
+	})
+	t.Run("codec error", func(t *testing.T) {
+		failure := errors.New("disk full")
+		options := SpillOptions[int]{
+			MaxBytesInMemory: 24,
+			TempDir:          t.TempDir(),
+			Codec: Codec[int]{
+				NewEncoder: func(w io.Writer) func(value int) error {
+					return func(value int) error {
+						return failure
+					}
+				},
+				NewDecoder: GobCodec[int]().NewDecoder,
+			},
+		}
+		defer func() {
+			if err, _ := recover().(error); !errors.Is(err, failure) {
+				t.Errorf("GroupBy() panicked with %v, want %v", err, failure)
+			}
+			assertNoSpillFiles(t, options.TempDir)
+		}()
+		for range GroupBy(FromSlice(source), byValue, options) {
+		}
+	})
+	t.Run("type that is not ordered", func(t *testing.T) {
+		defer func() {
+			if err, _ := recover().(error); !errors.Is(err, ErrTypeIsNotOrdered) {
+				t.Errorf("Distinct() panicked with %v, want %v", err, ErrTypeIsNotOrdered)
+			}
+		}()
+		Distinct(FromSlice([]bool{true}), SpillOptions[bool]{})
+	})
+}
+
+func TestSpillOptions_MaxBytesInMemory(t *testing.T) {
+	t.Run("default SizeOf", func(t *testing.T) {
+		type record struct {
+			ID     int64
+			Name   string
+			Tags   []string
+			Parent *int
+		}
+		size := int(reflect.TypeFor[record]().Size())
+		tags := make([]string, 2, 4)
+		tags[0], tags[1] = "ab", "cde"
+		tests := []struct {
+			name string
+			got  int
+			want int
+		}{
+			{name: "int", got: defaultSizeOf[int]()(7), want: 8},
+			{name: "string", got: defaultSizeOf[string]()("hello"), want: 16 + 5},
+			{name: "struct", got: defaultSizeOf[record]()(record{Name: "Ada", Tags: tags}), want: size + 3 + 4*16 + 2 + 3},
+		}
+		for _, tt := range tests {
+			if tt.got != tt.want {
+				t.Errorf("defaultSizeOf() of %s = %d, want %d", tt.name, tt.got, tt.want)
+			}
+		}
+	})
+	t.Run("runs are cut by bytes", func(t *testing.T) {
+		spilled := 0
+		options := spillEvery[string](t, 0, &spilled)
+		options.MaxBytesInMemory, options.SizeOf = 4000, nil
+		source := make([]string, 20)
+		for i := range source {
+			source[i] = strings.Repeat(string(rune('a'+i)), 984)
+		}
+		got, err := OrderExternal(FromSlice(source), options).ToSlice()
+		if err != nil {
+			t.Fatalf("OrderExternal() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, source) {
+			t.Errorf("OrderExternal() = %v, want the source", got)
+		}
+		if spilled != 5 {
+			t.Errorf("OrderExternal() wrote %d files, want 5 runs of 4 strings of 1000 bytes", spilled)
+		}
+	})
+}
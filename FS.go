@@ -0,0 +1,277 @@
+package linq
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// A file or directory found by FromFS, FromDirRecursive or FromGlob.
+type FileEntry struct {
+
+	// The path of the entry in its file system, for example "logs/2024/app.log".
+	Path string
+
+	// The entry, with its name, type and info.
+	fs.DirEntry
+}
+
+// Options of FromDirRecursive.
+type WalkOptions struct {
+
+	// The greatest depth of the entries, where the entries of the directory are at depth 1. If not positive, there is no limit.
+	MaxDepth int
+
+	// Reports whether to skip a directory, neither yielding it nor walking it. If nil, no directory is skipped.
+	SkipDir func(entry FileEntry) bool
+
+	// Whether to yield only the entries that are not directories. Directories are walked all the same.
+	FilesOnly bool
+
+	// Handles an error of reading the entry at a path. If it returns nil, the entry is skipped and the walk goes on;
+	// otherwise the enumeration fails with the error it returns. If nil, every error fails the enumeration.
+	OnError func(path string, err error) error
+}
+
+// Walks a file system with fs.WalkDir and yields the entries that visit includes. The walk descends into a directory
+// only if visit says so, and stops through fs.SkipAll as soon as yield returns false.
+func walk(fsys fs.FS, root string, onError func(path string, err error) error, visit func(entry FileEntry, depth int) (include bool, descend bool)) ErrorIterator[FileEntry] {
+	return func(yield func(value FileEntry, err error) bool) {
+		fs.WalkDir(fsys, root, func(name string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if onError != nil {
+					err = onError(name, err)
+				}
+				if err != nil {
+					yield(FileEntry{}, err)
+					return fs.SkipAll
+				}
+				return nil
+			}
+			item := FileEntry{Path: name, DirEntry: entry}
+			include, descend := visit(item, depthOf(root, name))
+			if include && !yield(item, nil) {
+				return fs.SkipAll
+			}
+			if entry.IsDir() && !descend {
+				return fs.SkipDir
+			}
+			return nil
+		})
+	}
+}
+
+// Returns the number of path elements between root and a path in it.
+func depthOf(root string, name string) int {
+	if name == root {
+		return 0
+	}
+	if root != "." {
+		name = name[len(root)+1:]
+	}
+	return strings.Count(name, "/") + 1
+}
+
+// Returns the files and directories of a file system, walking it lazily.
+//
+// # Parameters
+//
+//	fsys fs.FS
+//
+// The file system to walk.
+//
+//	root string
+//
+// The path of the file or directory to walk, for example "." or "logs/2024".
+//
+// # Returns
+//
+//	result ErrorIterator[FileEntry]
+//
+// An ErrorIterator[FileEntry] that contains root and every file and directory in it, in lexical order, as fs.WalkDir visits them.
+//
+// # Error
+//
+// The enumeration fails with the first error of the walk, such as a root that does not exist or a directory that cannot be read.
+//
+// # Example
+//
+//	entries, failed := linq.FromFS(os.DirFS("/var/log"), ".").Values()
+//	for entry := range entries.Where(func(entry linq.FileEntry) bool { return !entry.IsDir() }) {
+//		fmt.Println(entry.Path)
+//	}
+//	if err := failed(); err != nil {
+//		return err
+//	}
+//
+// # Remarks
+//
+// Directories are read as the enumeration reaches them, and the walk stops when the enumeration is stopped.
+// Each enumeration walks the file system again.
+func FromFS(fsys fs.FS, root string) (result ErrorIterator[FileEntry]) {
+	return walk(fsys, root, nil, func(entry FileEntry, depth int) (include bool, descend bool) {
+		return true, true
+	})
+}
+
+// Returns the files and directories in a directory and its subdirectories, walking them lazily.
+//
+// # Parameters
+//
+//	fsys fs.FS
+//
+// The file system to walk.
+//
+//	dir string
+//
+// The path of the directory to walk, for example "." or "logs/2024".
+//
+//	options ...WalkOptions
+//
+// The depth limit, the directories to skip, whether to yield directories, and how to handle errors. Optional.
+//
+// # Returns
+//
+//	result ErrorIterator[FileEntry]
+//
+// An ErrorIterator[FileEntry] that contains the entries in dir, not dir itself, in lexical order, as fs.WalkDir visits them.
+//
+// # Error
+//
+// The enumeration fails with the first error of the walk that options.OnError does not handle.
+//
+// # Example
+//
+//	sources, failed := linq.FromDirRecursive(fsys, ".", linq.WalkOptions{
+//		FilesOnly: true,
+//		SkipDir:   func(entry linq.FileEntry) bool { return entry.Name() == "vendor" },
+//	}).Values()
+//
+// # Remarks
+//
+// Directories are read as the enumeration reaches them, and the walk stops when the enumeration is stopped.
+// Each enumeration walks the file system again.
+func FromDirRecursive(fsys fs.FS, dir string, options ...WalkOptions) (result ErrorIterator[FileEntry]) {
+	var o WalkOptions
+	if len(options) > 0 {
+		o = options[0]
+	}
+	return walk(fsys, dir, o.OnError, func(entry FileEntry, depth int) (include bool, descend bool) {
+		switch {
+		case depth == 0:
+			return false, true
+		case entry.IsDir() && o.SkipDir != nil && o.SkipDir(entry):
+			return false, false
+		}
+		return !o.FilesOnly || !entry.IsDir(), o.MaxDepth <= 0 || depth < o.MaxDepth
+	})
+}
+
+// Returns the files and directories of a file system whose paths match a pattern, walking it lazily.
+//
+// # Parameters
+//
+//	fsys fs.FS
+//
+// The file system to walk.
+//
+//	pattern string
+//
+// The pattern of the paths, as path.Match takes it, for example "logs/*/app-*.log". The element "**" matches
+// any number of directories, so that "src/**/*.go" matches "src/main.go" and "src/a/b/c.go".
+//
+// # Returns
+//
+//	result ErrorIterator[FileEntry]
+//
+// An ErrorIterator[FileEntry] that contains the entries whose paths match pattern, in lexical order.
+//
+// # Error
+//
+// The enumeration fails with path.ErrBadPattern if pattern is malformed, and with the first error of the walk other than
+// a directory that does not exist.
+//
+// # Example
+//
+//	logs, failed := linq.FromGlob(fsys, "logs/**/*.log").Values()
+//	contents := linq.Select(logs, func(entry linq.FileEntry) string {
+//		data, _ := fs.ReadFile(fsys, entry.Path)
+//		return string(data)
+//	})
+//
+// # Remarks
+//
+// Only the directories that can contain matches are walked: the walk starts below the elements of pattern
+// that have no wildcards, and does not descend into directories whose paths cannot begin a match.
+// Each enumeration walks the file system again.
+func FromGlob(fsys fs.FS, pattern string) (result ErrorIterator[FileEntry]) {
+	elements := strings.Split(pattern, "/")
+	for _, element := range elements {
+		if _, err := path.Match(element, ""); err != nil {
+			return func(yield func(value FileEntry, err error) bool) {
+				yield(FileEntry{}, err)
+			}
+		}
+	}
+	static := 0
+	for static < len(elements)-1 && !strings.ContainsAny(elements[static], `*?[\`) {
+		static++
+	}
+	root := "."
+	if static > 0 {
+		root = path.Join(elements[:static]...)
+	}
+	notExist := func(name string, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return walk(fsys, root, notExist, func(entry FileEntry, depth int) (include bool, descend bool) {
+		if depth == 0 {
+			return false, true
+		}
+		names := strings.Split(entry.Path, "/")
+		return matchGlob(elements, names), entry.IsDir() && beginsGlob(elements, names)
+	})
+}
+
+// Reports whether the elements of a path match the elements of a pattern.
+func matchGlob(pattern []string, names []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := range len(names) + 1 {
+				if matchGlob(pattern[1:], names[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(names) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], names[0]); !matched {
+			return false
+		}
+		pattern, names = pattern[1:], names[1:]
+	}
+	return len(names) == 0
+}
+
+// Reports whether the elements of a directory match the first elements of a pattern, so that the directory can contain matches.
+func beginsGlob(pattern []string, names []string) bool {
+	for len(names) > 0 {
+		switch {
+		case len(pattern) == 0:
+			return false
+		case pattern[0] == "**":
+			return true
+		}
+		if matched, _ := path.Match(pattern[0], names[0]); !matched {
+			return false
+		}
+		pattern, names = pattern[1:], names[1:]
+	}
+	return len(pattern) > 0
+}
@@ -0,0 +1,242 @@
+package linq
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+var testFS = fstest.MapFS{
+	"a.txt":             {Data: []byte("a")},
+	"logs/app.log":      {Data: []byte("started\nfailed: disk full\n")},
+	"logs/2024/jan.log": {Data: []byte("failed: timeout\n")},
+	"logs/2024/feb.txt": {Data: []byte("notes")},
+	"src/main.go":       {Data: []byte("package main")},
+	"src/a/b/c.go":      {Data: []byte("package b")},
+	"vendor/x/y.go":     {Data: []byte("package x")},
+}
+
+// A file system that records the files it opens and fails to open one of them.
+// fs.WalkDir opens the root twice, to stat it and to read it.
+type brokenFS struct {
+	fs.FS
+	broken string
+	opened []string
+}
+
+var errBroken = errors.New("broken")
+
+func (fsys *brokenFS) Open(name string) (fs.File, error) {
+	fsys.opened = append(fsys.opened, name)
+	if name == fsys.broken {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errBroken}
+	}
+	return fsys.FS.Open(name)
+}
+
+func entryPaths(source ErrorIterator[FileEntry]) (result []string, err error) {
+	result = make([]string, 0)
+	for entry, err := range source {
+		if err != nil {
+			return result, err
+		}
+		result = append(result, entry.Path)
+	}
+	return result, nil
+}
+
+func TestFromFS(t *testing.T) {
+	tests := []struct {
+		name string
+		root string
+		want []string
+	}{
+		{
+			name: "root",
+			root: ".",
+			want: []string{".", "a.txt", "logs", "logs/2024", "logs/2024/feb.txt", "logs/2024/jan.log", "logs/app.log",
+				"src", "src/a", "src/a/b", "src/a/b/c.go", "src/main.go", "vendor", "vendor/x", "vendor/x/y.go"},
+		},
+		{
+			name: "directory",
+			root: "logs/2024",
+			want: []string{"logs/2024", "logs/2024/feb.txt", "logs/2024/jan.log"},
+		},
+		{
+			name: "file",
+			root: "a.txt",
+			want: []string{"a.txt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entryPaths(FromFS(testFS, tt.root))
+			if err != nil {
+				t.Fatalf("FromFS() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromFS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, err := entryPaths(FromFS(testFS, "missing")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("FromFS() error = %v, want %v", err, fs.ErrNotExist)
+	}
+}
+
+func TestFromFS_stop(t *testing.T) {
+	fsys := &brokenFS{FS: testFS}
+	for range FromFS(fsys, ".") {
+		break
+	}
+	if want := []string{"."}; !reflect.DeepEqual(fsys.opened, want) {
+		t.Errorf("FromFS() opened %v, want %v", fsys.opened, want)
+	}
+}
+
+func TestFromDirRecursive(t *testing.T) {
+	tests := []struct {
+		name    string
+		dir     string
+		options []WalkOptions
+		want    []string
+	}{
+		{
+			name: "no options",
+			dir:  "logs",
+			want: []string{"logs/2024", "logs/2024/feb.txt", "logs/2024/jan.log", "logs/app.log"},
+		},
+		{
+			name:    "files only",
+			dir:     "logs",
+			options: []WalkOptions{{FilesOnly: true}},
+			want:    []string{"logs/2024/feb.txt", "logs/2024/jan.log", "logs/app.log"},
+		},
+		{
+			name:    "max depth",
+			dir:     ".",
+			options: []WalkOptions{{MaxDepth: 1}},
+			want:    []string{"a.txt", "logs", "src", "vendor"},
+		},
+		{
+			name:    "max depth, files only",
+			dir:     ".",
+			options: []WalkOptions{{MaxDepth: 3, FilesOnly: true}},
+			want:    []string{"a.txt", "logs/2024/feb.txt", "logs/2024/jan.log", "logs/app.log", "src/main.go", "vendor/x/y.go"},
+		},
+		{
+			name: "skip directories",
+			dir:  ".",
+			options: []WalkOptions{{
+				FilesOnly: true,
+				SkipDir: func(entry FileEntry) bool {
+					return entry.Name() == "vendor" || entry.Path == "logs/2024"
+				},
+			}},
+			want: []string{"a.txt", "logs/app.log", "src/a/b/c.go", "src/main.go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entryPaths(FromDirRecursive(testFS, tt.dir, tt.options...))
+			if err != nil {
+				t.Fatalf("FromDirRecursive() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromDirRecursive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromDirRecursive_errors(t *testing.T) {
+	fsys := &brokenFS{FS: testFS, broken: "logs/2024"}
+	got, err := entryPaths(FromDirRecursive(fsys, "logs"))
+	if want := []string{"logs/2024"}; !errors.Is(err, errBroken) || !reflect.DeepEqual(got, want) {
+		t.Errorf("FromDirRecursive() = %v, %v, want %v, %v", got, err, want, errBroken)
+	}
+
+	var skipped []string
+	got, err = entryPaths(FromDirRecursive(fsys, "logs", WalkOptions{
+		OnError: func(path string, err error) error {
+			skipped = append(skipped, path)
+			return nil
+		},
+	}))
+	if want := []string{"logs/2024", "logs/app.log"}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("FromDirRecursive() = %v, %v, want %v, nil", got, err, want)
+	}
+	if want := []string{"logs/2024"}; !reflect.DeepEqual(skipped, want) {
+		t.Errorf("WalkOptions.OnError got %v, want %v", skipped, want)
+	}
+
+	failure := errors.New("unreadable logs")
+	_, err = entryPaths(FromDirRecursive(fsys, "logs", WalkOptions{
+		OnError: func(path string, err error) error {
+			return failure
+		},
+	}))
+	if !errors.Is(err, failure) {
+		t.Errorf("FromDirRecursive() error = %v, want %v", err, failure)
+	}
+}
+
+func TestFromGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []string
+		opened  []string
+	}{
+		{pattern: "*.txt", want: []string{"a.txt"}, opened: []string{".", "."}},
+		{pattern: "logs/*.log", want: []string{"logs/app.log"}, opened: []string{"logs", "logs"}},
+		{pattern: "logs/2024", want: []string{"logs/2024"}, opened: []string{"logs", "logs"}},
+		{pattern: "*/2024/*", want: []string{"logs/2024/feb.txt", "logs/2024/jan.log"}, opened: []string{".", ".", "logs", "logs/2024", "src", "vendor"}},
+		{pattern: "**/*.go", want: []string{"src/a/b/c.go", "src/main.go", "vendor/x/y.go"}},
+		{pattern: "src/**", want: []string{"src/a", "src/a/b", "src/a/b/c.go", "src/main.go"}},
+		{pattern: "**/2024/*.log", want: []string{"logs/2024/jan.log"}},
+		{pattern: "s[r]c/*/b/?.go", want: []string{"src/a/b/c.go"}},
+		{pattern: "missing/*.log", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			fsys := &brokenFS{FS: testFS}
+			got, err := entryPaths(FromGlob(fsys, tt.pattern))
+			if err != nil {
+				t.Fatalf("FromGlob() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromGlob() = %v, want %v", got, tt.want)
+			}
+			if tt.opened != nil && !reflect.DeepEqual(fsys.opened, tt.opened) {
+				t.Errorf("FromGlob() opened %v, want %v", fsys.opened, tt.opened)
+			}
+		})
+	}
+	if _, err := entryPaths(FromGlob(testFS, "logs/[")); !errors.Is(err, path.ErrBadPattern) {
+		t.Errorf("FromGlob() error = %v, want %v", err, path.ErrBadPattern)
+	}
+}
+
+func TestFromGlob_pipeline(t *testing.T) {
+	logs, failed := FromGlob(testFS, "logs/**/*.log").Values()
+	failures := SelectMany(logs, func(entry FileEntry) []string {
+		data, err := fs.ReadFile(testFS, entry.Path)
+		if err != nil {
+			t.Fatalf("fs.ReadFile() error = %v", err)
+		}
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}).Where(func(line string) bool {
+		return strings.HasPrefix(line, "failed: ")
+	})
+	want := []string{"failed: timeout", "failed: disk full"}
+	if got := failures.ToSlice(); !reflect.DeepEqual(got, want) {
+		t.Errorf("FromGlob() pipeline = %v, want %v", got, want)
+	}
+	if err := failed(); err != nil {
+		t.Errorf("FromGlob() error = %v", err)
+	}
+}
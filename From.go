@@ -16,13 +16,25 @@ import "github.com/thereisnoplanb/generic"
 //
 // The input sequence typed as Iterator[TSource].
 func FromSlice[TSlice ~[]TSource, TSource any](source TSlice) Iterator[TSource] {
-	return func(yield func(value TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("FromSlice", false, "O(n)", []string{parameter("len", len(source))})
+		},
+		index: func() (result indexed[TSource], ok bool) {
+			return indexed[TSource]{
+				length: len(source),
+				at: func(index int) TSource {
+					return source[index]
+				},
+			}, true
+		},
+	}, func(yield func(value TSource) bool) {
 		for _, value := range source {
 			if !yield(value) {
 				return
 			}
 		}
-	}
+	})
 }
 
 // Returns the input typed as Iterator[TSource].
@@ -56,7 +68,16 @@ func FromIterator[TSource any](source Iterator[TSource]) Iterator[TSource] {
 //
 // The input sequence typed as Iterator[generic.KeyValuePair[TKey, TValue]].
 func FromMap[TMap ~map[TKey]TValue, TKey comparable, TValue any](source TMap) Iterator[generic.KeyValuePair[TKey, TValue]] {
-	return func(yield func(value generic.KeyValuePair[TKey, TValue]) bool) {
+	return describe(descriptor[generic.KeyValuePair[TKey, TValue]]{
+		plan: func() *PlanNode {
+			return node("FromMap", false, "O(n)", []string{parameter("len", len(source))})
+		},
+		index: func() (result indexed[generic.KeyValuePair[TKey, TValue]], ok bool) {
+			return indexed[generic.KeyValuePair[TKey, TValue]]{
+				length: len(source),
+			}, true
+		},
+	}, func(yield func(value generic.KeyValuePair[TKey, TValue]) bool) {
 		for key, value := range source {
 			if !yield(generic.KeyValuePair[TKey, TValue]{
 				Key:   key,
@@ -65,7 +86,7 @@ func FromMap[TMap ~map[TKey]TValue, TKey comparable, TValue any](source TMap) It
 				return
 			}
 		}
-	}
+	})
 }
 
 // Returns the input typed as Iterator[rune].
@@ -82,13 +103,48 @@ func FromMap[TMap ~map[TKey]TValue, TKey comparable, TValue any](source TMap) It
 //
 // The input sequence typed as Iterator[rune].
 func FromString(source string) Iterator[rune] {
-	return func(yield func(value rune) bool) {
+	return describe(descriptor[rune]{
+		plan: func() *PlanNode {
+			return node("FromString", false, "O(n)", []string{parameter("bytes", len(source))})
+		},
+	}, func(yield func(value rune) bool) {
 		for _, value := range source {
 			if !yield(value) {
 				return
 			}
 		}
-	}
+	})
+}
+
+// Returns the elements an Enumerator has not advanced to yet, typed as Iterator[TSource].
+//
+// # Parameters
+//
+//	enumerator *Enumerator[TSource]
+//
+// The Enumerator to consume.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that advances the Enumerator and yields each element it advances to.
+//
+// # Remarks
+//
+// See Enumerator.Remaining.
+func FromEnumerator[TSource any](enumerator *Enumerator[TSource]) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("FromEnumerator", false, "O(n)", nil)
+		},
+	}, func(yield func(value TSource) bool) {
+		for enumerator.MoveNext() {
+			if !yield(enumerator.Current()) {
+				return
+			}
+		}
+	})
 }
 
 // Generates a sequence that contains one repeated value.
@@ -108,14 +164,25 @@ func FromString(source string) Iterator[rune] {
 //
 // An Iterator[TSource] that contains a repeated value.
 func Repeat[TSource any](element TSource, count int) Iterator[TSource] {
-	return func(yield func(value TSource) bool) {
-		for count > 0 {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Repeat", false, "O(n)", []string{parameter("count", count)})
+		},
+		index: func() (result indexed[TSource], ok bool) {
+			return indexed[TSource]{
+				length: max(count, 0),
+				at: func(index int) TSource {
+					return element
+				},
+			}, true
+		},
+	}, func(yield func(value TSource) bool) {
+		for range count {
 			if !yield(element) {
 				return
 			}
-			count--
 		}
-	}
+	})
 }
 
 // Generates a sequence of integral numbers within a specified range.
@@ -136,13 +203,24 @@ func Repeat[TSource any](element TSource, count int) Iterator[TSource] {
 //
 // An Iterator[int] that contains a range of sequential integral numbers.
 func Range(start int, count int) Iterator[int] {
-	return func(yield func(value int) bool) {
-		for count > 0 {
-			if !yield(start) {
+	return describe(descriptor[int]{
+		plan: func() *PlanNode {
+			return node("Range", false, "O(n)", []string{parameter("start", start), parameter("count", count)})
+		},
+		index: func() (result indexed[int], ok bool) {
+			first := start
+			return indexed[int]{
+				length: max(count, 0),
+				at: func(index int) int {
+					return first + index
+				},
+			}, true
+		},
+	}, func(yield func(value int) bool) {
+		for i := range count {
+			if !yield(start + i) {
 				return
 			}
-			start++
-			count--
 		}
-	}
+	})
 }
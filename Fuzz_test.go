@@ -0,0 +1,701 @@
+package linq
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+// The fuzz targets compare every operator of Iterator.go with a slice-based reference implementation of the same semantics.
+// Each target receives two sequences encoded as bytes, a count and a position at which the consumer stops the enumeration.
+// The operators are run on a slice-backed source, which takes the random access paths, and on an opaque one, which does not.
+// The seed corpus in testdata/fuzz covers empty, singleton and duplicate-heavy sequences, negative counts and early stops.
+
+// Decodes a sequence from fuzz input. Every byte becomes a value in [-8, 7], so that longer inputs are rich in duplicates.
+func decode(data []byte) []int {
+	values := make([]int, len(data))
+	for i, b := range data {
+		values[i] = int(int8(b)) / 16
+	}
+	return values
+}
+
+// Returns the elements of values through a sequence that does not support random access.
+func opaque(values []int) Iterator[int] {
+	return func(yield func(value int) bool) {
+		for _, value := range values {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+var fuzzSources = []struct {
+	name   string
+	source func(values []int) Iterator[int]
+}{
+	{"FromSlice", FromSlice[[]int]},
+	{"opaque", opaque},
+}
+
+func isEven(item int) bool {
+	return item%2 == 0
+}
+
+func identity(item int) int {
+	return item
+}
+
+// Checks the elements of a lazy sequence against the reference: twice to the end, then stopped after stop elements.
+func checkSequence[TResult any](t *testing.T, name string, got Iterator[TResult], want []TResult, stop int) {
+	t.Helper()
+	for pass := range 2 {
+		if elements := got.ToSlice(); !(len(elements) == 0 && len(want) == 0) && !reflect.DeepEqual(elements, want) {
+			t.Fatalf("%s: enumeration %d = %v, want %v", name, pass+1, elements, want)
+		}
+	}
+	if stop < 0 {
+		stop = -stop
+	}
+	stop = stop%(len(want)+1) + 1
+	elements := make([]TResult, 0, stop)
+	late := false
+	got(func(value TResult) bool {
+		if len(elements) == stop {
+			late = true
+			return false
+		}
+		elements = append(elements, value)
+		return len(elements) < stop
+	})
+	if late {
+		t.Fatalf("%s: yield called after it returned false", name)
+	}
+	if prefix := want[:min(stop, len(want))]; !(len(elements) == 0 && len(prefix) == 0) && !reflect.DeepEqual(elements, prefix) {
+		t.Fatalf("%s: enumeration stopped after %d elements = %v, want %v", name, stop, elements, prefix)
+	}
+}
+
+// Fuzzes an operator that returns a sequence.
+func fuzzSequence[TResult any](f *testing.F, lazy func(source Iterator[int], sequence Iterator[int], count int) Iterator[TResult], reference func(source []int, sequence []int, count int) []TResult) {
+	f.Fuzz(func(t *testing.T, data []byte, other []byte, count int, stop int) {
+		source, sequence := decode(data), decode(other)
+		want := reference(source, sequence, count)
+		for _, s := range fuzzSources {
+			checkSequence(t, s.name, lazy(s.source(source), s.source(sequence), count), want, stop)
+		}
+	})
+}
+
+// Fuzzes an operator that returns a value.
+func fuzzValue[TResult any](f *testing.F, eager func(source Iterator[int], sequence Iterator[int], count int) TResult, reference func(source []int, sequence []int, count int) TResult) {
+	f.Fuzz(func(t *testing.T, data []byte, other []byte, count int, stop int) {
+		source, sequence := decode(data), decode(other)
+		want := reference(source, sequence, count)
+		for _, s := range fuzzSources {
+			if got := eager(s.source(source), s.source(sequence), count); !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s: got %v, want %v", s.name, got, want)
+			}
+		}
+	})
+}
+
+// A value returned together with an error.
+type outcome[TValue any] struct {
+	Value TValue
+	Err   error
+}
+
+func result[TValue any](value TValue, err error) outcome[TValue] {
+	return outcome[TValue]{value, err}
+}
+
+// Returns the element of values at index, or ErrIndexOutOfRange.
+func elementAt(values []int, index int) outcome[int] {
+	if index < 0 || index >= len(values) {
+		return result(0, ErrIndexOutOfRange)
+	}
+	return result(values[index], nil)
+}
+
+// Returns the elements of values that pass the predicate, or all of them if predicate is nil.
+func filter(values []int, predicate func(int) bool) []int {
+	matches := make([]int, 0, len(values))
+	for _, value := range values {
+		if predicate == nil || predicate(value) {
+			matches = append(matches, value)
+		}
+	}
+	return matches
+}
+
+// Returns the first (or last) element of values that passes the predicate, with the errors of First and Last.
+func firstOrLast(values []int, predicate func(int) bool, last bool) outcome[int] {
+	matches := filter(values, predicate)
+	switch {
+	case len(values) == 0:
+		return result(0, ErrSourceContainsNoElements)
+	case len(matches) == 0:
+		return result(0, ErrNoElementSatisfiesTheConditionInPredicate)
+	case last:
+		return result(matches[len(matches)-1], nil)
+	default:
+		return result(matches[0], nil)
+	}
+}
+
+// Returns the only element of values that passes the predicate, with the errors of Single.
+func single(values []int, predicate func(int) bool) outcome[int] {
+	matches := filter(values, predicate)
+	switch {
+	case len(values) == 0:
+		return result(0, ErrSourceContainsNoElements)
+	case len(matches) == 0:
+		return result(0, ErrNoElementSatisfiesTheConditionInPredicate)
+	case len(matches) > 1 && predicate != nil:
+		return result(0, ErrMoreThanOneElementSatisfiesTheConditionInPredicate)
+	case len(matches) > 1:
+		return result(0, ErrSourceHasMoreThanOneElement)
+	default:
+		return result(matches[0], nil)
+	}
+}
+
+// Returns the distinct elements of values in the order of their first occurrence.
+func distinct(values []int) []int {
+	unique := make([]int, 0, len(values))
+	for _, value := range values {
+		if !slices.Contains(unique, value) {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+func clamp(count int, length int) int {
+	return min(max(count, 0), length)
+}
+
+func FuzzIterator_Aggregate(f *testing.F) {
+	fuzzValue(f, func(source Iterator[int], _ Iterator[int], count int) int {
+		return source.Aggregate(count, func(accumulator int, item int) int {
+			return accumulator*3 + item
+		})
+	}, func(source []int, _ []int, count int) int {
+		for _, item := range source {
+			count = count*3 + item
+		}
+		return count
+	})
+}
+
+func FuzzIterator_All(f *testing.F) {
+	fuzzValue(f, func(source Iterator[int], _ Iterator[int], _ int) bool {
+		return source.All(isEven)
+	}, func(source []int, _ []int, _ int) bool {
+		return len(filter(source, isEven)) == len(source)
+	})
+}
+
+func FuzzIterator_Any(f *testing.F) {
+	fuzzValue(f, func(source Iterator[int], _ Iterator[int], _ int) [2]bool {
+		return [2]bool{source.Any(), source.Any(isEven)}
+	}, func(source []int, _ []int, _ int) [2]bool {
+		return [2]bool{len(source) > 0, len(filter(source, isEven)) > 0}
+	})
+}
+
+func FuzzIterator_Append(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], count int) Iterator[int] {
+		return source.Append(count, -count)
+	}, func(source []int, _ []int, count int) []int {
+		return append(slices.Clone(source), count, -count)
+	})
+}
+
+func FuzzAverage(f *testing.F) {
+	fuzzValue(f, func(source Iterator[int], _ Iterator[int], _ int) outcome[float64] {
+		return result(Average(source))
+	}, func(source []int, _ []int, _ int) outcome[float64] {
+		if len(source) == 0 {
+			return result(0.0, ErrSourceContainsNoElements)
+		}
+		sum := 0
+		for _, item := range source {
+			sum += item
+		}
+		return result(float64(sum)/float64(len(source)), nil)
+	})
+}
+
+func FuzzCast(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], _ int) Iterator[int] {
+		return Cast[any, int](Select(source, func(item int) any {
+			return item
+		}))
+	}, func(source []int, _ []int, _ int) []int {
+		return source
+	})
+}
+
+func FuzzChunk(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte, _ []byte, size int, stop int) {
+		source := decode(data)
+		if size < 1 {
+			defer func() {
+				if r := recover(); r != ErrSizeIsBelowOne {
+					t.Fatalf("Chunk() panicked with %v, want %v", r, ErrSizeIsBelowOne)
+				}
+			}()
+			Chunk(FromSlice(source), size)
+			return
+		}
+		want := slices.Collect(slices.Chunk(source, size))
+		for _, s := range fuzzSources {
+			checkSequence(t, s.name, Chunk(s.source(source), size), want, stop)
+		}
+	})
+}
+
+func FuzzIterator_Concat(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], sequence Iterator[int], _ int) Iterator[int] {
+		return source.Concat(sequence)
+	}, func(source []int, sequence []int, _ int) []int {
+		return slices.Concat(source, sequence)
+	})
+}
+
+func FuzzIterator_Contains(f *testing.F) {
+	fuzzValue(f, func(source Iterator[int], sequence Iterator[int], count int) [3]bool {
+		values := sequence.ToSlice()
+		return [3]bool{source.Contains(count), source.ContainsAny(values), source.ContainsAll(values)}
+	}, func(source []int, sequence []int, count int) [3]bool {
+		matches := filter(sequence, func(item int) bool {
+			return slices.Contains(source, item)
+		})
+		return [3]bool{slices.Contains(source, count), len(matches) > 0, len(matches) == len(sequence)}
+	})
+}
+
+func FuzzIterator_Count(f *testing.F) {
+	fuzzValue(f, func(source Iterator[int], _ Iterator[int], _ int) [2]int {
+		return [2]int{source.Count(), source.Count(isEven)}
+	}, func(source []int, _ []int, _ int) [2]int {
+		return [2]int{len(source), len(filter(source, isEven))}
+	})
+}
+
+func FuzzIterator_Distinct(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], _ int) Iterator[int] {
+		return source.Distinct()
+	}, func(source []int, _ []int, _ int) []int {
+		return distinct(source)
+	})
+}
+
+func FuzzIterator_ElementAt(f *testing.F) {
+	fuzzValue(f, func(source Iterator[int], _ Iterator[int], index int) [3]outcome[int] {
+		return [3]outcome[int]{
+			result(source.ElementAt(index)),
+			result(source.ElementAtOrDefault(index), nil),
+			result(source.ElementAtOrFallback(index, 99), nil),
+		}
+	}, func(source []int, _ []int, index int) [3]outcome[int] {
+		element := elementAt(source, index)
+		if element.Err != nil {
+			return [3]outcome[int]{element, result(0, nil), result(99, nil)}
+		}
+		return [3]outcome[int]{element, element, element}
+	})
+}
+
+func FuzzIterator_Except(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], sequence Iterator[int], _ int) Iterator[int] {
+		return source.Except(sequence)
+	}, func(source []int, sequence []int, _ int) []int {
+		return filter(distinct(source), func(item int) bool {
+			return !slices.Contains(sequence, item)
+		})
+	})
+}
+
+func FuzzIterator_First(f *testing.F) {
+	fuzzValue(f, func(source Iterator[int], _ Iterator[int], _ int) [6]outcome[int] {
+		return [6]outcome[int]{
+			result(source.First()),
+			result(source.First(isEven)),
+			result(source.FirstOrDefault(), nil),
+			result(source.FirstOrDefault(isEven), nil),
+			result(source.FirstOrFallback(99), nil),
+			result(source.FirstOrFallback(99, isEven), nil),
+		}
+	}, func(source []int, _ []int, _ int) [6]outcome[int] {
+		first, firstEven := firstOrLast(source, nil, false), firstOrLast(source, isEven, false)
+		return [6]outcome[int]{
+			first,
+			firstEven,
+			result(first.Value, nil),
+			result(firstEven.Value, nil),
+			result(fallback(first, 99), nil),
+			result(fallback(firstEven, 99), nil),
+		}
+	})
+}
+
+// Returns the value of an outcome, or the fallback if it failed.
+func fallback(element outcome[int], value int) int {
+	if element.Err != nil {
+		return value
+	}
+	return element.Value
+}
+
+func FuzzGroupBy(f *testing.F) {
+	type group struct {
+		Key      int
+		Elements []int
+	}
+	fuzzValue(f, func(source Iterator[int], _ Iterator[int], _ int) []group {
+		groups := make([]group, 0)
+		for pair := range GroupBy(source, func(item int) int {
+			return item % 3
+		}) {
+			groups = append(groups, group{pair.Key, pair.Value.ToSlice()})
+		}
+		slices.SortFunc(groups, func(x, y group) int {
+			return x.Key - y.Key
+		})
+		return groups
+	}, func(source []int, _ []int, _ int) []group {
+		groups := make([]group, 0)
+		for key := -2; key <= 2; key++ {
+			if elements := filter(source, func(item int) bool {
+				return item%3 == key
+			}); len(elements) > 0 {
+				groups = append(groups, group{key, elements})
+			}
+		}
+		return groups
+	})
+}
+
+func FuzzIterator_Intersect(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], sequence Iterator[int], _ int) Iterator[int] {
+		return source.Intersect(sequence)
+	}, func(source []int, sequence []int, _ int) []int {
+		return filter(distinct(source), func(item int) bool {
+			return slices.Contains(sequence, item)
+		})
+	})
+}
+
+func FuzzJoin(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], sequence Iterator[int], _ int) Iterator[generic.ValuePair[int, int]] {
+		return Join(source, Select(sequence, func(item int) int {
+			return item * 2
+		}), identity, func(item int) int {
+			return item / 2
+		}, func(outer int, inner int) generic.ValuePair[int, int] {
+			return generic.ValuePair[int, int]{Item1: outer, Item2: inner}
+		})
+	}, func(source []int, sequence []int, _ int) []generic.ValuePair[int, int] {
+		pairs := make([]generic.ValuePair[int, int], 0)
+		for _, outer := range source {
+			for _, inner := range sequence {
+				if outer == inner {
+					pairs = append(pairs, generic.ValuePair[int, int]{Item1: outer, Item2: inner * 2})
+				}
+			}
+		}
+		return pairs
+	})
+}
+
+func FuzzIterator_Last(f *testing.F) {
+	fuzzValue(f, func(source Iterator[int], _ Iterator[int], _ int) [6]outcome[int] {
+		return [6]outcome[int]{
+			result(source.Last()),
+			result(source.Last(isEven)),
+			result(source.LastOrDefault(), nil),
+			result(source.LastOrDefault(isEven), nil),
+			result(source.LastOrFallback(99), nil),
+			result(source.LastOrFallback(99, isEven), nil),
+		}
+	}, func(source []int, _ []int, _ int) [6]outcome[int] {
+		last, lastEven := firstOrLast(source, nil, true), firstOrLast(source, isEven, true)
+		return [6]outcome[int]{
+			last,
+			lastEven,
+			result(last.Value, nil),
+			result(lastEven.Value, nil),
+			result(fallback(last, 99), nil),
+			result(fallback(lastEven, 99), nil),
+		}
+	})
+}
+
+func FuzzIterator_MinMax(f *testing.F) {
+	byMagnitude := func(x, y int) int {
+		return max(x, -x) - max(y, -y)
+	}
+	fuzzValue(f, func(source Iterator[int], _ Iterator[int], _ int) [8]outcome[int] {
+		min, max, err := source.MinMax()
+		packageMin, packageMax, packageErr := MinMax(source)
+		return [8]outcome[int]{
+			result(source.Min()),
+			result(source.Max()),
+			result(min, err),
+			result(max, err),
+			result(Min(source)),
+			result(Max(source)),
+			result(packageMin+packageMax, packageErr),
+			result(source.Max(byMagnitude)),
+		}
+	}, func(source []int, _ []int, _ int) [8]outcome[int] {
+		if len(source) == 0 {
+			empty := result(0, ErrSourceContainsNoElements)
+			return [8]outcome[int]{empty, empty, empty, empty, empty, empty, empty, empty}
+		}
+		least, greatest := slices.Min(source), slices.Max(source)
+		return [8]outcome[int]{
+			result(least, nil),
+			result(greatest, nil),
+			result(least, nil),
+			result(greatest, nil),
+			result(least, nil),
+			result(greatest, nil),
+			result(least+greatest, nil),
+			result(slices.MinFunc(source, byMagnitude), nil),
+		}
+	})
+}
+
+func FuzzIterator_Order(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], _ int) Iterator[int] {
+		return source.Order().Concat(source.OrderDescending()).Concat(Order(source)).Concat(OrderDescending(source))
+	}, func(source []int, _ []int, _ int) []int {
+		ascending := slices.Sorted(slices.Values(source))
+		descending := slices.Clone(ascending)
+		slices.Reverse(descending)
+		return slices.Concat(ascending, descending, ascending, descending)
+	})
+}
+
+func FuzzOrderBy(f *testing.F) {
+	half := func(item int) int {
+		return item / 2
+	}
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], _ int) Iterator[int] {
+		return Select(OrderBy(source, half), half).Concat(Select(OrderByDescending(source, half), half))
+	}, func(source []int, _ []int, _ int) []int {
+		keys := make([]int, len(source))
+		for i, item := range source {
+			keys[i] = half(item)
+		}
+		slices.Sort(keys)
+		descending := slices.Clone(keys)
+		slices.Reverse(descending)
+		return slices.Concat(keys, descending)
+	})
+}
+
+func FuzzIterator_Prepend(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], count int) Iterator[int] {
+		return source.Prepend(count, -count)
+	}, func(source []int, _ []int, count int) []int {
+		return slices.Concat([]int{count, -count}, source)
+	})
+}
+
+func FuzzIterator_Reverse(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], _ int) Iterator[int] {
+		return source.Reverse()
+	}, func(source []int, _ []int, _ int) []int {
+		reversed := slices.Clone(source)
+		slices.Reverse(reversed)
+		return reversed
+	})
+}
+
+func FuzzSelect(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], count int) Iterator[int] {
+		return Select(source, func(item int) int {
+			return item * count
+		})
+	}, func(source []int, _ []int, count int) []int {
+		products := make([]int, len(source))
+		for i, item := range source {
+			products[i] = item * count
+		}
+		return products
+	})
+}
+
+func FuzzSelectMany(f *testing.F) {
+	repeat := func(item int) []int {
+		return slices.Repeat([]int{item}, max(item, 0))
+	}
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], _ int) Iterator[int] {
+		return SelectMany(source, repeat)
+	}, func(source []int, _ []int, _ int) []int {
+		flattened := make([]int, 0)
+		for _, item := range source {
+			flattened = append(flattened, repeat(item)...)
+		}
+		return flattened
+	})
+}
+
+func FuzzIterator_SequenceEqual(f *testing.F) {
+	fuzzValue(f, func(source Iterator[int], sequence Iterator[int], _ int) [2]bool {
+		return [2]bool{source.SequenceEqual(sequence), source.SequenceEqual(source)}
+	}, func(source []int, sequence []int, _ int) [2]bool {
+		return [2]bool{slices.Equal(source, sequence), true}
+	})
+}
+
+func FuzzIterator_Single(f *testing.F) {
+	fuzzValue(f, func(source Iterator[int], _ Iterator[int], _ int) [6]outcome[int] {
+		return [6]outcome[int]{
+			result(source.Single()),
+			result(source.Single(isEven)),
+			result(source.SingleOrDefault(), nil),
+			result(source.SingleOrDefault(isEven), nil),
+			result(source.SingleOrFallback(99), nil),
+			result(source.SingleOrFallback(99, isEven), nil),
+		}
+	}, func(source []int, _ []int, _ int) [6]outcome[int] {
+		only, onlyEven := single(source, nil), single(source, isEven)
+		return [6]outcome[int]{
+			only,
+			onlyEven,
+			result(only.Value, nil),
+			result(onlyEven.Value, nil),
+			result(fallback(only, 99), nil),
+			result(fallback(onlyEven, 99), nil),
+		}
+	})
+}
+
+func FuzzIterator_Skip(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], count int) Iterator[int] {
+		return source.Skip(count)
+	}, func(source []int, _ []int, count int) []int {
+		return source[clamp(count, len(source)):]
+	})
+}
+
+func FuzzIterator_SkipLast(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], count int) Iterator[int] {
+		return source.SkipLast(count)
+	}, func(source []int, _ []int, count int) []int {
+		return source[:len(source)-clamp(count, len(source))]
+	})
+}
+
+// SkipWhile bypasses the elements that fail the predicate, up to the first one that passes it.
+func FuzzIterator_SkipWhile(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], _ int) Iterator[int] {
+		return source.SkipWhile(isEven)
+	}, func(source []int, _ []int, _ int) []int {
+		i := slices.IndexFunc(source, isEven)
+		if i < 0 {
+			return nil
+		}
+		return source[i:]
+	})
+}
+
+func FuzzSum(f *testing.F) {
+	fuzzValue(f, func(source Iterator[int], _ Iterator[int], _ int) int {
+		return Sum(source)
+	}, func(source []int, _ []int, _ int) int {
+		sum := 0
+		for _, item := range source {
+			sum += item
+		}
+		return sum
+	})
+}
+
+func FuzzIterator_Take(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], count int) Iterator[int] {
+		return source.Take(count)
+	}, func(source []int, _ []int, count int) []int {
+		return source[:clamp(count, len(source))]
+	})
+}
+
+func FuzzIterator_TakeLast(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], count int) Iterator[int] {
+		return source.TakeLast(count)
+	}, func(source []int, _ []int, count int) []int {
+		return source[len(source)-clamp(count, len(source)):]
+	})
+}
+
+// TakeWhile yields the elements that fail the predicate, up to the first one that passes it.
+func FuzzIterator_TakeWhile(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], _ int) Iterator[int] {
+		return source.TakeWhile(isEven)
+	}, func(source []int, _ []int, _ int) []int {
+		i := slices.IndexFunc(source, isEven)
+		if i < 0 {
+			return source
+		}
+		return source[:i]
+	})
+}
+
+func FuzzIterator_ToSlice(f *testing.F) {
+	fuzzValue(f, func(source Iterator[int], _ Iterator[int], _ int) []int {
+		return source.ToSlice()
+	}, func(source []int, _ []int, _ int) []int {
+		return slices.Clone(source)
+	})
+}
+
+func FuzzToMap(f *testing.F) {
+	fuzzValue(f, func(source Iterator[int], _ Iterator[int], _ int) map[int]int {
+		return ToMap(source, func(item int) int {
+			return item % 3
+		}, identity)
+	}, func(source []int, _ []int, _ int) map[int]int {
+		lastByKey := make(map[int]int)
+		for _, item := range source {
+			lastByKey[item%3] = item
+		}
+		return lastByKey
+	})
+}
+
+func FuzzIterator_Union(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], sequence Iterator[int], _ int) Iterator[int] {
+		return source.Union(sequence)
+	}, func(source []int, sequence []int, _ int) []int {
+		return distinct(slices.Concat(source, sequence))
+	})
+}
+
+func FuzzIterator_Where(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], _ Iterator[int], _ int) Iterator[int] {
+		return source.Where(isEven)
+	}, func(source []int, _ []int, _ int) []int {
+		return filter(source, isEven)
+	})
+}
+
+func FuzzZip(f *testing.F) {
+	fuzzSequence(f, func(source Iterator[int], sequence Iterator[int], _ int) Iterator[generic.ValuePair[int, int]] {
+		return Zip(source, sequence)
+	}, func(source []int, sequence []int, _ int) []generic.ValuePair[int, int] {
+		pairs := make([]generic.ValuePair[int, int], min(len(source), len(sequence)))
+		for i := range pairs {
+			pairs[i] = generic.ValuePair[int, int]{Item1: source[i], Item2: sequence[i]}
+		}
+		return pairs
+	})
+}
@@ -0,0 +1,410 @@
+package linq
+
+import (
+	"time"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+// Returns an empty sequence.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains no elements.
+func Empty[TSource any]() (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Empty", false, "O(1)", nil)
+		},
+		index: func() (result indexed[TSource], ok bool) {
+			return indexed[TSource]{
+				length: 0,
+				at: func(index int) TSource {
+					var zero TSource
+					return zero
+				},
+			}, true
+		},
+	}, func(yield func(value TSource) bool) {
+	})
+}
+
+// Generates an infinite sequence by applying a function to the previous element, starting from a seed.
+//
+// # Parameters
+//
+//	seed TSource
+//
+// The first element of the sequence.
+//
+//	next func(value TSource) TSource
+//
+// A function that returns the element that follows an element.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An infinite Iterator[TSource] that contains seed, next(seed), next(next(seed)), and so on.
+//
+// # Example
+//
+//	powers := linq.Iterate(1, func(value int) int { return value * 2 }).Take(5)
+//	fmt.Println(powers.ToSlice())
+//	/*This code produces the following output:
+//	[1 2 4 8 16]
+//	*/
+//
+// # Remarks
+//
+// The sequence never ends by itself: stop it with Take, TakeWhile, First or by breaking out of the loop.
+// next is called only for the elements that are pulled, and each enumeration starts again from seed.
+func Iterate[TSource any](seed TSource, next func(value TSource) TSource) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Iterate", false, "O(n)", []string{parameter("seed", seed)})
+		},
+	}, func(yield func(value TSource) bool) {
+		for value := seed; yield(value); value = next(value) {
+		}
+	})
+}
+
+// Generates a sequence from a state, by repeatedly computing an element and the next state.
+//
+// # Parameters
+//
+//	state TState
+//
+// The initial state.
+//
+//	next func(state TState) (value TResult, nextState TState, ok bool)
+//
+// A function that returns the element computed from a state and the state that follows it, or false to end the sequence.
+//
+// # Returns
+//
+//	result Iterator[TResult]
+//
+// An Iterator[TResult] that contains the elements computed from the initial state and each following state,
+// until next returns false.
+//
+// # Example
+//
+//	type pair struct{ current, following int }
+//	fibonacci := linq.Unfold(pair{0, 1}, func(state pair) (int, pair, bool) {
+//		return state.current, pair{state.following, state.current + state.following}, true
+//	})
+//	fmt.Println(fibonacci.Take(8).ToSlice())
+//	/*This code produces the following output:
+//	[0 1 1 2 3 5 8 13]
+//	*/
+//
+// # Remarks
+//
+// Each enumeration starts again from the initial state.
+func Unfold[TState any, TResult any](state TState, next func(state TState) (value TResult, nextState TState, ok bool)) (result Iterator[TResult]) {
+	return describe(descriptor[TResult]{
+		plan: func() *PlanNode {
+			return node("Unfold", false, "O(n)", nil)
+		},
+	}, func(yield func(value TResult) bool) {
+		for current := state; ; {
+			value, following, ok := next(current)
+			if !ok || !yield(value) {
+				return
+			}
+			current = following
+		}
+	})
+}
+
+// Generates an infinite sequence by calling a function for each element.
+//
+// # Parameters
+//
+//	generator func() TSource
+//
+// A function that returns the next element, for example a random number or the current time.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An infinite Iterator[TSource] that contains the values that generator returns, in order.
+//
+// # Remarks
+//
+// The sequence never ends by itself: stop it with Take, TakeWhile, First or by breaking out of the loop.
+// generator is called only for the elements that are pulled. Unless generator returns the same values every time,
+// enumerations of the result yield different elements.
+func Generate[TSource any](generator func() TSource) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Generate", false, "O(n)", nil)
+		},
+	}, func(yield func(value TSource) bool) {
+		for yield(generator()) {
+		}
+	})
+}
+
+// Repeats the elements of a sequence indefinitely.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to repeat.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains the elements of source over and over, or no elements if source is empty.
+//
+// # Example
+//
+//	weekdays := linq.Cycle(linq.FromSlice([]string{"Mon", "Tue", "Wed", "Thu", "Fri"}))
+//	fmt.Println(weekdays.Skip(3).Take(4).ToSlice())
+//	/*This code produces the following output:
+//	[Thu Fri Mon Tue]
+//	*/
+//
+// # Remarks
+//
+// source is enumerated once per enumeration of the result: its elements are buffered during the first cycle
+// and replayed from the buffer after that, so that a one-shot source, such as the result of Tee or FromEnumerator,
+// can be cycled too. A source backed by a slice or a range is not buffered.
+func Cycle[TSource any](source Iterator[TSource]) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Cycle", !randomAccess(source), "O(n)", nil, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
+		if source, ok := indexOf(source); ok && source.at != nil {
+			for i := 0; source.length > 0; i = (i + 1) % source.length {
+				if !yield(source.at(i)) {
+					return
+				}
+			}
+			return
+		}
+		buffer := make([]TSource, 0)
+		for item := range source {
+			buffer = append(buffer, item)
+			if !yield(item) {
+				return
+			}
+		}
+		for len(buffer) > 0 {
+			for _, item := range buffer {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// Generates a sequence of numbers from start towards stop, in increments of step.
+//
+// # Parameters
+//
+//	start T
+//
+// The first number of the sequence.
+//
+//	stop T
+//
+// The bound of the sequence, which is not included.
+//
+//	step T
+//
+// The difference between consecutive numbers. A negative step counts down.
+//
+// # Returns
+//
+//	result Iterator[T]
+//
+// An Iterator[T] that contains start, start+step, start+2·step, and so on, while they are below stop
+// (above stop if step is negative). It is empty if start is not below stop (not above it if step is negative).
+//
+// # Example
+//
+//	fmt.Println(linq.RangeStep(0.0, 1.0, 0.1).ToSlice())
+//	/*This code produces the following output:
+//	[0 0.1 0.2 0.30000000000000004 0.4 0.5 0.6000000000000001 0.7000000000000001 0.8 0.9]
+//	*/
+//
+// # Remarks
+//
+// The n-th floating-point number is computed as start + n·step rather than by adding step n times, so that rounding
+// errors do not accumulate. Integer sequences end before they would overflow T.
+//
+// T is a generic.Real rather than a generic.Number, because complex numbers are not ordered and so cannot be compared
+// with stop.
+//
+// Panics with ErrStepIsZero when step is zero.
+func RangeStep[T generic.Real](start T, stop T, step T) (result Iterator[T]) {
+	var zero T
+	if step == zero {
+		panic(ErrStepIsZero)
+	}
+	half := T(1)
+	half /= 2
+	float := half != zero
+	before := func(value T) bool {
+		if step > zero {
+			return value < stop
+		}
+		return value > stop
+	}
+	return describe(descriptor[T]{
+		plan: func() *PlanNode {
+			return node("RangeStep", false, "O(n)", []string{parameter("start", start), parameter("stop", stop), parameter("step", step)})
+		},
+	}, func(yield func(value T) bool) {
+		if float {
+			for i := 0; ; i++ {
+				value := start + T(i)*step
+				if !before(value) || !yield(value) {
+					return
+				}
+			}
+		}
+		for value := start; before(value); {
+			if !yield(value) {
+				return
+			}
+			next := value + step
+			if (step > zero) != (next > value) {
+				return
+			}
+			value = next
+		}
+	})
+}
+
+// Generates a sequence of times from from towards to, in increments of step.
+//
+// # Parameters
+//
+//	from time.Time
+//
+// The first time of the sequence.
+//
+//	to time.Time
+//
+// The bound of the sequence, which is not included.
+//
+//	step time.Duration
+//
+// The duration between consecutive times. A negative step goes back in time.
+//
+// # Returns
+//
+//	result Iterator[time.Time]
+//
+// An Iterator[time.Time] that contains from, from+step, from+2·step, and so on, while they are before to
+// (after to if step is negative).
+//
+// # Remarks
+//
+// The times are exactly step apart, so across a daylight saving time change a step of 24 hours does not land on the same
+// wall clock time every day. Use MonthRange to step by calendar months.
+//
+// Panics with ErrStepIsZero when step is zero.
+func DateRange(from time.Time, to time.Time, step time.Duration) (result Iterator[time.Time]) {
+	if step == 0 {
+		panic(ErrStepIsZero)
+	}
+	return describe(descriptor[time.Time]{
+		plan: func() *PlanNode {
+			return node("DateRange", false, "O(n)", []string{parameter("from", from), parameter("to", to), parameter("step", step)})
+		},
+	}, func(yield func(value time.Time) bool) {
+		for value := from; step > 0 && value.Before(to) || step < 0 && value.After(to); {
+			if !yield(value) {
+				return
+			}
+			// time.Time.Add saturates at the range of time.Time, so a step that does not move the time ends the sequence.
+			next := value.Add(step)
+			if next.Equal(value) || (step > 0) != next.After(value) {
+				return
+			}
+			value = next
+		}
+	})
+}
+
+// Generates a sequence of times from from towards to, in increments of calendar months.
+//
+// # Parameters
+//
+//	from time.Time
+//
+// The first time of the sequence.
+//
+//	to time.Time
+//
+// The bound of the sequence, which is not included.
+//
+//	months int
+//
+// The number of months between consecutive times, for example 1 for monthly or 3 for quarterly. A negative number goes back in time.
+//
+// # Returns
+//
+//	result Iterator[time.Time]
+//
+// An Iterator[time.Time] that contains from and the same day and wall clock time every months months after it,
+// while they are before to (after to if months is negative).
+//
+// # Example
+//
+//	from := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+//	to := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+//	for day := range linq.MonthRange(from, to, 1) {
+//		fmt.Println(day.Format(time.DateOnly))
+//	}
+//	/*This code produces the following output:
+//	2024-01-31
+//	2024-02-29
+//	2024-03-31
+//	2024-04-30
+//	*/
+//
+// # Remarks
+//
+// A day that does not exist in a month is clamped to the last day of the month, and the following months
+// return to the day of from. Times keep the location of from.
+//
+// Panics with ErrStepIsZero when months is zero.
+func MonthRange(from time.Time, to time.Time, months int) (result Iterator[time.Time]) {
+	if months == 0 {
+		panic(ErrStepIsZero)
+	}
+	return describe(descriptor[time.Time]{
+		plan: func() *PlanNode {
+			return node("MonthRange", false, "O(n)", []string{parameter("from", from), parameter("to", to), parameter("months", months)})
+		},
+	}, func(yield func(value time.Time) bool) {
+		for i := 0; ; i++ {
+			value := addMonths(from, i*months)
+			if !(months > 0 && value.Before(to) || months < 0 && value.After(to)) || !yield(value) {
+				return
+			}
+		}
+	})
+}
+
+// Adds a number of calendar months to a time, clamping the day to the last day of the resulting month.
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, last)-1)
+}
@@ -0,0 +1,234 @@
+package linq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEmpty(t *testing.T) {
+	if got := Empty[int]().ToSlice(); len(got) != 0 {
+		t.Errorf("Empty() = %v, want []", got)
+	}
+	if got, want := Explain(Empty[string]().Reverse()), "Reverse [O(n)]\n└─ Empty [O(1)]\n"; got != want {
+		t.Errorf("Explain(Empty()) = %q, want %q", got, want)
+	}
+}
+
+func TestIterate(t *testing.T) {
+	calls := 0
+	double := func(value int) int {
+		calls++
+		return value * 2
+	}
+	source := Iterate(1, double)
+	if got, want := source.Take(5).ToSlice(), []int{1, 2, 4, 8, 16}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterate() = %v, want %v", got, want)
+	}
+	if calls != 4 {
+		t.Errorf("Iterate() called next %d times, want 4", calls)
+	}
+	if got, want := source.Take(2).ToSlice(), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterate() enumerated again = %v, want %v", got, want)
+	}
+}
+
+func TestUnfold(t *testing.T) {
+	type pair struct{ current, following int }
+	fibonacci := Unfold(pair{0, 1}, func(state pair) (int, pair, bool) {
+		return state.current, pair{state.following, state.current + state.following}, true
+	})
+	if got, want := fibonacci.Take(8).ToSlice(), []int{0, 1, 1, 2, 3, 5, 8, 13}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unfold() = %v, want %v", got, want)
+	}
+	digits := Unfold(1234, func(state int) (int, int, bool) {
+		return state % 10, state / 10, state > 0
+	})
+	if got, want := digits.ToSlice(), []int{4, 3, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unfold() = %v, want %v", got, want)
+	}
+	if got, want := digits.ToSlice(), []int{4, 3, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unfold() enumerated again = %v, want %v", got, want)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	calls := 0
+	source := Generate(func() int {
+		calls++
+		return calls * calls
+	})
+	if got, want := source.Take(3).ToSlice(), []int{1, 4, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Generate() = %v, want %v", got, want)
+	}
+	if calls != 3 {
+		t.Errorf("Generate() called generator %d times, want 3", calls)
+	}
+}
+
+func TestCycle(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  Iterator[int]
+		take    int
+		want    []int
+		buffers bool
+	}{
+		{name: "slice", source: FromSlice([]int{1, 2, 3}), take: 7, want: []int{1, 2, 3, 1, 2, 3, 1}},
+		{name: "range", source: Range(5, 2).Reverse(), take: 5, want: []int{6, 5, 6, 5, 6}},
+		{name: "opaque", source: opaque([]int{1, 2, 3}), take: 7, want: []int{1, 2, 3, 1, 2, 3, 1}, buffers: true},
+		{name: "one-shot", source: FromEnumerator(FromSlice([]int{1, 2}).GetEnumerator()), take: 5, want: []int{1, 2, 1, 2, 1}, buffers: true},
+		{name: "empty slice", source: FromSlice([]int{}), take: 5, want: []int{}},
+		{name: "empty opaque", source: opaque(nil), take: 5, want: []int{}, buffers: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Plan(Cycle(tt.source)); got.Buffers != tt.buffers {
+				t.Errorf("Plan(Cycle()) = %v, want buffers %v", got, tt.buffers)
+			}
+			if got := Cycle(tt.source).Take(tt.take).ToSlice(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Cycle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	enumerations := 0
+	source := Iterator[int](func(yield func(value int) bool) {
+		enumerations++
+		opaque([]int{1, 2})(yield)
+	})
+	Cycle(source).Take(9).ToSlice()
+	Cycle(source).Take(1).ToSlice()
+	if enumerations != 2 {
+		t.Errorf("Cycle() enumerated its source %d times, want once per enumeration", enumerations)
+	}
+}
+
+func TestRangeStep(t *testing.T) {
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "int", got: RangeStep(0, 10, 3).ToSlice(), want: []int{0, 3, 6, 9}},
+		{name: "int, exact bound", got: RangeStep(0, 9, 3).ToSlice(), want: []int{0, 3, 6}},
+		{name: "int, negative step", got: RangeStep(10, 0, -4).ToSlice(), want: []int{10, 6, 2}},
+		{name: "int, empty", got: RangeStep(5, 5, 1).ToSlice(), want: []int{}},
+		{name: "int, wrong direction", got: RangeStep(0, 10, -1).ToSlice(), want: []int{}},
+		{name: "int8, overflow", got: RangeStep[int8](100, 127, 10).ToSlice(), want: []int8{100, 110, 120}},
+		{name: "int8, underflow", got: RangeStep[int8](-100, -128, -20).ToSlice(), want: []int8{-100, -120}},
+		{name: "uint8, overflow", got: RangeStep[uint8](250, 255, 3).ToSlice(), want: []uint8{250, 253}},
+		{name: "float", got: RangeStep(0.5, 2, 0.5).ToSlice(), want: []float64{0.5, 1, 1.5}},
+		{name: "float, negative step", got: RangeStep(1, -0.5, -0.75).ToSlice(), want: []float64{1, 0.25}},
+		{name: "float32", got: RangeStep[float32](0, 1, 0.25).ToSlice(), want: []float32{0, 0.25, 0.5, 0.75}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("RangeStep() = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	// Adding 0.1 ten times gives 0.9999999999999999, which would add an eleventh element below 1.
+	step := 0.1
+	tenths := RangeStep(0.0, 1.0, step).ToSlice()
+	if len(tenths) != 10 || tenths[3] != 3*step || tenths[9] != 9*step {
+		t.Errorf("RangeStep(0, 1, 0.1) = %v, want the 10 values n·0.1", tenths)
+	}
+	if got, _ := RangeStep(0.0, 1e9, step).ElementAt(1000003); got != 1000003*step {
+		t.Errorf("RangeStep(0, 1e9, 0.1).ElementAt(1000003) = %v, want %v", got, 1000003*step)
+	}
+	defer func() {
+		if r := recover(); !errors.Is(r.(error), ErrStepIsZero) {
+			t.Errorf("RangeStep() panicked with %v, want %v", r, ErrStepIsZero)
+		}
+	}()
+	RangeStep(0, 1, 0)
+	t.Errorf("RangeStep() did not panic with a zero step")
+}
+
+func TestDateRange(t *testing.T) {
+	from := time.Date(2024, time.March, 1, 22, 0, 0, 0, time.UTC)
+	to := from.Add(3 * time.Hour)
+	want := []time.Time{from, from.Add(time.Hour), from.Add(2 * time.Hour)}
+	if got := DateRange(from, to, time.Hour).ToSlice(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DateRange() = %v, want %v", got, want)
+	}
+	want = []time.Time{to, to.Add(-90 * time.Minute)}
+	if got := DateRange(to, from, -90*time.Minute).ToSlice(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DateRange() = %v, want %v", got, want)
+	}
+	if got := DateRange(to, from, time.Hour).ToSlice(); len(got) != 0 {
+		t.Errorf("DateRange() = %v, want []", got)
+	}
+	// 400 Gregorian years have 146097 days, which is longer than a time.Duration can hold.
+	start, end := time.Date(1700, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+	days := DateRange(start, end, 24*time.Hour).ToSlice()
+	if len(days) != 146097 {
+		t.Errorf("DateRange() over 400 years has %d days, want 146097", len(days))
+	}
+	for i, day := range days {
+		if want := start.AddDate(0, 0, i); !day.Equal(want) {
+			t.Fatalf("DateRange() over 400 years [%d] = %v, want %v", i, day, want)
+		}
+	}
+	defer func() {
+		if r := recover(); !errors.Is(r.(error), ErrStepIsZero) {
+			t.Errorf("DateRange() panicked with %v, want %v", r, ErrStepIsZero)
+		}
+	}()
+	DateRange(from, to, 0)
+	t.Errorf("DateRange() did not panic with a zero step")
+}
+
+func TestMonthRange(t *testing.T) {
+	zone := time.FixedZone("UTC+2", 2*60*60)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, zone)
+	}
+	tests := []struct {
+		name   string
+		from   time.Time
+		to     time.Time
+		months int
+		want   []time.Time
+	}{
+		{
+			name:   "end of month",
+			from:   date(2024, time.January, 31),
+			to:     date(2024, time.May, 1),
+			months: 1,
+			want:   []time.Time{date(2024, time.January, 31), date(2024, time.February, 29), date(2024, time.March, 31), date(2024, time.April, 30)},
+		},
+		{
+			name:   "quarters across years",
+			from:   date(2023, time.November, 30),
+			to:     date(2024, time.November, 30),
+			months: 3,
+			want:   []time.Time{date(2023, time.November, 30), date(2024, time.February, 29), date(2024, time.May, 30), date(2024, time.August, 30)},
+		},
+		{
+			name:   "backwards",
+			from:   date(2024, time.March, 31),
+			to:     date(2023, time.October, 31),
+			months: -1,
+			want:   []time.Time{date(2024, time.March, 31), date(2024, time.February, 29), date(2024, time.January, 31), date(2023, time.December, 31), date(2023, time.November, 30)},
+		},
+		{
+			name:   "empty",
+			from:   date(2024, time.March, 31),
+			to:     date(2024, time.March, 31),
+			months: 1,
+			want:   []time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MonthRange(tt.from, tt.to, tt.months).ToSlice(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MonthRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,431 @@
+package linq
+
+import (
+	"container/heap"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+// Describes a cycle found in a graph that was expected to be acyclic.
+//
+// CycleError wraps linq.ErrCycleDetected, so errors.Is(err, linq.ErrCycleDetected) reports whether err is a CycleError.
+type CycleError[TKey comparable] struct {
+
+	// The keys of the nodes that form the cycle. The first key is repeated at the end.
+	Cycle []TKey
+}
+
+func (err *CycleError[TKey]) Error() string {
+	keys := make([]string, len(err.Cycle))
+	for i, key := range err.Cycle {
+		keys[i] = fmt.Sprint(key)
+	}
+	return fmt.Sprintf("%s: %s", ErrCycleDetected, strings.Join(keys, " -> "))
+}
+
+func (err *CycleError[TKey]) Unwrap() error {
+	return ErrCycleDetected
+}
+
+type graph[TSource any, TKey comparable] struct {
+	keys   []TKey
+	values []TSource
+	index  map[TKey]int
+	edges  [][]int
+}
+
+func newGraph[TSource any, TKey comparable](nodes Iterator[TSource], keySelector generic.KeySelector[TSource, TKey], edgeSelector generic.ValueSelector[TSource, Iterator[TKey]], strict bool) (result *graph[TSource, TKey], err error) {
+	result = &graph[TSource, TKey]{
+		index: make(map[TKey]int),
+	}
+	for node := range nodes {
+		key := keySelector(node)
+		if _, ok := result.index[key]; ok {
+			return nil, fmt.Errorf("%w: %v", ErrDuplicateKey, key)
+		}
+		result.index[key] = len(result.keys)
+		result.keys = append(result.keys, key)
+		result.values = append(result.values, node)
+	}
+	result.edges = make([][]int, len(result.keys))
+	for i, node := range result.values {
+		targets := edgeSelector(node)
+		if targets == nil {
+			continue
+		}
+		for key := range targets {
+			j, ok := result.index[key]
+			if !ok {
+				if strict {
+					return nil, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+				}
+				continue
+			}
+			result.edges[i] = append(result.edges[i], j)
+		}
+	}
+	return result, nil
+}
+
+func (g *graph[TSource, TKey]) nodes(indices []int) Iterator[TSource] {
+	return Select(FromSlice(indices), func(i int) TSource {
+		return g.values[i]
+	})
+}
+
+type indexHeap []int
+
+func (h indexHeap) Len() int           { return len(h) }
+func (h indexHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h indexHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *indexHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *indexHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// Sorts nodes so that every node comes after all the nodes it depends on.
+//
+// # Parameters
+//
+//	nodes Iterator[TSource]
+//
+// The nodes to sort.
+//
+//	keySelector generic.KeySelector[TSource, TKey]
+//
+// A function to extract the key that identifies a node.
+//
+//	dependencySelector generic.ValueSelector[TSource, Iterator[TKey]]
+//
+// A function that returns the keys of the nodes a node depends on. A nil result means that the node has no dependencies.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains the nodes in dependency order.
+//
+// # Error
+//
+//	err error
+//
+// *linq.CycleError[TKey] - When the dependencies contain a cycle. The error lists the keys of one detected cycle and wraps linq.ErrCycleDetected.
+//
+// linq.ErrDuplicateKey - When two nodes have the same key.
+//
+// linq.ErrKeyNotFound - When a node depends on a key that does not belong to any node.
+//
+// # Remarks
+//
+// The nodes and their dependencies are enumerated once, before the method returns.
+// The sort is stable: of all the nodes whose dependencies are already satisfied, the one that occurs first in nodes is taken first.
+func TopologicalSort[TSource any, TKey comparable](nodes Iterator[TSource], keySelector generic.KeySelector[TSource, TKey], dependencySelector generic.ValueSelector[TSource, Iterator[TKey]]) (result Iterator[TSource], err error) {
+	g, err := newGraph(nodes, keySelector, dependencySelector, true)
+	if err != nil {
+		return nil, err
+	}
+	pending := make([]int, len(g.keys))
+	dependents := make([][]int, len(g.keys))
+	for i, dependencies := range g.edges {
+		pending[i] = len(dependencies)
+		for _, j := range dependencies {
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+	ready := &indexHeap{}
+	for i := range pending {
+		if pending[i] == 0 {
+			heap.Push(ready, i)
+		}
+	}
+	order := make([]int, 0, len(g.keys))
+	for ready.Len() > 0 {
+		i := heap.Pop(ready).(int)
+		order = append(order, i)
+		for _, j := range dependents[i] {
+			pending[j]--
+			if pending[j] == 0 {
+				heap.Push(ready, j)
+			}
+		}
+	}
+	if len(order) < len(g.keys) {
+		return nil, g.cycle(pending)
+	}
+	return g.nodes(order), nil
+}
+
+// Finds a cycle among the nodes that still have pending dependencies. Each such node has at least one dependency that is pending as well.
+func (g *graph[TSource, TKey]) cycle(pending []int) error {
+	start := 0
+	for pending[start] == 0 {
+		start++
+	}
+	position := make(map[int]int)
+	path := make([]int, 0)
+	for i := start; ; {
+		if p, ok := position[i]; ok {
+			path = append(path[p:], i)
+			break
+		}
+		position[i] = len(path)
+		path = append(path, i)
+		for _, j := range g.edges[i] {
+			if pending[j] > 0 {
+				i = j
+				break
+			}
+		}
+	}
+	cycle := make([]TKey, len(path))
+	for i, j := range path {
+		cycle[i] = g.keys[j]
+	}
+	return &CycleError[TKey]{
+		Cycle: cycle,
+	}
+}
+
+// Finds, for every node, all the nodes that can be reached from it by following edges.
+//
+// # Parameters
+//
+//	nodes Iterator[TSource]
+//
+// The nodes of the graph.
+//
+//	keySelector generic.KeySelector[TSource, TKey]
+//
+// A function to extract the key that identifies a node.
+//
+//	edgeSelector generic.ValueSelector[TSource, Iterator[TKey]]
+//
+// A function that returns the keys of the nodes a node has an edge to. A nil result means that the node has no edges.
+//
+// # Returns
+//
+//	result Iterator[generic.KeyValuePair[TKey, Iterator[TSource]]]
+//
+// An Iterator that contains, for every node in the order of nodes, its key and the nodes reachable from it in breadth-first order.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrDuplicateKey - When two nodes have the same key.
+//
+// # Remarks
+//
+// The nodes and their edges are enumerated once, before the function returns; the nodes reachable from a node
+// are found when the result reaches it.
+// A node is reachable from itself only if it lies on a cycle.
+// Edges to keys that do not belong to any node are ignored.
+func TransitiveClosure[TSource any, TKey comparable](nodes Iterator[TSource], keySelector generic.KeySelector[TSource, TKey], edgeSelector generic.ValueSelector[TSource, Iterator[TKey]]) (result Iterator[generic.KeyValuePair[TKey, Iterator[TSource]]], err error) {
+	g, err := newGraph(nodes, keySelector, edgeSelector, false)
+	if err != nil {
+		return nil, err
+	}
+	return describe(descriptor[generic.KeyValuePair[TKey, Iterator[TSource]]]{
+		plan: func() *PlanNode {
+			return node("TransitiveClosure", true, "O(n·(n+e))", []string{parameter("nodes", len(g.keys))})
+		},
+	}, func(yield func(value generic.KeyValuePair[TKey, Iterator[TSource]]) bool) {
+		for i, key := range g.keys {
+			if !yield(generic.KeyValuePair[TKey, Iterator[TSource]]{
+				Key:   key,
+				Value: g.nodes(g.reachable(i)),
+			}) {
+				return
+			}
+		}
+	}), nil
+}
+
+func (g *graph[TSource, TKey]) reachable(start int) (result []int) {
+	visited := make([]bool, len(g.keys))
+	queue := []int{start}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for _, j := range g.edges[i] {
+			if !visited[j] {
+				visited[j] = true
+				result = append(result, j)
+				queue = append(queue, j)
+			}
+		}
+	}
+	return result
+}
+
+// Splits the nodes of a graph into connected components. The direction of the edges is ignored.
+//
+// # Parameters
+//
+//	nodes Iterator[TSource]
+//
+// The nodes of the graph.
+//
+//	keySelector generic.KeySelector[TSource, TKey]
+//
+// A function to extract the key that identifies a node.
+//
+//	edgeSelector generic.ValueSelector[TSource, Iterator[TKey]]
+//
+// A function that returns the keys of the nodes a node has an edge to. A nil result means that the node has no edges.
+//
+// # Returns
+//
+//	result Iterator[Iterator[TSource]]
+//
+// An Iterator that contains one sequence of nodes per connected component.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrDuplicateKey - When two nodes have the same key.
+//
+// # Remarks
+//
+// The components are ordered by their first node and the nodes of a component keep the order of nodes.
+// Edges to keys that do not belong to any node are ignored.
+// The nodes and their edges are enumerated once, before the function returns.
+func ConnectedComponents[TSource any, TKey comparable](nodes Iterator[TSource], keySelector generic.KeySelector[TSource, TKey], edgeSelector generic.ValueSelector[TSource, Iterator[TKey]]) (result Iterator[Iterator[TSource]], err error) {
+	g, err := newGraph(nodes, keySelector, edgeSelector, false)
+	if err != nil {
+		return nil, err
+	}
+	return describe(descriptor[Iterator[TSource]]{
+		plan: func() *PlanNode {
+			return node("ConnectedComponents", true, "O(n+e)", []string{parameter("nodes", len(g.keys))})
+		},
+	}, func(yield func(value Iterator[TSource]) bool) {
+		parent := make([]int, len(g.keys))
+		for i := range parent {
+			parent[i] = i
+		}
+		var find func(i int) int
+		find = func(i int) int {
+			if parent[i] != i {
+				parent[i] = find(parent[i])
+			}
+			return parent[i]
+		}
+		for i, targets := range g.edges {
+			for _, j := range targets {
+				ri, rj := find(i), find(j)
+				if ri < rj {
+					parent[rj] = ri
+				} else if rj < ri {
+					parent[ri] = rj
+				}
+			}
+		}
+		components := make(map[int][]int)
+		roots := make([]int, 0)
+		for i := range g.keys {
+			root := find(i)
+			if _, ok := components[root]; !ok {
+				roots = append(roots, root)
+			}
+			components[root] = append(components[root], i)
+		}
+		for _, root := range roots {
+			if !yield(g.nodes(components[root])) {
+				return
+			}
+		}
+	}), nil
+}
+
+// Finds a path with the fewest edges between two nodes of a graph.
+//
+// # Parameters
+//
+//	nodes Iterator[TSource]
+//
+// The nodes of the graph.
+//
+//	keySelector generic.KeySelector[TSource, TKey]
+//
+// A function to extract the key that identifies a node.
+//
+//	edgeSelector generic.ValueSelector[TSource, Iterator[TKey]]
+//
+// A function that returns the keys of the nodes a node has an edge to. A nil result means that the node has no edges.
+//
+//	from TKey
+//
+// The key of the first node of the path.
+//
+//	to TKey
+//
+// The key of the last node of the path.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains the nodes of the path, from and to inclusive.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrDuplicateKey - When two nodes have the same key.
+//
+// linq.ErrKeyNotFound - When from or to does not belong to any node.
+//
+// linq.ErrNoPathFound - When to cannot be reached from from.
+//
+// # Remarks
+//
+// Edges to keys that do not belong to any node are ignored.
+// When several shortest paths exist, the one that follows the edges in the order returned by edgeSelector is chosen.
+func ShortestPathBFS[TSource any, TKey comparable](nodes Iterator[TSource], keySelector generic.KeySelector[TSource, TKey], edgeSelector generic.ValueSelector[TSource, Iterator[TKey]], from TKey, to TKey) (result Iterator[TSource], err error) {
+	g, err := newGraph(nodes, keySelector, edgeSelector, false)
+	if err != nil {
+		return nil, err
+	}
+	start, ok := g.index[from]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrKeyNotFound, from)
+	}
+	end, ok := g.index[to]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrKeyNotFound, to)
+	}
+	previous := make([]int, len(g.keys))
+	for i := range previous {
+		previous[i] = -1
+	}
+	previous[start] = start
+	queue := []int{start}
+	for len(queue) > 0 && previous[end] < 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for _, j := range g.edges[i] {
+			if previous[j] < 0 {
+				previous[j] = i
+				queue = append(queue, j)
+			}
+		}
+	}
+	if previous[end] < 0 {
+		return nil, ErrNoPathFound
+	}
+	path := []int{end}
+	for i := end; i != start; {
+		i = previous[i]
+		path = append(path, i)
+	}
+	slices.Reverse(path)
+	return g.nodes(path), nil
+}
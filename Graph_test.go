@@ -0,0 +1,233 @@
+package linq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type task struct {
+	Name      string
+	DependsOn []string
+}
+
+func taskName(t task) string {
+	return t.Name
+}
+
+func taskDependencies(t task) Iterator[string] {
+	return FromSlice(t.DependsOn)
+}
+
+func TestTopologicalSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodes   []task
+		want    []string
+		wantErr error
+	}{
+		{
+			name:  "TopologicalSort empty nodes",
+			nodes: []task{},
+			want:  []string{},
+		},
+		{
+			name: "TopologicalSort independent nodes keep their order",
+			nodes: []task{
+				{Name: "c"},
+				{Name: "a"},
+				{Name: "b"},
+			},
+			want: []string{"c", "a", "b"},
+		},
+		{
+			name: "TopologicalSort dependencies first",
+			nodes: []task{
+				{Name: "app", DependsOn: []string{"lib", "log"}},
+				{Name: "lib", DependsOn: []string{"log"}},
+				{Name: "log"},
+				{Name: "cli", DependsOn: []string{"app"}},
+			},
+			want: []string{"log", "lib", "app", "cli"},
+		},
+		{
+			name: "TopologicalSort cycle",
+			nodes: []task{
+				{Name: "a"},
+				{Name: "b", DependsOn: []string{"c"}},
+				{Name: "c", DependsOn: []string{"d"}},
+				{Name: "d", DependsOn: []string{"b"}},
+			},
+			wantErr: ErrCycleDetected,
+		},
+		{
+			name: "TopologicalSort unknown dependency",
+			nodes: []task{
+				{Name: "a", DependsOn: []string{"x"}},
+			},
+			wantErr: ErrKeyNotFound,
+		},
+		{
+			name: "TopologicalSort duplicate key",
+			nodes: []task{
+				{Name: "a"},
+				{Name: "a"},
+			},
+			wantErr: ErrDuplicateKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TopologicalSort(FromSlice(tt.nodes), taskName, taskDependencies)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TopologicalSort() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := Select(got, taskName).ToSlice(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TopologicalSort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopologicalSort_CycleError(t *testing.T) {
+	nodes := []task{
+		{Name: "a", DependsOn: []string{"b"}},
+		{Name: "b", DependsOn: []string{"c"}},
+		{Name: "c", DependsOn: []string{"a"}},
+	}
+	_, err := TopologicalSort(FromSlice(nodes), taskName, taskDependencies)
+	var cycle *CycleError[string]
+	if !errors.As(err, &cycle) {
+		t.Fatalf("TopologicalSort() error = %v, want *CycleError[string]", err)
+	}
+	if want := []string{"a", "b", "c", "a"}; !reflect.DeepEqual(cycle.Cycle, want) {
+		t.Errorf("CycleError.Cycle = %v, want %v", cycle.Cycle, want)
+	}
+	if want := "cycle detected: a -> b -> c -> a"; err.Error() != want {
+		t.Errorf("CycleError.Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestTransitiveClosure(t *testing.T) {
+	nodes := []task{
+		{Name: "a", DependsOn: []string{"b"}},
+		{Name: "b", DependsOn: []string{"c", "x"}},
+		{Name: "c"},
+		{Name: "d", DependsOn: []string{"d"}},
+	}
+	closure, err := TransitiveClosure(FromSlice(nodes), taskName, taskDependencies)
+	if err != nil {
+		t.Fatalf("TransitiveClosure() error = %v, want nil", err)
+	}
+	got := make(map[string][]string)
+	for pair := range closure {
+		got[pair.Key] = Select(pair.Value, taskName).ToSlice()
+	}
+	want := map[string][]string{
+		"a": {"b", "c"},
+		"b": {"c"},
+		"c": {},
+		"d": {"d"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TransitiveClosure() = %v, want %v", got, want)
+	}
+}
+
+func TestConnectedComponents(t *testing.T) {
+	nodes := []task{
+		{Name: "a"},
+		{Name: "b", DependsOn: []string{"d"}},
+		{Name: "c", DependsOn: []string{"a"}},
+		{Name: "d"},
+		{Name: "e"},
+	}
+	components, err := ConnectedComponents(FromSlice(nodes), taskName, taskDependencies)
+	if err != nil {
+		t.Fatalf("ConnectedComponents() error = %v, want nil", err)
+	}
+	got := Select(components, func(component Iterator[task]) []string {
+		return Select(component, taskName).ToSlice()
+	}).ToSlice()
+	want := [][]string{{"a", "c"}, {"b", "d"}, {"e"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ConnectedComponents() = %v, want %v", got, want)
+	}
+}
+
+func TestGraph_duplicateKey(t *testing.T) {
+	nodes := FromSlice([]task{{Name: "a"}, {Name: "b"}, {Name: "a"}})
+	for name, err := range map[string]error{
+		"TopologicalSort":     second(TopologicalSort(nodes, taskName, taskDependencies)),
+		"TransitiveClosure":   second(TransitiveClosure(nodes, taskName, taskDependencies)),
+		"ConnectedComponents": second(ConnectedComponents(nodes, taskName, taskDependencies)),
+		"ShortestPathBFS":     second(ShortestPathBFS(nodes, taskName, taskDependencies, "a", "b")),
+	} {
+		if !errors.Is(err, ErrDuplicateKey) {
+			t.Errorf("%s() error = %v, want %v", name, err, ErrDuplicateKey)
+		}
+	}
+}
+
+func TestShortestPathBFS(t *testing.T) {
+	nodes := []task{
+		{Name: "a", DependsOn: []string{"b", "c"}},
+		{Name: "b", DependsOn: []string{"d"}},
+		{Name: "c", DependsOn: []string{"e"}},
+		{Name: "d", DependsOn: []string{"e"}},
+		{Name: "e"},
+	}
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "ShortestPathBFS to itself",
+			from: "a",
+			to:   "a",
+			want: []string{"a"},
+		},
+		{
+			name: "ShortestPathBFS shortest of two paths",
+			from: "a",
+			to:   "e",
+			want: []string{"a", "c", "e"},
+		},
+		{
+			name:    "ShortestPathBFS against edge direction",
+			from:    "e",
+			to:      "a",
+			wantErr: ErrNoPathFound,
+		},
+		{
+			name:    "ShortestPathBFS unknown key",
+			from:    "a",
+			to:      "x",
+			wantErr: ErrKeyNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ShortestPathBFS(FromSlice(nodes), taskName, taskDependencies, tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ShortestPathBFS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := Select(got, taskName).ToSlice(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ShortestPathBFS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
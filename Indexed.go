@@ -0,0 +1,85 @@
+package linq
+
+// The length of a sequence, and random access to its elements, that an operator knows without enumerating its source.
+//
+// Slice-, map- and range-backed sources carry it in their descriptor, and Select, Skip, Take and Reverse pass it on,
+// so that Count, ElementAt, Last, TakeLast, SkipLast, Reverse and ToSlice do not have to walk or buffer the sequence.
+type indexed[TSource any] struct {
+
+	// The number of elements.
+	length int
+
+	// Returns the element at an index in [0, length), or is nil if only the length is known.
+	at func(index int) TSource
+}
+
+// Returns the random access a sequence supports, without enumerating it.
+// Only sequences whose descriptor has an index are asked; others, such as Where, are not.
+func indexOf[TSource any](source Iterator[TSource]) (result indexed[TSource], ok bool) {
+	description := descriptorOf(source, hasIndex)
+	if description == nil {
+		return result, false
+	}
+	return description.index()
+}
+
+// Reports whether source supports random access, in which case Reverse, SkipLast, TakeLast and Cycle read it by index
+// instead of buffering it.
+func randomAccess[TSource any](source Iterator[TSource]) bool {
+	indexed, ok := indexOf(source)
+	return ok && indexed.at != nil
+}
+
+func (source indexed[TSource]) skip(count int) indexed[TSource] {
+	count = min(max(count, 0), source.length)
+	result := indexed[TSource]{
+		length: source.length - count,
+	}
+	if at := source.at; at != nil {
+		result.at = func(index int) TSource {
+			return at(index + count)
+		}
+	}
+	return result
+}
+
+func (source indexed[TSource]) take(count int) indexed[TSource] {
+	return indexed[TSource]{
+		length: min(max(count, 0), source.length),
+		at:     source.at,
+	}
+}
+
+func (source indexed[TSource]) reverse() indexed[TSource] {
+	result := indexed[TSource]{
+		length: source.length,
+	}
+	if at := source.at; at != nil {
+		last := source.length - 1
+		result.at = func(index int) TSource {
+			return at(last - index)
+		}
+	}
+	return result
+}
+
+func selectIndexed[TSource any, TResult any](source indexed[TSource], valueSelector func(TSource) TResult) indexed[TResult] {
+	result := indexed[TResult]{
+		length: source.length,
+	}
+	if at := source.at; at != nil {
+		result.at = func(index int) TResult {
+			return valueSelector(at(index))
+		}
+	}
+	return result
+}
+
+// Yields the elements in [from, to) in order.
+func (source indexed[TSource]) yieldRange(from int, to int, yield func(value TSource) bool) {
+	for i := from; i < to; i++ {
+		if !yield(source.at(i)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package linq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestIndexOf(t *testing.T) {
+	numbers := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	tests := []struct {
+		name   string
+		source Iterator[int]
+		want   []int
+		ok     bool
+	}{
+		{
+			name:   "FromSlice",
+			source: FromSlice(numbers),
+			want:   numbers,
+			ok:     true,
+		},
+		{
+			name:   "Range, Skip, Take, Reverse",
+			source: Range(10, 8).Skip(2).Take(4).Reverse(),
+			want:   []int{15, 14, 13, 12},
+			ok:     true,
+		},
+		{
+			name: "Select",
+			source: Select(FromSlice(numbers).Skip(6), func(item int) int {
+				return item * 10
+			}),
+			want: []int{60, 70},
+			ok:   true,
+		},
+		{
+			name:   "Repeat, Take more than the count",
+			source: Repeat(7, 2).Take(5),
+			want:   []int{7, 7},
+			ok:     true,
+		},
+		{
+			name: "Where",
+			source: FromSlice(numbers).Where(func(item int) bool {
+				return true
+			}),
+			want: numbers,
+		},
+		{
+			name:   "opaque",
+			source: oneShot(numbers...),
+			want:   numbers,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := indexOf(tt.source)
+			if ok != tt.ok {
+				t.Fatalf("indexOf() ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				if again := tt.source.ToSlice(); !reflect.DeepEqual(again, tt.want) {
+					t.Errorf("indexOf() enumerated the sequence, which then yielded %v, want %v", again, tt.want)
+				}
+				return
+			}
+			elements := make([]int, got.length)
+			for i := range elements {
+				elements[i] = got.at(i)
+			}
+			if !reflect.DeepEqual(elements, tt.want) {
+				t.Errorf("indexOf() = %v, want %v", elements, tt.want)
+			}
+			if again := tt.source.ToSlice(); !reflect.DeepEqual(again, tt.want) {
+				t.Errorf("indexOf() changed the sequence to %v, want %v", again, tt.want)
+			}
+		})
+	}
+	t.Run("FromMap is sized only", func(t *testing.T) {
+		got, ok := indexOf(FromMap(map[string]int{"a": 1, "b": 2}))
+		if !ok || got.length != 2 || got.at != nil {
+			t.Errorf("indexOf() = %v, %v, want length 2 without random access", got.length, ok)
+		}
+	})
+}
+
+func TestIterator_randomAccess(t *testing.T) {
+	calls := 0
+	tens := Select(FromSlice([]int{1, 2, 3, 4, 5}), func(item int) int {
+		calls++
+		return item * 10
+	})
+	if got := tens.Count(); got != 5 || calls != 0 {
+		t.Errorf("Iterator.Count() = %v with %d selector calls, want 5 with 0", got, calls)
+	}
+	if got, err := tens.ElementAt(3); got != 40 || err != nil || calls != 1 {
+		t.Errorf("Iterator.ElementAt() = %v, %v with %d selector calls, want 40, nil with 1", got, err, calls)
+	}
+	if _, err := tens.ElementAt(5); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Iterator.ElementAt() error = %v, want %v", err, ErrIndexOutOfRange)
+	}
+	if got := tens.ElementAtOrDefault(9); got != 0 {
+		t.Errorf("Iterator.ElementAtOrDefault() = %v, want 0", got)
+	}
+	if got, err := tens.Last(); got != 50 || err != nil {
+		t.Errorf("Iterator.Last() = %v, %v, want 50, nil", got, err)
+	}
+	if _, err := FromSlice([]int{}).Last(); !errors.Is(err, ErrSourceContainsNoElements) {
+		t.Errorf("Iterator.Last() error = %v, want %v", err, ErrSourceContainsNoElements)
+	}
+	if got := Range(1, 4).LastOrDefault(); got != 4 {
+		t.Errorf("Iterator.LastOrDefault() = %v, want 4", got)
+	}
+	if got, want := tens.TakeLast(2).ToSlice(), []int{40, 50}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator.TakeLast() = %v, want %v", got, want)
+	}
+	if got, want := tens.SkipLast(3).ToSlice(), []int{10, 20}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator.SkipLast() = %v, want %v", got, want)
+	}
+	if got, want := tens.Reverse().Take(2).ToSlice(), []int{50, 40}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator.Reverse() = %v, want %v", got, want)
+	}
+	if got := FromSlice([]int{1, 2, 3, 4}).Skip(1).ToSlice(); cap(got) != 3 {
+		t.Errorf("Iterator.ToSlice() capacity = %v, want 3", cap(got))
+	}
+	for _, query := range []Iterator[int]{tens.Reverse(), tens.SkipLast(1), tens.TakeLast(1)} {
+		if got := Plan(query); got.Buffers {
+			t.Errorf("Plan() = %v, want no buffering over a slice", got)
+		}
+	}
+	odd := tens.Where(func(item int) bool {
+		return item%20 == 10
+	})
+	for _, query := range []Iterator[int]{odd.Reverse(), odd.SkipLast(1), odd.TakeLast(1)} {
+		if got := Plan(query); !got.Buffers {
+			t.Errorf("Plan() = %v, want buffering over Where", got)
+		}
+	}
+}
+
+func BenchmarkCount(b *testing.B) {
+	numbers := benchmarkNumbers()
+	b.Run("opaque", func(b *testing.B) {
+		var source Iterator[int] = func(yield func(value int) bool) {
+			for _, item := range numbers {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+		for b.Loop() {
+			source.Count()
+		}
+	})
+	b.Run("FromSlice", func(b *testing.B) {
+		for b.Loop() {
+			FromSlice(numbers).Count()
+		}
+	})
+}
+
+func BenchmarkWhere(b *testing.B) {
+	numbers := []int{1, 2, 3, 4, 5}
+	isOdd := func(item int) bool { return item%2 == 1 }
+	b.Run("ToSlice", func(b *testing.B) {
+		for b.Loop() {
+			FromSlice(numbers).Where(isOdd).ToSlice()
+		}
+	})
+	b.Run("Count", func(b *testing.B) {
+		for b.Loop() {
+			FromSlice(numbers).Where(isOdd).Count()
+		}
+	})
+}
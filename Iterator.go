@@ -140,7 +140,11 @@ func (source Iterator[TSource]) Any(predicate ...generic.Predicate[TSource]) (re
 //
 // A new sequence that ends with elements.
 func (source Iterator[TSource]) Append(elements ...TSource) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Append", false, "O(n)", []string{parameter("count", len(elements))}, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
 		for item := range source {
 			if !yield(item) {
 				return
@@ -151,7 +155,7 @@ func (source Iterator[TSource]) Append(elements ...TSource) (result Iterator[TSo
 				return
 			}
 		}
-	}
+	})
 }
 
 // Computes the average of a sequence of numeric values.
@@ -204,13 +208,17 @@ func Average[TSource generic.Real](source Iterator[TSource]) (result float64, er
 //
 // When an element in the sequence cannot be cast to type TResult.
 func Cast[TSource any, TResult any](source Iterator[TSource]) (result Iterator[TResult]) {
-	return func(yield func(value TResult) bool) {
+	return describe(descriptor[TResult]{
+		plan: func() *PlanNode {
+			return node("Cast", false, "O(n)", nil, askPlan(source))
+		},
+	}, func(yield func(value TResult) bool) {
 		for item := range source {
 			if !yield((any(item)).(TResult)) {
 				return
 			}
 		}
-	}
+	})
 }
 
 // Splits the elements of a sequence into chunks of size at most <size>.
@@ -240,7 +248,11 @@ func Chunk[TSource any](source Iterator[TSource], size int) (result Iterator[[]T
 	if size < 1 {
 		panic(ErrSizeIsBelowOne)
 	}
-	return func(yield func(value []TSource) bool) {
+	return describe(descriptor[[]TSource]{
+		plan: func() *PlanNode {
+			return node("Chunk", true, "O(n)", []string{parameter("size", size)}, askPlan(source))
+		},
+	}, func(yield func(value []TSource) bool) {
 		chunk := make([]TSource, size)
 		i := 0
 		for item := range source {
@@ -259,7 +271,7 @@ func Chunk[TSource any](source Iterator[TSource], size int) (result Iterator[[]T
 				return
 			}
 		}
-	}
+	})
 }
 
 // Concatenates two sequences.
@@ -276,7 +288,11 @@ func Chunk[TSource any](source Iterator[TSource], size int) (result Iterator[[]T
 //
 // An Iterator[TSource] that contains the concatenated elements of the original sequence and input sequence.
 func (source Iterator[TSource]) Concat(sequence Iterator[TSource]) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Concat", false, "O(n+m)", nil, askPlan(source), askPlan(sequence))
+		},
+	}, func(yield func(value TSource) bool) {
 		for item := range source {
 			if !yield(item) {
 				return
@@ -287,7 +303,7 @@ func (source Iterator[TSource]) Concat(sequence Iterator[TSource]) (result Itera
 				return
 			}
 		}
-	}
+	})
 }
 
 // Determines whether a sequence contains a specified element by using a specified generic.Equality[TSource].
@@ -369,9 +385,12 @@ func (source Iterator[TSource]) Contains(value TSource, comparer ...generic.Equa
 // Before doing this, it is checked whether the type TSource implements the generic.IEquatable interface.
 // If so, the Equals() method from that interface is used to compare elements to the specified values.
 func (source Iterator[TSource]) ContainsAny(values []TSource, comparer ...generic.Equality[TSource]) (result bool) {
-	for _, value := range values {
-		if source.Contains(value, comparer...) {
-			return true
+	Equal := equality(comparer...)
+	for item := range source {
+		for _, value := range values {
+			if Equal(item, value) {
+				return true
+			}
 		}
 	}
 	return false
@@ -402,12 +421,63 @@ func (source Iterator[TSource]) ContainsAny(values []TSource, comparer ...generi
 // Before doing this, it is checked whether the type TSource implements the generic.IEquatable interface.
 // If so, the Equals() method from that interface is used to compare elements to the specified values.
 func (source Iterator[TSource]) ContainsAll(values []TSource, comparer ...generic.Equality[TSource]) (result bool) {
-	for _, value := range values {
-		if !source.Contains(value, comparer...) {
-			return false
+	Equal := equality(comparer...)
+	missing := slices.Clone(values)
+	for item := range source {
+		if len(missing) == 0 {
+			return true
 		}
+		missing = slices.DeleteFunc(missing, func(value TSource) bool {
+			return Equal(item, value)
+		})
 	}
-	return true
+	return len(missing) == 0
+}
+
+// Returns the equality function used by Contains to compare an element of the sequence with a value.
+func equality[TSource any](comparer ...generic.Equality[TSource]) (result generic.Equality[TSource]) {
+	if len(comparer) > 0 && comparer[0] != nil {
+		return comparer[0]
+	}
+	return func(item, value TSource) bool {
+		if v, ok := (any(value)).(generic.IEquatable[TSource]); ok {
+			return v.Equal(item)
+		}
+		if v := reflect.ValueOf(value); v.Comparable() {
+			return v.Equal(reflect.ValueOf(item))
+		}
+		return reflect.DeepEqual(item, value)
+	}
+}
+
+// Reports whether the elements of TSource can key a map with the same result as equality: no comparer is passed,
+// TSource does not implement generic.IEquatable and == cannot panic for it.
+func keyable[TSource any](comparer ...generic.Equality[TSource]) bool {
+	if len(comparer) > 0 && comparer[0] != nil {
+		return false
+	}
+	if _, ok := (any(*new(TSource))).(generic.IEquatable[TSource]); ok {
+		return false
+	}
+	return comparableType(reflect.TypeFor[TSource]())
+}
+
+// Reports whether == is defined for a type and cannot panic, that is whether it is comparable and holds no interface values.
+func comparableType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return false
+	case reflect.Array:
+		return comparableType(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if !comparableType(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return t.Comparable()
 }
 
 // Returns the number of elements in a sequence or returns a number that represents how many elements in the specified sequence satisfy a condition in predicate if passed.
@@ -423,6 +493,11 @@ func (source Iterator[TSource]) ContainsAll(values []TSource, comparer ...generi
 //	result int
 //
 // The number of elements in the input sequence or a number that represents how many elements in the sequence satisfy the condition in the predicate function if passed.
+//
+// # Remarks
+//
+// If source is backed by a slice, a map or Range, possibly through Select, Skip, Take and Reverse, and predicate is omitted,
+// the number of elements is known without enumerating source; the value selectors of Select are not called.
 func (source Iterator[TSource]) Count(predicate ...generic.Predicate[TSource]) (result int) {
 	if len(predicate) > 0 && predicate[0] != nil {
 		Predicate := predicate[0]
@@ -432,6 +507,9 @@ func (source Iterator[TSource]) Count(predicate ...generic.Predicate[TSource]) (
 			}
 		}
 	} else {
+		if source, ok := indexOf(source); ok {
+			return source.length
+		}
 		for range source {
 			result++
 		}
@@ -458,8 +536,14 @@ func (source Iterator[TSource]) Count(predicate ...generic.Predicate[TSource]) (
 // If the comparer parameter is omitted or nil, the default equality comparator is used to compare elements to the specified value.
 // Before doing this, it is checked whether the type TSource implements the generic.IEquatable interface.
 // If so, the Equals() method from that interface is used to compare elements to the specified value.
+//
+// The distinct elements are held in memory; see the package-level Distinct for sequences whose distinct elements may not fit.
 func (source Iterator[TSource]) Distinct(comparer ...generic.Equality[TSource]) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Distinct", true, "O(n²)", nil, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
 		result := make([]TSource, 0)
 		for item := range source {
 			if !FromSlice(result).Contains(item, comparer...) {
@@ -471,7 +555,7 @@ func (source Iterator[TSource]) Distinct(comparer ...generic.Equality[TSource])
 				return
 			}
 		}
-	}
+	})
 }
 
 // Returns the element at a specified index in a sequence.
@@ -493,10 +577,20 @@ func (source Iterator[TSource]) Distinct(comparer ...generic.Equality[TSource])
 //	err error
 //
 //	linq.ErrIndexOutOfRange - When index is less than 0 or greater than or equal to the number of elements in source.
+//
+// # Remarks
+//
+// If source is backed by a slice or Range, possibly through Select, Skip, Take and Reverse, the element is read directly.
 func (source Iterator[TSource]) ElementAt(index int) (result TSource, err error) {
 	if index < 0 {
 		return result, ErrIndexOutOfRange
 	}
+	if source, ok := indexOf(source); ok && source.at != nil {
+		if index >= source.length {
+			return result, ErrIndexOutOfRange
+		}
+		return source.at(index), nil
+	}
 	for item := range source {
 		if index == 0 {
 			return item, nil
@@ -519,10 +613,20 @@ func (source Iterator[TSource]) ElementAt(index int) (result TSource, err error)
 //	reslut TSource
 //
 // Default value if index is outside the bounds of the source sequence; otherwise, the element at the specified position in the source sequence.
+//
+// # Remarks
+//
+// If source is backed by a slice or Range, possibly through Select, Skip, Take and Reverse, the element is read directly.
 func (source Iterator[TSource]) ElementAtOrDefault(index int) (result TSource) {
 	if index < 0 {
 		return result
 	}
+	if source, ok := indexOf(source); ok && source.at != nil {
+		if index >= source.length {
+			return result
+		}
+		return source.at(index)
+	}
 	for item := range source {
 		if index == 0 {
 			return item
@@ -582,49 +686,33 @@ func (source Iterator[TSource]) ElementAtOrFallback(index int, fallback TSource)
 // This method returns those elements in source that don't appear in sequence.
 // It doesn't return those elements in sequence that don't appear in source.
 // Only unique elements are returned.
+// The sequence is enumerated once and its distinct elements are buffered.
 //
 // # Example
 //
 //	source := FromSlice([]int{1, 2, 3, 1, 2, 3})
-//	sequence := FroSlice([]int{1, 2, 1, 1})
+//	sequence := FromSlice([]int{1, 2, 1, 1})
 //	result := source.Except(sequence).ToSlice()
 //	/*This code produces the following output result = []int{3}*/
 func (source Iterator[TSource]) Except(sequence Iterator[TSource], comparer ...generic.Equality[TSource]) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
-		if len(comparer) > 0 {
-			isEqual := comparer[0]
-			for item := range source.Distinct(isEqual) {
-				for other := range sequence.Distinct(isEqual) {
-					if isEqual(item, other) {
-						if !yield(item) {
-							return
-						}
-					}
-				}
-			}
-		} else if _, ok := (any(*new(TSource))).(generic.IEquatable[TSource]); ok {
-			for item := range source.Distinct() {
-				c := any(item).(generic.IEquatable[TSource])
-				for other := range sequence.Distinct() {
-					if c.Equal(other) {
-						if !yield(item) {
-							return
-						}
-					}
-				}
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Except", true, "O(n·m)", nil, askPlan(source), askPlan(sequence))
+		},
+	}, func(yield func(value TSource) bool) {
+		isEqual := equality(comparer...)
+		others := sequence.Distinct(comparer...).ToSlice()
+		for item := range source.Distinct(comparer...) {
+			if slices.ContainsFunc(others, func(other TSource) bool {
+				return isEqual(item, other)
+			}) {
+				continue
 			}
-		} else {
-			for item := range source.Distinct() {
-				for other := range sequence.Distinct() {
-					if reflect.DeepEqual(item, other) {
-						if !yield(item) {
-							return
-						}
-					}
-				}
+			if !yield(item) {
+				return
 			}
 		}
-	}
+	})
 }
 
 // Returns the first element of a sequence or returns the first element in a sequence that satisfies a specified condition in predicate if passed.
@@ -663,7 +751,7 @@ func (source Iterator[TSource]) First(predicate ...generic.Predicate[TSource]) (
 		}
 		return *new(TSource), ErrNoElementSatisfiesTheConditionInPredicate
 	}
-	for item := range source {
+	for item := range source.Take(1) {
 		return item, nil
 	}
 	return *new(TSource), ErrSourceContainsNoElements
@@ -691,7 +779,7 @@ func (source Iterator[TSource]) FirstOrDefault(predicate ...generic.Predicate[TS
 			}
 		}
 	} else {
-		for item := range source {
+		for item := range source.Take(1) {
 			return item
 		}
 	}
@@ -720,15 +808,61 @@ func (source Iterator[TSource]) FirstOrFallback(fallback TSource, predicate ...g
 			}
 		}
 	} else {
-		for item := range source {
+		for item := range source.Take(1) {
 			return item
 		}
 	}
 	return fallback
 }
 
-func GroupBy[TSource any, TKey comparable](source Iterator[TSource], keySelector generic.KeySelector[TSource, TKey]) (result Iterator[generic.KeyValuePair[TKey, Iterator[TSource]]]) {
-	return func(yield func(object generic.KeyValuePair[TKey, Iterator[TSource]]) bool) {
+// Groups the elements of a sequence according to a key.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to group.
+//
+//	keySelector generic.KeySelector[TSource, TKey]
+//
+// A function to extract the key of an element.
+//
+//	options SpillOptions[TSource]
+//
+// The memory budget, the temporary directory and the codec of the elements. If passed, the elements are spilled to disk
+// when they exceed the budget. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[generic.KeyValuePair[TKey, Iterator[TSource]]]
+//
+// One pair per key, with the elements of the key in source order. Without options, the groups are yielded in no particular
+// order; with options, in ascending order of key.
+//
+// # Panics
+//
+// With linq.ErrTypeIsNotOrdered, when GroupBy is called, if options are passed and TKey has no natural order, because the
+// spilled elements are sorted by key. With the error of a temporary file, while the result is enumerated, if writing
+// or reading it fails. GroupBy with options is a convenience: call GroupByExternal to have these errors yielded instead.
+//
+// # Remarks
+//
+// Without options, all the elements are held in memory. With options, GroupBy groups as GroupByExternal does: the elements
+// are kept in memory while they fit in the budget, and only then sorted in runs on disk.
+func GroupBy[TSource any, TKey comparable](source Iterator[TSource], keySelector generic.KeySelector[TSource, TKey], options ...SpillOptions[TSource]) (result Iterator[generic.KeyValuePair[TKey, Iterator[TSource]]]) {
+	if len(options) > 0 {
+		groups := GroupByExternal(source, keySelector, options[0], must(DefaultComparison[TKey]()))
+		return describe(descriptor[generic.KeyValuePair[TKey, Iterator[TSource]]]{
+			plan: func() *PlanNode {
+				return node("GroupBy", true, "O(n log n)", []string{parameter("spill", true)}, askPlan(source))
+			},
+		}, spilled(groups))
+	}
+	return describe(descriptor[generic.KeyValuePair[TKey, Iterator[TSource]]]{
+		plan: func() *PlanNode {
+			return node("GroupBy", true, "O(n)", nil, askPlan(source))
+		},
+	}, func(yield func(object generic.KeyValuePair[TKey, Iterator[TSource]]) bool) {
 		groups := make(map[TKey][]TSource)
 		for item := range source {
 			key := keySelector(item)
@@ -742,7 +876,7 @@ func GroupBy[TSource any, TKey comparable](source Iterator[TSource], keySelector
 				return
 			}
 		}
-	}
+	})
 }
 
 // Produces the set intersection of two sequences.
@@ -769,19 +903,25 @@ func GroupBy[TSource any, TKey comparable](source Iterator[TSource], keySelector
 // This method returns those elements in source that also appear in sequence.
 // It doesn't return those elements in sequence that don't appear in source.
 // Only unique elements are returned.
+// The sequence is enumerated once and its distinct elements are buffered.
 //
 // # Example
 //
 //	source := FromSlice([]int{1, 2, 3, 1, 2, 3})
-//	sequence := FroSlice([]int{1, 2, 1, 1})
+//	sequence := FromSlice([]int{1, 2, 1, 1})
 //	result := source.Except(sequence).ToSlice()
 //	/*This code produces the following output result = []int{1, 2}*/
 func (source Iterator[TSource]) Intersect(sequence Iterator[TSource], comparer ...generic.Equality[TSource]) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Intersect", true, "O(n·m)", nil, askPlan(source), askPlan(sequence))
+		},
+	}, func(yield func(value TSource) bool) {
 		if len(comparer) > 0 {
 			isEqual := comparer[0]
+			others := sequence.Distinct(isEqual).ToSlice()
 			for item := range source.Distinct(isEqual) {
-				for other := range sequence.Distinct(isEqual) {
+				for _, other := range others {
 					if isEqual(item, other) {
 						if !yield(item) {
 							return
@@ -790,9 +930,10 @@ func (source Iterator[TSource]) Intersect(sequence Iterator[TSource], comparer .
 				}
 			}
 		} else if _, ok := (any(*new(TSource))).(generic.IEquatable[TSource]); ok {
+			others := sequence.Distinct().ToSlice()
 			for item := range source.Distinct() {
 				c := any(item).(generic.IEquatable[TSource])
-				for other := range sequence.Distinct() {
+				for _, other := range others {
 					if c.Equal(other) {
 						if !yield(item) {
 							return
@@ -801,8 +942,9 @@ func (source Iterator[TSource]) Intersect(sequence Iterator[TSource], comparer .
 				}
 			}
 		} else {
+			others := sequence.Distinct().ToSlice()
 			for item := range source.Distinct() {
-				for other := range sequence.Distinct() {
+				for _, other := range others {
 					if reflect.DeepEqual(item, other) {
 						if !yield(item) {
 							return
@@ -811,14 +953,19 @@ func (source Iterator[TSource]) Intersect(sequence Iterator[TSource], comparer .
 				}
 			}
 		}
-	}
+	})
 }
 
 func joinComparer[TOuter any, TInner any, TKey any, TResult any](outer Iterator[TOuter], inner Iterator[TInner], outerKeySelector generic.ValueSelector[TOuter, TKey], innerKeySelector generic.ValueSelector[TInner, TKey], resultSelector func(outer TOuter, inner TInner) TResult, isEqual generic.Equality[TKey]) (result Iterator[TResult]) {
-	return func(yield func(value TResult) bool) {
+	return describe(descriptor[TResult]{
+		plan: func() *PlanNode {
+			return node("Join", true, "O(n·m)", []string{"match=comparer"}, askPlan(outer), askPlan(inner))
+		},
+	}, func(yield func(value TResult) bool) {
+		innerItems := inner.ToSlice()
 		for outerItem := range outer {
 			outerKey := outerKeySelector(outerItem)
-			for innerItem := range inner {
+			for _, innerItem := range innerItems {
 				innerKey := innerKeySelector(innerItem)
 				if isEqual(outerKey, innerKey) {
 					if !yield(resultSelector(outerItem, innerItem)) {
@@ -827,14 +974,19 @@ func joinComparer[TOuter any, TInner any, TKey any, TResult any](outer Iterator[
 				}
 			}
 		}
-	}
+	})
 }
 
 func joinEquatable[TOuter any, TInner any, TKey any, TResult any](outer Iterator[TOuter], inner Iterator[TInner], outerKeySelector generic.ValueSelector[TOuter, TKey], innerKeySelector generic.ValueSelector[TInner, TKey], resultSelector func(outer TOuter, inner TInner) TResult) (result Iterator[TResult]) {
-	return func(yield func(value TResult) bool) {
+	return describe(descriptor[TResult]{
+		plan: func() *PlanNode {
+			return node("Join", true, "O(n·m)", []string{"match=Equal"}, askPlan(outer), askPlan(inner))
+		},
+	}, func(yield func(value TResult) bool) {
+		innerItems := inner.ToSlice()
 		for outerItem := range outer {
 			outerKey := any(outerKeySelector(outerItem)).(generic.IEquatable[TKey])
-			for innerItem := range inner {
+			for _, innerItem := range innerItems {
 				innerKey := innerKeySelector(innerItem)
 				if outerKey.Equal(innerKey) {
 					if !yield(resultSelector(outerItem, innerItem)) {
@@ -843,14 +995,19 @@ func joinEquatable[TOuter any, TInner any, TKey any, TResult any](outer Iterator
 				}
 			}
 		}
-	}
+	})
 }
 
 func joinComparable[TOuter any, TInner any, TKey any, TResult any](outer Iterator[TOuter], inner Iterator[TInner], outerKeySelector generic.ValueSelector[TOuter, TKey], innerKeySelector generic.ValueSelector[TInner, TKey], resultSelector func(outer TOuter, inner TInner) TResult) (result Iterator[TResult]) {
-	return func(yield func(value TResult) bool) {
+	return describe(descriptor[TResult]{
+		plan: func() *PlanNode {
+			return node("Join", true, "O(n·m)", []string{"match=DeepEqual"}, askPlan(outer), askPlan(inner))
+		},
+	}, func(yield func(value TResult) bool) {
+		innerItems := inner.ToSlice()
 		for outerItem := range outer {
 			outerKey := outerKeySelector(outerItem)
-			for innerItem := range inner {
+			for _, innerItem := range innerItems {
 				innerKey := innerKeySelector(innerItem)
 				if reflect.DeepEqual(outerKey, innerKey) {
 					if !yield(resultSelector(outerItem, innerItem)) {
@@ -859,7 +1016,7 @@ func joinComparable[TOuter any, TInner any, TKey any, TResult any](outer Iterato
 				}
 			}
 		}
-	}
+	})
 }
 
 func Join[TOuter any, TInner any, TKey any, TResult any](outer Iterator[TOuter], inner Iterator[TInner], outerKeySelector generic.ValueSelector[TOuter, TKey], innerKeySelector generic.ValueSelector[TInner, TKey], resultSelector func(outer TOuter, inner TInner) TResult, comparer ...generic.Equality[TKey]) (result Iterator[TResult]) {
@@ -893,6 +1050,11 @@ func Join[TOuter any, TInner any, TKey any, TResult any](outer Iterator[TOuter],
 // linq.ErrSourceContainsNoElements - When sequence contains no elelements.
 //
 // linq.ErrNoElementSatisfiesTheConditionInPredicate - When sequence contains elements but none of them passes the test in the specified predicate function if passed.
+//
+// # Remarks
+//
+// If source is backed by a slice or Range, possibly through Select, Skip, Take and Reverse, and predicate is omitted,
+// the last element is read directly.
 func (source Iterator[TSource]) Last(predicate ...generic.Predicate[TSource]) (result TSource, err error) {
 	found := false
 	if len(predicate) > 0 && predicate[0] != nil {
@@ -913,6 +1075,12 @@ func (source Iterator[TSource]) Last(predicate ...generic.Predicate[TSource]) (r
 		}
 		return *new(TSource), ErrNoElementSatisfiesTheConditionInPredicate
 	}
+	if source, ok := indexOf(source); ok && source.at != nil {
+		if source.length == 0 {
+			return *new(TSource), ErrSourceContainsNoElements
+		}
+		return source.at(source.length - 1), nil
+	}
 	for item := range source {
 		found = true
 		result = item
@@ -936,6 +1104,11 @@ func (source Iterator[TSource]) Last(predicate ...generic.Predicate[TSource]) (r
 //	result TSource
 //
 // Default value if source is empty or if no element passes the test specified by predicate; otherwise, the last element in source that passes the test specified by predicate.
+//
+// # Remarks
+//
+// If source is backed by a slice or Range, possibly through Select, Skip, Take and Reverse, and predicate is omitted,
+// the last element is read directly.
 func (source Iterator[TSource]) LastOrDefault(predicate ...generic.Predicate[TSource]) (result TSource) {
 	found := false
 	if len(predicate) > 0 && predicate[0] != nil {
@@ -951,6 +1124,12 @@ func (source Iterator[TSource]) LastOrDefault(predicate ...generic.Predicate[TSo
 		}
 		return *new(TSource)
 	}
+	if source, ok := indexOf(source); ok && source.at != nil {
+		if source.length == 0 {
+			return *new(TSource)
+		}
+		return source.at(source.length - 1)
+	}
 	for item := range source {
 		found = true
 		result = item
@@ -1003,98 +1182,50 @@ func (source Iterator[TSource]) LastOrFallback(fallback TSource, predicate ...ge
 	return fallback
 }
 
-func minmax[TSource any, T generic.Real | generic.String](source Iterator[TSource]) (resultMin, resultMax TSource, err error) {
+// Returns the maximum value of a sequence.
+//
+// # Parameters
+//
+//	compare generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	max TSource
+//
+// The maximum value in the sequence.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrSourceContainsNoElements - When sequence contains no elements.
+//
+// linq.ErrTypeIsNotOrdered - When compare is omitted and TSource has no natural order (see DefaultComparison).
+//
+// # Remarks
+//
+// If several elements are equal to the maximum, the first of them is returned.
+//
+// A passed compare ranks the maximum first: Max returns an element m for which compare(m, item) is not positive for any item,
+// that is, the first element of Order(compare). Without compare, Max returns the greatest element in the natural order.
+func (source Iterator[TSource]) Max(compare ...generic.Comparison[TSource]) (max TSource, err error) {
+	Compare, err := extremeComparison(compare...)
+	if err != nil {
+		return max, err
+	}
 	found := false
-	var min, max T
 	for item := range source {
-		value := any(item).(T)
-		if !found {
-			min = value
-			max = value
+		if !found || Compare(item, max) > 0 {
+			max = item
 			found = true
-			continue
-		}
-		if value < min {
-			min = value
-			continue
-		}
-		if value > max {
-			max = value
 		}
 	}
 	if !found {
-		return resultMin, resultMax, ErrSourceContainsNoElements
-	}
-	return any(min).(TSource), any(max).(TSource), nil
-}
-
-func (source Iterator[TSource]) Max(compare ...generic.Comparison[TSource]) (max TSource, err error) {
-	found := false
-	if len(compare) > 0 {
-		for item := range source {
-			if !found {
-				max = item
-				found = true
-				continue
-			}
-			if compare[0](max, item) > 0 {
-				max = item
-			}
-		}
-		if !found {
-			return max, ErrSourceContainsNoElements
-		}
-		return max, nil
+		return max, ErrSourceContainsNoElements
 	}
-	if v, ok := (any(max)).(generic.IComparable[TSource]); ok {
-		for item := range source {
-			if !found {
-				max = item
-				found = true
-				continue
-			}
-			if v.Compare(item) > 0 {
-				max = item
-			}
-		}
-		if !found {
-			return max, ErrSourceContainsNoElements
-		}
-		return max, nil
-	}
-	switch (any(*new(TSource))).(type) {
-	case int:
-		_, max, err = minmax[TSource, int](source)
-	case int8:
-		_, max, err = minmax[TSource, int8](source)
-	case int16:
-		_, max, err = minmax[TSource, int16](source)
-	case int32:
-		_, max, err = minmax[TSource, int32](source)
-	case int64:
-		_, max, err = minmax[TSource, int64](source)
-	case uint:
-		_, max, err = minmax[TSource, uint](source)
-	case uint8:
-		_, max, err = minmax[TSource, uint8](source)
-	case uint16:
-		_, max, err = minmax[TSource, uint16](source)
-	case uint32:
-		_, max, err = minmax[TSource, uint32](source)
-	case uint64:
-		_, max, err = minmax[TSource, uint64](source)
-	case uintptr:
-		_, max, err = minmax[TSource, uintptr](source)
-	case float32:
-		_, max, err = minmax[TSource, float32](source)
-	case float64:
-		_, max, err = minmax[TSource, float64](source)
-	case string:
-		_, max, err = minmax[TSource, string](source)
-	default:
-		panic("unsupported type for Max")
-	}
-	return max, err
+	return max, nil
 }
 
 func Max[TSource generic.Comparable](source Iterator[TSource]) (max TSource, err error) {
@@ -1115,73 +1246,50 @@ func Max[TSource generic.Comparable](source Iterator[TSource]) (max TSource, err
 	return max, nil
 }
 
+// Returns the minimum value of a sequence.
+//
+// # Parameters
+//
+//	compare generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	min TSource
+//
+// The minimum value in the sequence.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrSourceContainsNoElements - When sequence contains no elements.
+//
+// linq.ErrTypeIsNotOrdered - When compare is omitted and TSource has no natural order (see DefaultComparison).
+//
+// # Remarks
+//
+// If several elements are equal to the minimum, the first of them is returned.
+//
+// A passed compare ranks the minimum last: Min returns an element m for which compare(m, item) is not negative for any item.
+// Without compare, Min returns the least element in the natural order. See Iterator.Max.
 func (source Iterator[TSource]) Min(compare ...generic.Comparison[TSource]) (min TSource, err error) {
+	Compare, err := extremeComparison(compare...)
+	if err != nil {
+		return min, err
+	}
 	found := false
-	if len(compare) > 0 {
-		for item := range source {
-			if !found {
-				min = item
-				found = true
-				continue
-			}
-			if compare[0](min, item) < 0 {
-				min = item
-			}
-		}
-		if !found {
-			return min, ErrSourceContainsNoElements
+	for item := range source {
+		if !found || Compare(item, min) < 0 {
+			min = item
+			found = true
 		}
-		return min, nil
 	}
-	if v, ok := (any(min)).(generic.IComparable[TSource]); ok {
-		for item := range source {
-			if !found {
-				min = item
-				found = true
-				continue
-			}
-			if v.Compare(item) < 0 {
-				min = item
-			}
-		}
-		if !found {
-			return min, ErrSourceContainsNoElements
-		}
-		return min, nil
-	}
-	switch (any(*new(TSource))).(type) {
-	case int:
-		min, _, err = minmax[TSource, int](source)
-	case int8:
-		min, _, err = minmax[TSource, int8](source)
-	case int16:
-		min, _, err = minmax[TSource, int16](source)
-	case int32:
-		min, _, err = minmax[TSource, int32](source)
-	case int64:
-		min, _, err = minmax[TSource, int64](source)
-	case uint:
-		min, _, err = minmax[TSource, uint](source)
-	case uint8:
-		min, _, err = minmax[TSource, uint8](source)
-	case uint16:
-		min, _, err = minmax[TSource, uint16](source)
-	case uint32:
-		min, _, err = minmax[TSource, uint32](source)
-	case uint64:
-		min, _, err = minmax[TSource, uint64](source)
-	case uintptr:
-		min, _, err = minmax[TSource, uintptr](source)
-	case float32:
-		min, _, err = minmax[TSource, float32](source)
-	case float64:
-		min, _, err = minmax[TSource, float64](source)
-	case string:
-		min, _, err = minmax[TSource, string](source)
-	default:
-		panic("unsupported type for Max")
-	}
-	return min, err
+	if !found {
+		return min, ErrSourceContainsNoElements
+	}
+	return min, nil
 }
 
 func Min[TSource generic.Comparable](source Iterator[TSource]) (min TSource, err error) {
@@ -1202,84 +1310,59 @@ func Min[TSource generic.Comparable](source Iterator[TSource]) (min TSource, err
 	return min, nil
 }
 
+// Returns the minimum and maximum values of a sequence.
+//
+// # Parameters
+//
+//	compare generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	min TSource
+//
+// The minimum value in the sequence.
+//
+//	max TSource
+//
+// The maximum value in the sequence.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrSourceContainsNoElements - When sequence contains no elements.
+//
+// linq.ErrTypeIsNotOrdered - When compare is omitted and TSource has no natural order (see DefaultComparison).
+//
+// # Remarks
+//
+// The sequence is enumerated once. If several elements are equal to the minimum or the maximum, the first of them is returned.
+// A passed compare is read as by Iterator.Min and Iterator.Max.
 func (source Iterator[TSource]) MinMax(compare ...generic.Comparison[TSource]) (min TSource, max TSource, err error) {
+	Compare, err := extremeComparison(compare...)
+	if err != nil {
+		return min, max, err
+	}
 	found := false
-	if len(compare) > 0 {
-		for item := range source {
-			if !found {
-				min = item
-				max = item
-				found = true
-				continue
-			}
-			if compare[0](min, item) < 0 {
-				min = item
-				continue
-			}
-			if compare[0](max, item) > 0 {
-				max = item
-			}
-		}
+	for item := range source {
 		if !found {
-			return min, max, ErrSourceContainsNoElements
+			min, max = item, item
+			found = true
+			continue
 		}
-		return min, max, nil
-	}
-	vmin, ok := (any(min)).(generic.IComparable[TSource])
-	vmax, ok2 := (any(min)).(generic.IComparable[TSource])
-	if ok && ok2 {
-		for item := range source {
-			if !found {
-				min = item
-				max = item
-				found = true
-				continue
-			}
-			if vmin.Compare(item) < 0 {
-				min = item
-			}
-			if vmax.Compare(item) > 0 {
-				max = item
-			}
+		if Compare(item, min) < 0 {
+			min = item
 		}
-		if !found {
-			return min, max, ErrSourceContainsNoElements
-		}
-		return min, max, nil
-	}
-	switch (any(*new(TSource))).(type) {
-	case int:
-		min, max, err = minmax[TSource, int](source)
-	case int8:
-		min, max, err = minmax[TSource, int8](source)
-	case int16:
-		min, max, err = minmax[TSource, int16](source)
-	case int32:
-		min, max, err = minmax[TSource, int32](source)
-	case int64:
-		min, max, err = minmax[TSource, int64](source)
-	case uint:
-		min, max, err = minmax[TSource, uint](source)
-	case uint8:
-		min, max, err = minmax[TSource, uint8](source)
-	case uint16:
-		min, max, err = minmax[TSource, uint16](source)
-	case uint32:
-		min, max, err = minmax[TSource, uint32](source)
-	case uint64:
-		min, max, err = minmax[TSource, uint64](source)
-	case uintptr:
-		min, max, err = minmax[TSource, uintptr](source)
-	case float32:
-		min, max, err = minmax[TSource, float32](source)
-	case float64:
-		min, max, err = minmax[TSource, float64](source)
-	case string:
-		min, max, err = minmax[TSource, string](source)
-	default:
-		panic("unsupported type for Max")
-	}
-	return min, max, err
+		if Compare(item, max) > 0 {
+			max = item
+		}
+	}
+	if !found {
+		return min, max, ErrSourceContainsNoElements
+	}
+	return min, max, nil
 }
 
 func MinMax[TSource generic.Comparable](source Iterator[TSource]) (min, max TSource, err error) {
@@ -1305,87 +1388,132 @@ func MinMax[TSource generic.Comparable](source Iterator[TSource]) (min, max TSou
 	return min, max, nil
 }
 
-func sort[TSource any, T generic.Comparable](source []TSource) {
-	slices.SortFunc(source, func(x, y TSource) int {
-		return cmp.Compare(any(x).(T), any(y).(T))
-	})
+// Sorts the elements of a sequence in ascending order.
+//
+// # Parameters
+//
+//	compare generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// A sequence whose elements are sorted. Elements that compare equal keep their source order.
+//
+// # Panics
+//
+// With linq.ErrTypeIsNotOrdered, when Order is called, if compare is omitted and TSource has no natural order.
+// Order is a convenience for types that are known to be ordered: call TryOrder to have the error returned instead,
+// or the package-level Order, which requires generic.Comparable, to have the order checked at compile time.
+func (source Iterator[TSource]) Order(compare ...generic.Comparison[TSource]) (result Iterator[TSource]) {
+	return must(source.TryOrder(compare...))
 }
 
-func (source Iterator[TSource]) Order(compare ...generic.Comparison[TSource]) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
+// Sorts the elements of a sequence in ascending order, or reports that they cannot be ordered.
+//
+// # Parameters
+//
+//	compare generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// A sequence whose elements are sorted, as by Iterator.Order; nil if err is not nil.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrTypeIsNotOrdered - When compare is omitted and TSource has no natural order (see DefaultComparison).
+//
+// # Example
+//
+//	sorted, err := linq.FromSlice(deadlines).TryOrder()
+//	if err != nil {
+//		return err
+//	}
+//	next := sorted.Take(3)
+func (source Iterator[TSource]) TryOrder(compare ...generic.Comparison[TSource]) (result Iterator[TSource], err error) {
+	Compare, err := resolveComparison(compare...)
+	if err != nil {
+		return nil, err
+	}
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Order", true, "O(n log n)", nil, askPlan(source))
+		},
+		filter: func(predicate generic.Predicate[TSource]) Iterator[TSource] {
+			return source.Where(predicate).Order(Compare)
+		},
+		first: func(count int) Iterator[TSource] {
+			return bottomK(source, count, Compare)
+		},
+	}, func(yield func(value TSource) bool) {
 		result1 := make([]TSource, 0)
 		for item := range source {
 			result1 = append(result1, item)
 		}
-		if len(compare) > 0 {
-			slices.SortFunc(result1, compare[0])
-		} else if _, ok := (any(*new(TSource))).(generic.IComparable[TSource]); ok {
-			slices.SortFunc(result1, func(first, second TSource) int {
-				return (any(first)).(generic.IComparable[TSource]).Compare(second)
-			})
-		} else {
-			switch (any(*new(TSource))).(type) {
-			case int:
-				sort[TSource, int](result1)
-			case int8:
-				sort[TSource, int8](result1)
-			case int16:
-				sort[TSource, int16](result1)
-			case int32:
-				sort[TSource, int32](result1)
-			case int64:
-				sort[TSource, int64](result1)
-			case uint:
-				sort[TSource, uint](result1)
-			case uint8:
-				sort[TSource, uint8](result1)
-			case uint16:
-				sort[TSource, uint16](result1)
-			case uint32:
-				sort[TSource, uint32](result1)
-			case uint64:
-				sort[TSource, uint64](result1)
-			case uintptr:
-				sort[TSource, uintptr](result1)
-			case float32:
-				sort[TSource, float32](result1)
-			case float64:
-				sort[TSource, float64](result1)
-			case string:
-				sort[TSource, string](result1)
-			default:
-				panic("unsupported type for Order")
-			}
-		}
+		slices.SortStableFunc(result1, Compare)
 		for _, item := range result1 {
 			if !yield(item) {
 				return
 			}
 		}
-	}
+	}), nil
 }
 
 func Order[TSource generic.Comparable](source Iterator[TSource], compare ...generic.Comparison[TSource]) Iterator[TSource] {
-	return func(yield func(value TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Order", true, "O(n log n)", nil, askPlan(source))
+		},
+		filter: func(predicate generic.Predicate[TSource]) Iterator[TSource] {
+			return Order(source.Where(predicate), compare...)
+		},
+		first: func(count int) Iterator[TSource] {
+			if len(compare) > 0 {
+				return bottomK(source, count, compare[0])
+			}
+			return bottomK(source, count, cmp.Compare[TSource])
+		},
+	}, func(yield func(value TSource) bool) {
 		result := make([]TSource, 0)
 		for item := range source {
 			result = append(result, item)
 		}
 		if len(compare) > 0 {
-			slices.SortFunc(result, compare[0])
+			slices.SortStableFunc(result, compare[0])
 		} else {
-			slices.Sort(result)
+			slices.SortStableFunc(result, cmp.Compare[TSource])
 		}
 		for _, item := range result {
 			if !yield(item) {
 				return
 			}
 		}
-	}
+	})
 }
 
 func OrderBy[TSource any, TValue generic.Comparable](source Iterator[TSource], valueSelector generic.ValueSelector[TSource, TValue], compare ...generic.Comparison[TValue]) Iterator[TSource] {
-	return func(yield func(value TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("OrderBy", true, "O(n log n)", nil, askPlan(source))
+		},
+		filter: func(predicate generic.Predicate[TSource]) Iterator[TSource] {
+			return OrderBy(source.Where(predicate), valueSelector, compare...)
+		},
+		first: func(count int) Iterator[TSource] {
+			if len(compare) > 0 {
+				return bottomKBy(source, count, valueSelector, compare[0])
+			}
+			return bottomKBy(source, count, valueSelector, cmp.Compare[TValue])
+		},
+	}, func(yield func(value TSource) bool) {
 		result := make([]generic.ValuePair[TSource, TValue], 0)
 		for item := range source {
 			result = append(result, generic.ValuePair[TSource, TValue]{
@@ -1395,11 +1523,11 @@ func OrderBy[TSource any, TValue generic.Comparable](source Iterator[TSource], v
 		}
 		if len(compare) > 0 {
 			Compare := compare[0]
-			slices.SortFunc(result, func(x, y generic.ValuePair[TSource, TValue]) int {
+			slices.SortStableFunc(result, func(x, y generic.ValuePair[TSource, TValue]) int {
 				return Compare(x.Item2, y.Item2)
 			})
 		} else {
-			slices.SortFunc(result, func(x, y generic.ValuePair[TSource, TValue]) int {
+			slices.SortStableFunc(result, func(x, y generic.ValuePair[TSource, TValue]) int {
 				return cmp.Compare(x.Item2, y.Item2)
 			})
 		}
@@ -1408,85 +1536,106 @@ func OrderBy[TSource any, TValue generic.Comparable](source Iterator[TSource], v
 				return
 			}
 		}
-	}
-}
-
-func sortDescending[TSource any, T generic.Real | generic.String](source []TSource) {
-	slices.SortFunc(source, func(x, y TSource) int {
-		return cmp.Compare(any(y).(T), any(x).(T))
 	})
 }
 
+// Sorts the elements of a sequence in descending order.
+//
+// # Parameters
+//
+//	compare generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// A sequence whose elements are sorted in descending order. Elements that compare equal keep their source order.
+//
+// # Panics
+//
+// See Iterator.Order. Call TryOrderDescending to have the error returned instead.
 func (source Iterator[TSource]) OrderDescending(compare ...generic.Comparison[TSource]) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
+	return must(source.TryOrderDescending(compare...))
+}
+
+// Sorts the elements of a sequence in descending order, or reports that they cannot be ordered.
+//
+// # Parameters
+//
+//	compare generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// A sequence whose elements are sorted, as by Iterator.OrderDescending; nil if err is not nil.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrTypeIsNotOrdered - When compare is omitted and TSource has no natural order (see DefaultComparison).
+func (source Iterator[TSource]) TryOrderDescending(compare ...generic.Comparison[TSource]) (result Iterator[TSource], err error) {
+	Compare, err := resolveComparison(compare...)
+	if err != nil {
+		return nil, err
+	}
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("OrderDescending", true, "O(n log n)", nil, askPlan(source))
+		},
+		filter: func(predicate generic.Predicate[TSource]) Iterator[TSource] {
+			return source.Where(predicate).OrderDescending(Compare)
+		},
+		first: func(count int) Iterator[TSource] {
+			return bottomK(source, count, descending(Compare))
+		},
+	}, func(yield func(value TSource) bool) {
 		result1 := make([]TSource, 0)
 		for item := range source {
 			result1 = append(result1, item)
 		}
-		if len(compare) > 0 {
-			Compare := compare[0]
-			slices.SortFunc(result1, func(x, y TSource) int {
-				return Compare(y, x)
-			})
-		} else if _, ok := (any(*new(TSource))).(generic.IComparable[TSource]); ok {
-			slices.SortFunc(result1, func(first, second TSource) int {
-				return (any(second)).(generic.IComparable[TSource]).Compare(first)
-			})
-		} else {
-			switch (any(*new(TSource))).(type) {
-			case int:
-				sortDescending[TSource, int](result1)
-			case int8:
-				sortDescending[TSource, int8](result1)
-			case int16:
-				sortDescending[TSource, int16](result1)
-			case int32:
-				sortDescending[TSource, int32](result1)
-			case int64:
-				sortDescending[TSource, int64](result1)
-			case uint:
-				sortDescending[TSource, uint](result1)
-			case uint8:
-				sortDescending[TSource, uint8](result1)
-			case uint16:
-				sortDescending[TSource, uint16](result1)
-			case uint32:
-				sortDescending[TSource, uint32](result1)
-			case uint64:
-				sortDescending[TSource, uint64](result1)
-			case uintptr:
-				sortDescending[TSource, uintptr](result1)
-			case float32:
-				sortDescending[TSource, float32](result1)
-			case float64:
-				sortDescending[TSource, float64](result1)
-			case string:
-				sortDescending[TSource, string](result1)
-			default:
-				panic("unsupported type for Order")
-			}
-		}
+		slices.SortStableFunc(result1, func(x, y TSource) int {
+			return Compare(y, x)
+		})
 		for _, item := range result1 {
 			if !yield(item) {
 				return
 			}
 		}
-	}
+	}), nil
 }
 
 func OrderDescending[TSource generic.Comparable](source Iterator[TSource], compare ...generic.Comparison[TSource]) Iterator[TSource] {
-	return func(yield func(value TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("OrderDescending", true, "O(n log n)", nil, askPlan(source))
+		},
+		filter: func(predicate generic.Predicate[TSource]) Iterator[TSource] {
+			return OrderDescending(source.Where(predicate), compare...)
+		},
+		first: func(count int) Iterator[TSource] {
+			if len(compare) > 0 {
+				return bottomK(source, count, descending(compare[0]))
+			}
+			return bottomK(source, count, descending(cmp.Compare[TSource]))
+		},
+	}, func(yield func(value TSource) bool) {
 		result := make([]TSource, 0)
 		for item := range source {
 			result = append(result, item)
 		}
 		if len(compare) > 0 {
 			Compare := compare[0]
-			slices.SortFunc(result, func(x, y TSource) int {
+			slices.SortStableFunc(result, func(x, y TSource) int {
 				return Compare(y, x)
 			})
 		} else {
-			slices.SortFunc(result, func(x, y TSource) int {
+			slices.SortStableFunc(result, func(x, y TSource) int {
 				return cmp.Compare(y, x)
 			})
 		}
@@ -1495,11 +1644,24 @@ func OrderDescending[TSource generic.Comparable](source Iterator[TSource], compa
 				return
 			}
 		}
-	}
+	})
 }
 
 func OrderByDescending[TSource any, TValue generic.Comparable](source Iterator[TSource], valueSelector generic.ValueSelector[TSource, TValue], compare ...generic.Comparison[TValue]) Iterator[TSource] {
-	return func(yield func(value TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("OrderByDescending", true, "O(n log n)", nil, askPlan(source))
+		},
+		filter: func(predicate generic.Predicate[TSource]) Iterator[TSource] {
+			return OrderByDescending(source.Where(predicate), valueSelector, compare...)
+		},
+		first: func(count int) Iterator[TSource] {
+			if len(compare) > 0 {
+				return bottomKBy(source, count, valueSelector, descending(compare[0]))
+			}
+			return bottomKBy(source, count, valueSelector, descending(cmp.Compare[TValue]))
+		},
+	}, func(yield func(value TSource) bool) {
 		result := make([]generic.ValuePair[TSource, TValue], 0)
 		for item := range source {
 			result = append(result, generic.ValuePair[TSource, TValue]{
@@ -1509,11 +1671,11 @@ func OrderByDescending[TSource any, TValue generic.Comparable](source Iterator[T
 		}
 		if len(compare) > 0 {
 			Compare := compare[0]
-			slices.SortFunc(result, func(x, y generic.ValuePair[TSource, TValue]) int {
+			slices.SortStableFunc(result, func(x, y generic.ValuePair[TSource, TValue]) int {
 				return Compare(y.Item2, x.Item2)
 			})
 		} else {
-			slices.SortFunc(result, func(x, y generic.ValuePair[TSource, TValue]) int {
+			slices.SortStableFunc(result, func(x, y generic.ValuePair[TSource, TValue]) int {
 				return cmp.Compare(y.Item2, x.Item2)
 			})
 		}
@@ -1522,7 +1684,7 @@ func OrderByDescending[TSource any, TValue generic.Comparable](source Iterator[T
 				return
 			}
 		}
-	}
+	})
 }
 
 // Prepends values to the beggining of the sequence.
@@ -1539,7 +1701,11 @@ func OrderByDescending[TSource any, TValue generic.Comparable](source Iterator[T
 //
 // A new sequence that begins with elements.
 func (source Iterator[TSource]) Prepend(elements ...TSource) (result Iterator[TSource]) {
-	return func(yield func(TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Prepend", false, "O(n)", []string{parameter("count", len(elements))}, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
 		for _, element := range elements {
 			if !yield(element) {
 				return
@@ -1550,7 +1716,7 @@ func (source Iterator[TSource]) Prepend(elements ...TSource) (result Iterator[TS
 				return
 			}
 		}
-	}
+	})
 }
 
 // Inverts the order of the elements in a sequence.
@@ -1560,32 +1726,69 @@ func (source Iterator[TSource]) Prepend(elements ...TSource) (result Iterator[TS
 //	result Iterator[TSource]
 //
 // A sequence whose elements correspond to those of the input sequence in reverse order.
+//
+// # Remarks
+//
+// If source is backed by a slice or Range, possibly through Select, Skip, Take and Reverse, nothing is buffered.
+// Otherwise, Take(count) and First on the result hold only the last count elements of source, as TakeLast does,
+// instead of all of them.
 func (source Iterator[TSource]) Reverse() (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Reverse", !randomAccess(source), "O(n)", nil, askPlan(source))
+		},
+		first: func(count int) Iterator[TSource] {
+			return source.TakeLast(count).Reverse()
+		},
+		index: func() (result indexed[TSource], ok bool) {
+			if source, ok := indexOf(source); ok {
+				return source.reverse(), true
+			}
+			return result, false
+		},
+	}, func(yield func(value TSource) bool) {
+		if source, ok := indexOf(source); ok && source.at != nil {
+			source.reverse().yieldRange(0, source.length, yield)
+			return
+		}
 		reverse := make([]TSource, 0)
 		for item := range source {
 			reverse = append(reverse, item)
 		}
-		for i := len(reverse); i >= 0; i-- {
+		for i := len(reverse) - 1; i >= 0; i-- {
 			if !yield(reverse[i]) {
 				return
 			}
 		}
-	}
+	})
 }
 
 func Select[TSource any, TResult any](source Iterator[TSource], valueSelector generic.ValueSelector[TSource, TResult]) Iterator[TResult] {
-	return func(yield func(value TResult) bool) {
+	return describe(descriptor[TResult]{
+		plan: func() *PlanNode {
+			return node("Select", false, "O(n)", nil, askPlan(source))
+		},
+		index: func() (result indexed[TResult], ok bool) {
+			if source, ok := indexOf(source); ok {
+				return selectIndexed(source, valueSelector), true
+			}
+			return result, false
+		},
+	}, func(yield func(value TResult) bool) {
 		for item := range source {
 			if !yield(valueSelector(item)) {
 				return
 			}
 		}
-	}
+	})
 }
 
 func SelectMany[TSource any, TResult any](source Iterator[TSource], valueSelector generic.ValueSelector[TSource, []TResult]) Iterator[TResult] {
-	return func(yield func(value TResult) bool) {
+	return describe(descriptor[TResult]{
+		plan: func() *PlanNode {
+			return node("SelectMany", false, "O(n)", nil, askPlan(source))
+		},
+	}, func(yield func(value TResult) bool) {
 		for item := range source {
 			innerSource := valueSelector(item)
 			for _, innerItem := range innerSource {
@@ -1594,7 +1797,7 @@ func SelectMany[TSource any, TResult any](source Iterator[TSource], valueSelecto
 				}
 			}
 		}
-	}
+	})
 }
 
 // Determines whether two sequences are equal by comparing their elements by using a specified Equality[TSource].
@@ -1808,17 +2011,28 @@ func (source Iterator[TSource]) SingleOrFallback(fallback TSource, predicate ...
 //
 // If count is greater then collection length, this method returns an empty iterable collection.
 func (source Iterator[TSource]) Skip(count int) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Skip", false, "O(n)", []string{parameter("count", count)}, askPlan(source))
+		},
+		index: func() (result indexed[TSource], ok bool) {
+			if source, ok := indexOf(source); ok {
+				return source.skip(count), true
+			}
+			return result, false
+		},
+	}, func(yield func(value TSource) bool) {
+		skipped := 0
 		for item := range source {
-			if count > 0 {
-				count--
+			if skipped < count {
+				skipped++
 				continue
 			}
 			if !yield(item) {
 				return
 			}
 		}
-	}
+	})
 }
 
 // Returns a new iterable collection that contains the elements from source with the last <count> elements of the source collection omitted.
@@ -1838,19 +2052,40 @@ func (source Iterator[TSource]) Skip(count int) (result Iterator[TSource]) {
 // # Remarks
 //
 // If count is greater then collection length, this method returns an empty iterable collection.
+// The source is enumerated once and at most count elements are buffered.
+// If source is backed by a slice or Range, possibly through Select, Skip, Take and Reverse, nothing is buffered.
 func (source Iterator[TSource]) SkipLast(count int) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
-		count = source.Count() - count
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("SkipLast", !randomAccess(source), "O(n)", []string{parameter("count", count)}, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
+		if count <= 0 {
+			for item := range source {
+				if !yield(item) {
+					return
+				}
+			}
+			return
+		}
+		if source, ok := indexOf(source); ok && source.at != nil {
+			source.yieldRange(0, max(source.length-count, 0), yield)
+			return
+		}
+		buffer := make([]TSource, 0, count)
+		i := 0
 		for item := range source {
-			if count <= 0 {
-				return
+			if len(buffer) < count {
+				buffer = append(buffer, item)
+				continue
 			}
-			if !yield(item) {
+			if !yield(buffer[i]) {
 				return
 			}
-			count--
+			buffer[i] = item
+			i = (i + 1) % count
 		}
-	}
+	})
 }
 
 // Bypasses elements in a sequence as long as a specified condition is true and then returns the remaining elements.
@@ -1867,7 +2102,11 @@ func (source Iterator[TSource]) SkipLast(count int) (result Iterator[TSource]) {
 //
 // An Iterator[TSource] that contains the elements from the input sequence starting at the first element in the linear series that does not pass the test specified by predicate.
 func (source Iterator[TSource]) SkipWhile(predicate generic.Predicate[TSource]) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("SkipWhile", false, "O(n)", nil, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
 		skip := true
 		for item := range source {
 			if skip && !predicate(item) {
@@ -1878,7 +2117,7 @@ func (source Iterator[TSource]) SkipWhile(predicate generic.Predicate[TSource])
 				return
 			}
 		}
-	}
+	})
 }
 
 func Sum[TValue generic.Number | generic.String](source Iterator[TValue]) (result TValue) {
@@ -1905,18 +2144,42 @@ func Sum[TValue generic.Number | generic.String](source Iterator[TValue]) (resul
 // # Remarks
 //
 // If count is not a positive number, this method returns an empty iterable collection.
+// No element beyond the first count elements is pulled from the source.
+//
+// If source is returned by Order, OrderDescending, OrderBy or OrderByDescending, the whole source is still enumerated, but
+// only count elements are held, in a bounded heap, in O(n log count) time instead of the O(n log n) of the sort.
+// The elements and their order are those of the sort. If source is returned by Reverse, only the last count elements
+// of its source are held, as by TakeLast. First, FirstOrDefault and FirstOrFallback without a predicate do the same with a count of 1.
 func (source Iterator[TSource]) Take(count int) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Take", false, "O(n)", []string{parameter("count", count)}, askPlan(source))
+		},
+		index: func() (result indexed[TSource], ok bool) {
+			if source, ok := indexOf(source); ok {
+				return source.take(count), true
+			}
+			return result, false
+		},
+	}, func(yield func(value TSource) bool) {
+		if count <= 0 {
+			return
+		}
+		if description := descriptorOf(source, hasFirst); description != nil {
+			description.first(count)(yield)
+			return
+		}
+		remaining := count
 		for item := range source {
-			if count <= 0 {
+			if !yield(item) {
 				return
 			}
-			if !yield(item) {
+			remaining--
+			if remaining == 0 {
 				return
 			}
-			count--
 		}
-	}
+	})
 }
 
 // Returns a new iterable collection that contains the last count elements from source.
@@ -1936,19 +2199,37 @@ func (source Iterator[TSource]) Take(count int) (result Iterator[TSource]) {
 // # Remarks
 //
 // If count is not a positive number, this method returns an empty iterable collection.
+// The source is enumerated once and at most count elements are buffered.
+// If source is backed by a slice or Range, possibly through Select, Skip, Take and Reverse, nothing is buffered.
 func (source Iterator[TSource]) TakeLast(count int) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
-		count = source.Count() - count
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("TakeLast", !randomAccess(source), "O(n)", []string{parameter("count", count)}, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
+		if count <= 0 {
+			return
+		}
+		if source, ok := indexOf(source); ok && source.at != nil {
+			source.yieldRange(max(source.length-count, 0), source.length, yield)
+			return
+		}
+		buffer := make([]TSource, 0, count)
+		i := 0
 		for item := range source {
-			if count > 0 {
-				count--
+			if len(buffer) < count {
+				buffer = append(buffer, item)
 				continue
 			}
-			if !yield(item) {
+			buffer[i] = item
+			i = (i + 1) % count
+		}
+		for j := range buffer {
+			if !yield(buffer[(i+j)%len(buffer)]) {
 				return
 			}
 		}
-	}
+	})
 }
 
 // Returns elements from a sequence as long as a specified condition is true, and then skips the remaining elements.
@@ -1965,7 +2246,11 @@ func (source Iterator[TSource]) TakeLast(count int) (result Iterator[TSource]) {
 //
 // An Iterator[Tsource] that contains the elements from the input sequence that occur before the element at which the test no longer passes.
 func (source Iterator[TSource]) TakeWhile(predicate generic.Predicate[TSource]) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("TakeWhile", false, "O(n)", nil, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
 		for item := range source {
 			if predicate(item) {
 				return
@@ -1974,7 +2259,7 @@ func (source Iterator[TSource]) TakeWhile(predicate generic.Predicate[TSource])
 				return
 			}
 		}
-	}
+	})
 }
 
 // Creates a slice of TSource from an Iterator[TSource]
@@ -1984,8 +2269,16 @@ func (source Iterator[TSource]) TakeWhile(predicate generic.Predicate[TSource])
 //	result []TSource
 //
 // A slice of TSource that contains elements from the input sequence.
+//
+// # Remarks
+//
+// If source is backed by a slice, a map or Range, possibly through Select, Skip, Take and Reverse, the slice is allocated with the exact capacity.
 func (source Iterator[TSource]) ToSlice() (result []TSource) {
-	result = make([]TSource, 0)
+	if source, ok := indexOf(source); ok {
+		result = make([]TSource, 0, source.length)
+	} else {
+		result = make([]TSource, 0)
+	}
 	for item := range source {
 		result = append(result, item)
 	}
@@ -2038,21 +2331,45 @@ func ToMap[TSource any, TKey comparable, TValue any](source Iterator[TSource], k
 //	result Iterator[TSource]
 //
 // An Iterator[TSource] that contains the elements from both input sequences, excluding duplicates.
+//
+// # Remarks
+//
+// Each input sequence is enumerated once. The distinct elements yielded so far are buffered.
+// Without comparer, the elements of a type that can key a map are kept in a map, which takes O(n) time;
+// otherwise every element is compared with the distinct elements before it, which takes O(n²) time.
 func (source Iterator[TSource]) Union(sequence Iterator[TSource], comparer ...generic.Equality[TSource]) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
-		for item := range source.Distinct(comparer...) {
-			if !yield(item) {
-				return
-			}
-		}
-		for item := range sequence.Distinct(comparer...) {
-			if !source.Contains(item, comparer...) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Union", true, "O((n+m)²)", nil, askPlan(source), askPlan(sequence))
+		},
+	}, func(yield func(value TSource) bool) {
+		if keyable[TSource](comparer...) {
+			seen := make(map[any]struct{})
+			for item := range source.Concat(sequence) {
+				if _, ok := seen[item]; ok {
+					continue
+				}
+				seen[item] = struct{}{}
 				if !yield(item) {
 					return
 				}
 			}
+			return
 		}
-	}
+		Equal := equality(comparer...)
+		result := make([]TSource, 0)
+		for item := range source.Concat(sequence) {
+			if slices.ContainsFunc(result, func(value TSource) bool {
+				return Equal(value, item)
+			}) {
+				continue
+			}
+			result = append(result, item)
+			if !yield(item) {
+				return
+			}
+		}
+	})
 }
 
 // Filters a sequence of values based on a predicate.
@@ -2068,8 +2385,21 @@ func (source Iterator[TSource]) Union(sequence Iterator[TSource], comparer ...ge
 //	result Iterator[TSource]
 //
 // An Iterator[TSource] that contains elements from the input sequence that satisfy the condition in predicate.
+//
+// # Remarks
+//
+// If source is returned by Order, OrderDescending, OrderBy or OrderByDescending, the filter is moved below the sort,
+// so that only the elements that satisfy the condition are buffered and sorted. The result is the same, but predicate
+// is then called on the elements in source order.
 func (source Iterator[TSource]) Where(predicate generic.Predicate[TSource]) (result Iterator[TSource]) {
-	return func(yield func(value TSource) bool) {
+	if description := descriptorOf(source, hasFilter); description != nil {
+		return description.filter(predicate)
+	}
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Where", false, "O(n)", nil, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
 		for item := range source {
 			if predicate(item) {
 				if !yield(item) {
@@ -2077,7 +2407,7 @@ func (source Iterator[TSource]) Where(predicate generic.Predicate[TSource]) (res
 				}
 			}
 		}
-	}
+	})
 }
 
 // Produces a sequence of tuples with elements from the two specified sequences.
@@ -2098,7 +2428,11 @@ func (source Iterator[TSource]) Where(predicate generic.Predicate[TSource]) (res
 //
 // A sequence of pairs with elements taken from the first and second sequences, in that order.
 func Zip[TFirst any, TSecond any](source Iterator[TFirst], sequence Iterator[TSecond]) (result Iterator[generic.ValuePair[TFirst, TSecond]]) {
-	return func(yield func(value generic.ValuePair[TFirst, TSecond]) bool) {
+	return describe(descriptor[generic.ValuePair[TFirst, TSecond]]{
+		plan: func() *PlanNode {
+			return node("Zip", false, "O(n)", nil, askPlan(source), askPlan(sequence))
+		},
+	}, func(yield func(value generic.ValuePair[TFirst, TSecond]) bool) {
 		next, stop := iter.Pull(iter.Seq[TSecond](sequence))
 		defer stop()
 		for item1 := range source {
@@ -2113,5 +2447,5 @@ func Zip[TFirst any, TSecond any](source Iterator[TFirst], sequence Iterator[TSe
 				return
 			}
 		}
-	}
+	})
 }
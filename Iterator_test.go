@@ -2807,3 +2807,50 @@ func TestIterator_SequenceEqual_IEquatable(t *testing.T) {
 		})
 	}
 }
+
+func TestIterator_Union(t *testing.T) {
+	instant := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		got  func() any
+		want any
+	}{
+		{
+			name: "Union comparable elements",
+			got: func() any {
+				return FromSlice([]int{1, 2, 2, 3}).Union(FromSlice([]int{3, 4, 1, 5})).ToSlice()
+			},
+			want: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name: "Union with comparer",
+			got: func() any {
+				return FromSlice([]int{1, 2, 3}).Union(FromSlice([]int{4, 5, 6}), func(x, y int) bool {
+					return x%3 == y%3
+				}).ToSlice()
+			},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "Union IEquatable elements",
+			got: func() any {
+				return FromSlice([]time.Time{instant}).Union(FromSlice([]time.Time{instant.In(time.FixedZone("UTC+1", 60*60))})).ToSlice()
+			},
+			want: []time.Time{instant},
+		},
+		{
+			name: "Union elements that are not comparable",
+			got: func() any {
+				return FromSlice([]any{[]int{1}, 2}).Union(FromSlice([]any{2, []int{1}, []int{3}})).ToSlice()
+			},
+			want: []any{[]int{1}, 2, []int{3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Iterator.Union() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package linq
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+)
+
+// Pulls the elements of a source on demand and releases the source once it is exhausted,
+// or once the puller is no longer referenced.
+type puller[TSource any] struct {
+	source Iterator[TSource]
+	next   func() (TSource, bool)
+	stop   func()
+	done   bool
+}
+
+func newPuller[TSource any](source Iterator[TSource]) *puller[TSource] {
+	return &puller[TSource]{
+		source: source,
+	}
+}
+
+func (p *puller[TSource]) pull() (value TSource, ok bool) {
+	if p.done {
+		return value, false
+	}
+	if p.next == nil {
+		p.next, p.stop = iter.Pull(iter.Seq[TSource](p.source))
+		runtime.AddCleanup(p, func(stop func()) {
+			stop()
+		}, p.stop)
+	}
+	value, ok = p.next()
+	if !ok {
+		p.close()
+	}
+	return value, ok
+}
+
+func (p *puller[TSource]) close() {
+	p.done = true
+	if p.stop != nil {
+		p.stop()
+	}
+}
+
+type memoized[TSource any] struct {
+	mutex  sync.Mutex
+	source *puller[TSource]
+	items  []TSource
+}
+
+func (m *memoized[TSource]) at(i int) (value TSource, ok bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if i < len(m.items) {
+		return m.items[i], true
+	}
+	value, ok = m.source.pull()
+	if ok {
+		m.items = append(m.items, value)
+	}
+	return value, ok
+}
+
+// Caches the elements of a sequence as they are enumerated for the first time and replays them to later enumerations.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains the elements of the source sequence.
+//
+// # Remarks
+//
+// The source is enumerated at most once, and only as far as the furthest enumeration of the result has reached.
+// All the elements pulled from the source stay in memory as long as the result is referenced.
+// The result may be enumerated from several goroutines at the same time.
+// This makes single-pass sources, such as channels or readers, safe to use with operators that enumerate their input more than once.
+func (source Iterator[TSource]) Memoize() (result Iterator[TSource]) {
+	cache := &memoized[TSource]{
+		source: newPuller(source),
+	}
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Memoize", true, "O(n)", nil, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
+		for i := 0; ; i++ {
+			item, ok := cache.at(i)
+			if !ok {
+				return
+			}
+			if !yield(item) {
+				return
+			}
+		}
+	})
+}
+
+type shared[TSource any] struct {
+	mutex  sync.Mutex
+	source *puller[TSource]
+}
+
+func (s *shared[TSource]) pull() (value TSource, ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.source.pull()
+}
+
+// Shares a single enumeration of a sequence between all enumerations of the result.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] whose enumerations all read from one enumeration of the source sequence.
+//
+// # Remarks
+//
+// Every element of the source is yielded to exactly one enumeration of the result. An enumeration that stops early
+// leaves the remaining elements to the next one, so consecutive enumerations continue where the previous one stopped.
+// Enumerations running on several goroutines at the same time split the elements between them, which makes the result a work queue.
+// No elements are buffered.
+func (source Iterator[TSource]) Share() (result Iterator[TSource]) {
+	cursor := &shared[TSource]{
+		source: newPuller(source),
+	}
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Share", false, "O(n)", nil, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
+		for {
+			item, ok := cursor.pull()
+			if !ok {
+				return
+			}
+			if !yield(item) {
+				return
+			}
+		}
+	})
+}
+
+// Splits a sequence into a number of branches that share a single enumeration of the source.
+//
+// # Parameters
+//
+//	n int
+//
+// The number of branches.
+//
+//	capacity ...int
+//
+// Optional. The most elements the buffer holds. When it is full, the fastest branch waits for the slowest one.
+// If omitted, the buffer has no capacity limit.
+//
+// # Returns
+//
+//	result []Iterator[TSource]
+//
+// The branches. Each of them contains all the elements of the source sequence.
+//
+// # Remarks
+//
+// The source is enumerated at most once, while the branches are enumerated.
+// An element is buffered until every branch that has not stopped early has received it, so the buffer holds the elements
+// between the slowest and the fastest active branch.
+//
+// Without a capacity, the branches may be enumerated one after another or from different goroutines. Enumerated one
+// after another, the first branch leaves every element in the buffer for the rest.
+//
+// With a capacity, the branches must be enumerated from different goroutines. A branch that would pull a new element
+// while the buffer is full waits until the slowest active branch has received an element or stopped, so enumerated one
+// after another, the first branch waits forever once it is capacity elements ahead of the rest.
+//
+// Each branch can be enumerated only once. A branch that stops early, because its consumer stops the enumeration, is detached:
+// it yields nothing when enumerated again and no longer holds elements in the buffer. The source is released when every branch
+// has either finished or stopped. A branch that is never enumerated holds every element in the buffer until the source is no longer
+// referenced or, with a capacity, keeps the other branches waiting once the buffer is full.
+//
+// Panics with ErrSizeIsBelowOne when capacity is below 1.
+func (source Iterator[TSource]) Publish(n int, capacity ...int) (result []Iterator[TSource]) {
+	limit := 0
+	if len(capacity) > 0 {
+		if capacity[0] < 1 {
+			panic(ErrSizeIsBelowOne)
+		}
+		limit = capacity[0]
+	}
+	return newBroadcast(source, n, limit).branches()
+}
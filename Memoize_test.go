@@ -0,0 +1,168 @@
+package linq
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+// Returns a sequence that yields its elements only on the first enumeration, like a channel or a reader does.
+func oneShot[TSource any](values ...TSource) Iterator[TSource] {
+	used := false
+	return func(yield func(value TSource) bool) {
+		if used {
+			return
+		}
+		used = true
+		for _, value := range values {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+func TestIterator_Memoize(t *testing.T) {
+	pulled := 0
+	source := Select(oneShot(1, 2, 3, 4), func(item int) int {
+		pulled++
+		return item
+	}).Memoize()
+	if got, want := source.Take(2).ToSlice(), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator.Memoize() = %v, want %v", got, want)
+	}
+	if pulled != 2 {
+		t.Errorf("Iterator.Memoize() pulled %d elements, want 2", pulled)
+	}
+	for range 2 {
+		if got, want := source.ToSlice(), []int{1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Iterator.Memoize() = %v, want %v", got, want)
+		}
+	}
+	if pulled != 4 {
+		t.Errorf("Iterator.Memoize() pulled %d elements, want 4", pulled)
+	}
+}
+
+func TestIterator_Share(t *testing.T) {
+	t.Run("Share consecutive enumerations", func(t *testing.T) {
+		source := FromSlice([]int{1, 2, 3, 4, 5}).Share()
+		if got, want := source.Take(2).ToSlice(), []int{1, 2}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Iterator.Share() = %v, want %v", got, want)
+		}
+		if got, want := source.ToSlice(), []int{3, 4, 5}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Iterator.Share() = %v, want %v", got, want)
+		}
+		if got, want := source.ToSlice(), []int{}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Iterator.Share() = %v, want %v", got, want)
+		}
+	})
+	t.Run("Share concurrent enumerations", func(t *testing.T) {
+		source := Range(0, 1000).Share()
+		var mutex sync.Mutex
+		got := make([]int, 0)
+		var wait sync.WaitGroup
+		for range 4 {
+			wait.Add(1)
+			go func() {
+				defer wait.Done()
+				for item := range source {
+					mutex.Lock()
+					got = append(got, item)
+					mutex.Unlock()
+				}
+			}()
+		}
+		wait.Wait()
+		if got, want := Order(FromSlice(got)).ToSlice(), Range(0, 1000).ToSlice(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Iterator.Share() yielded %d elements, want each of %d elements once", len(got), len(want))
+		}
+	})
+}
+
+func TestIterator_Publish(t *testing.T) {
+	enumerations := 0
+	source := Iterator[int](func(yield func(int) bool) {
+		enumerations++
+		FromSlice([]int{1, 2, 3})(yield)
+	})
+	branches := source.Publish(3)
+	if got, want := branches[0].Take(1).ToSlice(), []int{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator.Publish() branch 0 = %v, want %v", got, want)
+	}
+	for i := 1; i < 3; i++ {
+		if got, want := branches[i].ToSlice(), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Iterator.Publish() branch %d = %v, want %v", i, got, want)
+		}
+	}
+	if enumerations != 1 {
+		t.Errorf("Iterator.Publish() enumerated source %d times, want 1", enumerations)
+	}
+}
+
+func TestOneShotInputs(t *testing.T) {
+	tests := []struct {
+		name string
+		got  func() []int
+		want []int
+	}{
+		{
+			name: "Union",
+			got: func() []int {
+				return oneShot(1, 2, 2, 3).Union(oneShot(3, 4, 1, 5)).ToSlice()
+			},
+			want: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name: "Intersect",
+			got: func() []int {
+				return oneShot(1, 2, 3, 4).Intersect(oneShot(4, 2, 6)).ToSlice()
+			},
+			want: []int{2, 4},
+		},
+		{
+			name: "Join",
+			got: func() []int {
+				identity := func(item int) int {
+					return item
+				}
+				return Join(oneShot(1, 2, 3), oneShot(3, 2, 2), identity, identity, func(outer, inner int) int {
+					return outer * 10
+				}).ToSlice()
+			},
+			want: []int{20, 20, 30},
+		},
+		{
+			name: "SkipLast",
+			got: func() []int {
+				return oneShot(1, 2, 3, 4, 5).SkipLast(2).ToSlice()
+			},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "TakeLast",
+			got: func() []int {
+				return oneShot(1, 2, 3, 4, 5).TakeLast(2).ToSlice()
+			},
+			want: []int{4, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Iterator.%s() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+	if !oneShot(1, 2, 3).ContainsAll([]int{3, 1}) {
+		t.Errorf("Iterator.ContainsAll() = false, want true")
+	}
+	if !oneShot(1, 2, 3).ContainsAny([]int{5, 3}) {
+		t.Errorf("Iterator.ContainsAny() = false, want true")
+	}
+	if oneShot(1, 2, 3).ContainsAll([]int{3, 4}, generic.Equality[int](func(x, y int) bool { return x == y })) {
+		t.Errorf("Iterator.ContainsAll() = true, want false")
+	}
+}
@@ -0,0 +1,218 @@
+package linq
+
+import (
+	"container/heap"
+	"iter"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+type mergeHead[TSource any] struct {
+	value  TSource
+	source int
+}
+
+type mergeHeap[TSource any] struct {
+	heads   []mergeHead[TSource]
+	compare generic.Comparison[TSource]
+}
+
+func (h *mergeHeap[TSource]) Len() int {
+	return len(h.heads)
+}
+
+func (h *mergeHeap[TSource]) Less(i, j int) bool {
+	if c := h.compare(h.heads[i].value, h.heads[j].value); c != 0 {
+		return c < 0
+	}
+	return h.heads[i].source < h.heads[j].source
+}
+
+func (h *mergeHeap[TSource]) Swap(i, j int) {
+	h.heads[i], h.heads[j] = h.heads[j], h.heads[i]
+}
+
+func (h *mergeHeap[TSource]) Push(x any) {
+	h.heads = append(h.heads, x.(mergeHead[TSource]))
+}
+
+func (h *mergeHeap[TSource]) Pop() any {
+	x := h.heads[len(h.heads)-1]
+	h.heads = h.heads[:len(h.heads)-1]
+	return x
+}
+
+func pullAll[TSource any](sources []Iterator[TSource]) (next []func() (TSource, bool), stop func()) {
+	next = make([]func() (TSource, bool), len(sources))
+	stops := make([]func(), len(sources))
+	for i, source := range sources {
+		next[i], stops[i] = iter.Pull(iter.Seq[TSource](source))
+	}
+	return next, func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+}
+
+// Merges sequences that are already sorted into one sorted sequence.
+//
+// # Parameters
+//
+//	comparison generic.Comparison[TSource]
+//
+// A function to compare elements. Every source must be sorted according to it.
+//
+//	sources ...Iterator[TSource]
+//
+// The sorted sequences to merge.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains the elements of all sources in sorted order.
+//
+// # Remarks
+//
+// The merge is lazy: it holds one element per source and pulls the next element from a source only after the previous one has been yielded.
+// The merge is stable. Equal elements keep their order within a source, and elements of an earlier source precede equal elements of a later source.
+func MergeSorted[TSource any](comparison generic.Comparison[TSource], sources ...Iterator[TSource]) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("MergeSorted", false, "O(n log k)", []string{parameter("k", len(sources))}, askPlans(sources)...)
+		},
+	}, func(yield func(value TSource) bool) {
+		next, stop := pullAll(sources)
+		defer stop()
+		heads := &mergeHeap[TSource]{
+			heads:   make([]mergeHead[TSource], 0, len(sources)),
+			compare: comparison,
+		}
+		for i := range next {
+			if value, ok := next[i](); ok {
+				heads.heads = append(heads.heads, mergeHead[TSource]{
+					value:  value,
+					source: i,
+				})
+			}
+		}
+		heap.Init(heads)
+		for heads.Len() > 0 {
+			head := heads.heads[0]
+			if !yield(head.value) {
+				return
+			}
+			if value, ok := next[head.source](); ok {
+				heads.heads[0].value = value
+				heap.Fix(heads, 0)
+			} else {
+				heap.Pop(heads)
+			}
+		}
+	})
+}
+
+// Takes elements from the sequences in turn, until all of them are exhausted.
+//
+// # Parameters
+//
+//	sources ...Iterator[TSource]
+//
+// The sequences to interleave.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains the first element of every source, then the second element of every source, and so on.
+//
+// # Remarks
+//
+// Exhausted sources are skipped, so the remaining elements of longer sources are yielded after the shorter sources end.
+//
+// # Example
+//
+//	result := Interleave(FromSlice([]int{1, 2, 3}), FromSlice([]int{10}), FromSlice([]int{100, 200})).ToSlice()
+//	/*This code produces the following output result = []int{1, 10, 100, 2, 200, 3}*/
+func Interleave[TSource any](sources ...Iterator[TSource]) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Interleave", false, "O(n)", nil, askPlans(sources)...)
+		},
+	}, func(yield func(value TSource) bool) {
+		next, stop := pullAll(sources)
+		defer stop()
+		for len(next) > 0 {
+			active := next[:0]
+			for _, pull := range next {
+				value, ok := pull()
+				if !ok {
+					continue
+				}
+				if !yield(value) {
+					return
+				}
+				active = append(active, pull)
+			}
+			next = active
+		}
+	})
+}
+
+// Takes elements from the sequences in turn, until the first of them is exhausted.
+//
+// # Parameters
+//
+//	sources ...Iterator[TSource]
+//
+// The sequences to alternate between. The first of them sets the length of the result.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains the first element of every source, then the second element of every source, and so on,
+// for as many rounds as the first source has elements.
+//
+// # Remarks
+//
+// Other sources that are exhausted earlier are skipped, as in Interleave. The remaining elements of the other sources are dropped
+// when the first source ends, and every source is stopped.
+//
+// # Example
+//
+//	result := Alternate(FromSlice([]int{1, 2, 3}), FromSlice([]int{10}), FromSlice([]int{100, 200, 300, 400})).ToSlice()
+//	/*This code produces the following output result = []int{1, 10, 100, 2, 200, 3, 300}*/
+func Alternate[TSource any](sources ...Iterator[TSource]) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Alternate", false, "O(n)", nil, askPlans(sources)...)
+		},
+	}, func(yield func(value TSource) bool) {
+		if len(sources) == 0 {
+			return
+		}
+		next, stop := pullAll(sources)
+		defer stop()
+		first, rest := next[0], next[1:]
+		for {
+			value, ok := first()
+			if !ok || !yield(value) {
+				return
+			}
+			active := rest[:0]
+			for _, pull := range rest {
+				value, ok := pull()
+				if !ok {
+					continue
+				}
+				if !yield(value) {
+					return
+				}
+				active = append(active, pull)
+			}
+			rest = active
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package linq
+
+import (
+	"cmp"
+	"reflect"
+	"testing"
+)
+
+func TestMergeSorted(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []Iterator[int]
+		take    int
+		want    []int
+	}{
+		{
+			name:    "MergeSorted no sources",
+			sources: []Iterator[int]{},
+			take:    100,
+			want:    []int{},
+		},
+		{
+			name: "MergeSorted empty sources",
+			sources: []Iterator[int]{
+				FromSlice([]int{}),
+				FromSlice([]int{}),
+			},
+			take: 100,
+			want: []int{},
+		},
+		{
+			name: "MergeSorted sources",
+			sources: []Iterator[int]{
+				FromSlice([]int{1, 4, 7}),
+				FromSlice([]int{}),
+				FromSlice([]int{2, 5, 8, 9}),
+				FromSlice([]int{3, 6}),
+			},
+			take: 100,
+			want: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name: "MergeSorted stopped early",
+			sources: []Iterator[int]{
+				FromSlice([]int{1, 4, 7}),
+				FromSlice([]int{2, 5, 8}),
+			},
+			take: 4,
+			want: []int{1, 2, 4, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeSorted(cmp.Compare[int], tt.sources...).Take(tt.take).ToSlice(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeSorted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeSorted_Stable(t *testing.T) {
+	type pair struct {
+		Key   int
+		Value string
+	}
+	compare := func(x, y pair) int {
+		return cmp.Compare(x.Key, y.Key)
+	}
+	got := MergeSorted(compare,
+		FromSlice([]pair{{1, "a1"}, {2, "a2"}, {2, "a3"}}),
+		FromSlice([]pair{{1, "b1"}, {2, "b2"}}),
+		FromSlice([]pair{{2, "c1"}}),
+	).ToSlice()
+	want := []pair{{1, "a1"}, {1, "b1"}, {2, "a2"}, {2, "a3"}, {2, "b2"}, {2, "c1"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeSorted() = %v, want %v", got, want)
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []Iterator[int]
+		want    []int
+	}{
+		{
+			name:    "Interleave no sources",
+			sources: []Iterator[int]{},
+			want:    []int{},
+		},
+		{
+			name: "Interleave sources of different length",
+			sources: []Iterator[int]{
+				FromSlice([]int{1, 2, 3}),
+				FromSlice([]int{10}),
+				FromSlice([]int{}),
+				FromSlice([]int{100, 200}),
+			},
+			want: []int{1, 10, 100, 2, 200, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Interleave(tt.sources...).ToSlice(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Interleave() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlternate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []Iterator[int]
+		want    []int
+	}{
+		{
+			name:    "Alternate no sources",
+			sources: []Iterator[int]{},
+			want:    []int{},
+		},
+		{
+			name: "Alternate empty first source",
+			sources: []Iterator[int]{
+				FromSlice([]int{}),
+				FromSlice([]int{10, 20}),
+			},
+			want: []int{},
+		},
+		{
+			name: "Alternate first source is shorter",
+			sources: []Iterator[int]{
+				FromSlice([]int{1, 2}),
+				FromSlice([]int{10, 20, 30}),
+			},
+			want: []int{1, 10, 2, 20},
+		},
+		{
+			name: "Alternate second source is shorter",
+			sources: []Iterator[int]{
+				FromSlice([]int{1, 2, 3}),
+				FromSlice([]int{10, 20}),
+			},
+			want: []int{1, 10, 2, 20, 3},
+		},
+		{
+			name: "Alternate middle source is shorter",
+			sources: []Iterator[int]{
+				FromSlice([]int{1, 2, 3, 4}),
+				FromSlice([]int{10}),
+				FromSlice([]int{100, 200, 300}),
+			},
+			want: []int{1, 10, 100, 2, 200, 3, 300, 4},
+		},
+		{
+			name: "Alternate empty source",
+			sources: []Iterator[int]{
+				FromSlice([]int{1, 2}),
+				FromSlice([]int{}),
+			},
+			want: []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Alternate(tt.sources...).ToSlice(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Alternate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	t.Run("Alternate stops the longer sources", func(t *testing.T) {
+		pulled, stopped := 0, 0
+		longer := func(yield func(value int) bool) {
+			defer func() {
+				stopped++
+			}()
+			for i := 100; ; i++ {
+				pulled++
+				if !yield(i) {
+					return
+				}
+			}
+		}
+		if got, want := Alternate(FromSlice([]int{1, 2}), longer).ToSlice(), []int{1, 100, 2, 101}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Alternate() = %v, want %v", got, want)
+		}
+		if pulled != 2 || stopped != 1 {
+			t.Errorf("Alternate() pulled %d elements of the longer source and stopped it %d times, want 2 and 1", pulled, stopped)
+		}
+	})
+}
@@ -0,0 +1,292 @@
+package linq
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Invokes an action on each element of a sequence as it is yielded.
+//
+// # Parameters
+//
+//	action func(TSource)
+//
+// The action to invoke on each element.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains the elements of the source sequence, unchanged.
+//
+// # Remarks
+//
+// The action is invoked before the element is passed on, only for the elements that are actually pulled from the source.
+func (source Iterator[TSource]) Tap(action func(TSource)) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Tap", false, "O(n)", nil, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
+		for item := range source {
+			action(item)
+			if !yield(item) {
+				return
+			}
+		}
+	})
+}
+
+// Logs the enumeration of a sequence.
+//
+// # Parameters
+//
+//	name string
+//
+// The name of the stage, logged with every record as the "stage" attribute.
+//
+//	logger *slog.Logger
+//
+// The logger to write to. If nil, slog.Default() is used.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains the elements of the source sequence, unchanged.
+//
+// # Remarks
+//
+// When the result is enumerated, the following records are written:
+//
+//	level  message               attributes
+//	INFO   "stage started"       stage
+//	DEBUG  "stage element"       stage, index, value
+//	INFO   "stage completed"     stage, elements, elapsed   - when the source is exhausted
+//	INFO   "stage stopped early" stage, elements, elapsed   - when the consumer stops the enumeration
+//
+// Elements are formatted only if the logger is enabled for the DEBUG level.
+func (source Iterator[TSource]) Trace(name string, logger *slog.Logger) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Trace", false, "O(n)", []string{parameter("name", name)}, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
+		log := logger
+		if log == nil {
+			log = slog.Default()
+		}
+		log = log.With(slog.String("stage", name))
+		ctx := context.Background()
+		start := time.Now()
+		elements := 0
+		log.InfoContext(ctx, "stage started")
+		for item := range source {
+			if log.Enabled(ctx, slog.LevelDebug) {
+				log.DebugContext(ctx, "stage element", slog.Int("index", elements), slog.Any("value", item))
+			}
+			elements++
+			if !yield(item) {
+				log.InfoContext(ctx, "stage stopped early", slog.Int("elements", elements), slog.Duration("elapsed", time.Since(start)))
+				return
+			}
+		}
+		log.InfoContext(ctx, "stage completed", slog.Int("elements", elements), slog.Duration("elapsed", time.Since(start)))
+	})
+}
+
+// A snapshot of the counters of a pipeline stage measured by Measure.
+type StageMetrics struct {
+
+	// The name of the stage.
+	Name string
+
+	// The number of times the stage has been enumerated.
+	Enumerations int64
+
+	// The number of enumerations that are still running.
+	Running int64
+
+	// The number of elements the stage has pulled from its input.
+	ElementsIn int64
+
+	// The number of elements the stage has yielded.
+	ElementsOut int64
+
+	// The number of enumerations stopped by the consumer before the stage was exhausted.
+	EarlyStops int64
+
+	// The time spent in the enumerations of the stage, excluding the time spent by the consumer of its elements.
+	// The time spent by the stages before it is included, so the cost of a single stage is the difference between it and the stage that feeds it.
+	// It grows as every element is pulled, so it can be read while the stage runs.
+	Elapsed time.Duration
+}
+
+type stageCounters struct {
+	name         string
+	enumerations atomic.Int64
+	running      atomic.Int64
+	elementsIn   atomic.Int64
+	elementsOut  atomic.Int64
+	earlyStops   atomic.Int64
+	elapsed      atomic.Int64
+}
+
+func (counters *stageCounters) snapshot() StageMetrics {
+	return StageMetrics{
+		Name:         counters.name,
+		Enumerations: counters.enumerations.Load(),
+		Running:      counters.running.Load(),
+		ElementsIn:   counters.elementsIn.Load(),
+		ElementsOut:  counters.elementsOut.Load(),
+		EarlyStops:   counters.earlyStops.Load(),
+		Elapsed:      time.Duration(counters.elapsed.Load()),
+	}
+}
+
+// A collection of per-stage counters of iterator pipelines.
+//
+// The counters are updated while the pipelines run and may be read at any time, from any goroutine.
+// The zero value is ready to use.
+type Metrics struct {
+	mutex  sync.Mutex
+	stages []*stageCounters
+	index  map[string]*stageCounters
+}
+
+func (metrics *Metrics) stage(name string) *stageCounters {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	if counters, ok := metrics.index[name]; ok {
+		return counters
+	}
+	if metrics.index == nil {
+		metrics.index = make(map[string]*stageCounters)
+	}
+	counters := &stageCounters{
+		name: name,
+	}
+	metrics.index[name] = counters
+	metrics.stages = append(metrics.stages, counters)
+	return counters
+}
+
+// Returns the current counters of a stage.
+//
+// # Parameters
+//
+//	name string
+//
+// The name of the stage.
+//
+// # Returns
+//
+//	result StageMetrics
+//
+// The counters of the stage. They are all zero if the stage has not been measured.
+func (metrics *Metrics) Stage(name string) (result StageMetrics) {
+	metrics.mutex.Lock()
+	counters, ok := metrics.index[name]
+	metrics.mutex.Unlock()
+	if !ok {
+		return StageMetrics{
+			Name: name,
+		}
+	}
+	return counters.snapshot()
+}
+
+// Returns the current counters of all the stages.
+//
+// # Returns
+//
+//	result []StageMetrics
+//
+// The counters of every measured stage, in the order the stages were first measured.
+func (metrics *Metrics) Snapshot() (result []StageMetrics) {
+	metrics.mutex.Lock()
+	stages := append([]*stageCounters(nil), metrics.stages...)
+	metrics.mutex.Unlock()
+	result = make([]StageMetrics, len(stages))
+	for i, counters := range stages {
+		result[i] = counters.snapshot()
+	}
+	return result
+}
+
+// Applies a pipeline stage to a sequence and records its counters under a name.
+//
+// # Parameters
+//
+//	metrics *Metrics
+//
+// The collection to record the counters in.
+//
+//	name string
+//
+// The name of the stage. Stages measured under the same name share their counters.
+//
+//	source Iterator[TSource]
+//
+// The input of the stage.
+//
+//	stage func(Iterator[TSource]) Iterator[TResult]
+//
+// A function that builds the stage on top of its input, for example func(source Iterator[int]) Iterator[int] { return source.Where(isEven) }.
+//
+// # Returns
+//
+//	result Iterator[TResult]
+//
+// The output of the stage.
+//
+// # Example
+//
+//	var metrics linq.Metrics
+//	evens := linq.Measure(&metrics, "evens", source, func(source linq.Iterator[int]) linq.Iterator[int] {
+//		return source.Where(isEven)
+//	})
+//	names := linq.Measure(&metrics, "names", evens, func(source linq.Iterator[int]) linq.Iterator[string] {
+//		return linq.Select(source, strconv.Itoa)
+//	})
+func Measure[TSource any, TResult any](metrics *Metrics, name string, source Iterator[TSource], stage func(Iterator[TSource]) Iterator[TResult]) (result Iterator[TResult]) {
+	counters := metrics.stage(name)
+	output := stage(describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return askPlan(source)
+		},
+	}, func(yield func(value TSource) bool) {
+		for item := range source {
+			counters.elementsIn.Add(1)
+			if !yield(item) {
+				return
+			}
+		}
+	}))
+	return describe(descriptor[TResult]{
+		plan: func() *PlanNode {
+			return node("Measure", false, "O(n)", []string{parameter("name", name)}, askPlan(output))
+		},
+	}, func(yield func(value TResult) bool) {
+		counters.enumerations.Add(1)
+		counters.running.Add(1)
+		defer counters.running.Add(-1)
+		pulled := time.Now()
+		defer func() {
+			counters.elapsed.Add(int64(time.Since(pulled)))
+		}()
+		for item := range output {
+			counters.elapsed.Add(int64(time.Since(pulled)))
+			counters.elementsOut.Add(1)
+			ok := yield(item)
+			pulled = time.Now()
+			if !ok {
+				counters.earlyStops.Add(1)
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package linq
+
+import (
+	"bytes"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIterator_Tap(t *testing.T) {
+	tapped := make([]int, 0)
+	got := FromSlice([]int{1, 2, 3, 4}).Tap(func(item int) {
+		tapped = append(tapped, item)
+	}).Take(2).ToSlice()
+	if want := []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator.Tap() = %v, want %v", got, want)
+	}
+	if want := []int{1, 2}; !reflect.DeepEqual(tapped, want) {
+		t.Errorf("Iterator.Tap() tapped %v, want %v", tapped, want)
+	}
+}
+
+func TestIterator_Trace(t *testing.T) {
+	records := func(level slog.Level, source Iterator[int]) []string {
+		var buffer bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buffer, &slog.HandlerOptions{
+			Level: level,
+			ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+				if attr.Key == slog.TimeKey || attr.Key == "elapsed" {
+					return slog.Attr{}
+				}
+				return attr
+			},
+		}))
+		source.Trace("numbers", logger).Take(2).ToSlice()
+		return strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	}
+	tests := []struct {
+		name   string
+		level  slog.Level
+		source Iterator[int]
+		want   []string
+	}{
+		{
+			name:   "Trace completed",
+			level:  slog.LevelInfo,
+			source: FromSlice([]int{1}),
+			want: []string{
+				`level=INFO msg="stage started" stage=numbers`,
+				`level=INFO msg="stage completed" stage=numbers elements=1`,
+			},
+		},
+		{
+			name:   "Trace stopped early, with elements",
+			level:  slog.LevelDebug,
+			source: FromSlice([]int{1, 2, 3}),
+			want: []string{
+				`level=INFO msg="stage started" stage=numbers`,
+				`level=DEBUG msg="stage element" stage=numbers index=0 value=1`,
+				`level=DEBUG msg="stage element" stage=numbers index=1 value=2`,
+				`level=INFO msg="stage stopped early" stage=numbers elements=2`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := records(tt.level, tt.source); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Iterator.Trace() logged %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	var metrics Metrics
+	evens := Measure(&metrics, "evens", FromSlice([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}), func(source Iterator[int]) Iterator[int] {
+		return source.Where(func(item int) bool {
+			return item%2 == 0
+		})
+	})
+	squares := Measure(&metrics, "squares", evens, func(source Iterator[int]) Iterator[int] {
+		return Select(source, func(item int) int {
+			return item * item
+		})
+	})
+	if got, want := squares.ToSlice(), []int{0, 4, 16, 36, 64}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Measure() = %v, want %v", got, want)
+	}
+	if got, want := squares.Take(2).ToSlice(), []int{0, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Measure() = %v, want %v", got, want)
+	}
+	got := metrics.Snapshot()
+	for i := range got {
+		got[i].Elapsed = 0
+	}
+	want := []StageMetrics{
+		{Name: "evens", Enumerations: 2, ElementsIn: 10 + 3, ElementsOut: 5 + 2, EarlyStops: 1},
+		{Name: "squares", Enumerations: 2, ElementsIn: 5 + 2, ElementsOut: 5 + 2, EarlyStops: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Metrics.Snapshot() = %+v, want %+v", got, want)
+	}
+	if got := metrics.Stage("evens").ElementsOut; got != 7 {
+		t.Errorf("Metrics.Stage() ElementsOut = %v, want 7", got)
+	}
+	if got := metrics.Stage("unknown"); got != (StageMetrics{Name: "unknown"}) {
+		t.Errorf("Metrics.Stage() = %+v, want zero counters", got)
+	}
+	t.Run("Elapsed grows while the stage runs", func(t *testing.T) {
+		var metrics Metrics
+		slow := Measure(&metrics, "slow", Range(0, 3), func(source Iterator[int]) Iterator[int] {
+			return source.Tap(func(item int) {
+				time.Sleep(time.Millisecond)
+			})
+		})
+		for item := range slow {
+			got := metrics.Stage("slow")
+			if least := time.Duration(item+1) * time.Millisecond; got.Elapsed < least || got.Running != 1 {
+				t.Errorf("Metrics.Stage() = %+v after %d elements, want Elapsed at least %v while running", got, item+1, least)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if got := metrics.Stage("slow").Elapsed; got >= 30*time.Millisecond {
+			t.Errorf("Metrics.Stage() Elapsed = %v, want the consumer's time excluded", got)
+		}
+	})
+}
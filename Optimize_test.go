@@ -0,0 +1,245 @@
+package linq
+
+import (
+	"cmp"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type scored struct {
+	Name  string
+	Score int
+}
+
+func byScore(x, y scored) int {
+	return cmp.Compare(x.Score, y.Score)
+}
+
+var scores = []scored{
+	{"a", 3}, {"b", 1}, {"c", 3}, {"d", 2}, {"e", 1}, {"f", 5}, {"g", 2}, {"h", 3},
+}
+
+// Returns source without its descriptor, so that the operators applied to it take their unoptimized path.
+func undescribed[TSource any](source Iterator[TSource]) Iterator[TSource] {
+	return func(yield func(value TSource) bool) {
+		source(yield)
+	}
+}
+
+func TestOptimize(t *testing.T) {
+	odd := func(item int) bool {
+		return item%2 != 0
+	}
+	numbers := []int{9, 4, 7, 1, 8, 3, 3, 6, 2, 5, 0}
+	byParity := func(x, y scored) int {
+		return cmp.Compare(x.Score%2, y.Score%2)
+	}
+	high := func(item scored) bool {
+		return item.Score > 1
+	}
+	score := func(item scored) int {
+		return item.Score
+	}
+	tests := []struct {
+		name string
+		got  Iterator[scored]
+		want Iterator[scored]
+	}{
+		{
+			name: "Order, Where",
+			got:  FromSlice(scores).Order(byScore).Where(high),
+			want: undescribed(FromSlice(scores).Order(byScore)).Where(high),
+		},
+		{
+			name: "OrderByDescending, Where, Take",
+			got:  OrderByDescending(FromSlice(scores), score).Where(high).Take(3),
+			want: undescribed(undescribed(OrderByDescending(FromSlice(scores), score)).Where(high)).Take(3),
+		},
+		{
+			name: "Order, Order, Take",
+			got:  FromSlice(scores).Order(byScore).Order(byParity).Take(5),
+			want: undescribed(FromSlice(scores).Order(byScore).Order(byParity)).Take(5),
+		},
+		{
+			name: "Order, Take, Where",
+			got:  FromSlice(scores).Order(byScore).Take(5).Where(high),
+			want: undescribed(FromSlice(scores).Order(byScore)).Take(5).Where(high),
+		},
+		{
+			name: "Order, Reverse, Take",
+			got:  FromSlice(scores).Order(byScore).Reverse().Take(3),
+			want: undescribed(FromSlice(scores).Order(byScore).Reverse()).Take(3),
+		},
+		{
+			name: "Reverse, Take",
+			got:  undescribed(FromSlice(scores)).Reverse().Take(3),
+			want: undescribed(undescribed(FromSlice(scores)).Reverse()).Take(3),
+		},
+		{
+			name: "Reverse, Take more than the count",
+			got:  undescribed(FromSlice(scores)).Reverse().Take(20),
+			want: undescribed(undescribed(FromSlice(scores)).Reverse()).Take(20),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, want := tt.got.ToSlice(), tt.want.ToSlice()
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("optimized = %v, want %v", got, want)
+			}
+			if again := tt.got.ToSlice(); !reflect.DeepEqual(again, got) {
+				t.Errorf("optimized enumerated again = %v, want %v", again, got)
+			}
+		})
+	}
+	t.Run("Order, Where, Take of numbers", func(t *testing.T) {
+		got := FromSlice(numbers).OrderDescending().Where(odd).Take(3).ToSlice()
+		if want := []int{9, 7, 5}; !reflect.DeepEqual(got, want) {
+			t.Errorf("optimized = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestOptimize_First(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  Iterator[scored]
+		want    scored
+		wantErr error
+	}{
+		{
+			name:   "Order, First",
+			source: FromSlice(scores).Order(byScore),
+			want:   scored{"b", 1},
+		},
+		{
+			name:   "OrderDescending, First",
+			source: FromSlice(scores).OrderDescending(byScore),
+			want:   scored{"f", 5},
+		},
+		{
+			name:   "Order, Reverse, First",
+			source: FromSlice(scores).Where(func(item scored) bool { return item.Score < 5 }).Order(byScore).Reverse(),
+			want:   scored{"h", 3},
+		},
+		{
+			name:   "Reverse, First",
+			source: oneShot(scores...).Reverse(),
+			want:   scored{"h", 3},
+		},
+		{
+			name:    "Order, First of an empty sequence",
+			source:  FromSlice([]scored{}).Order(byScore),
+			wantErr: ErrSourceContainsNoElements,
+		},
+		{
+			name:    "Reverse, First of an empty sequence",
+			source:  oneShot[scored]().Reverse(),
+			wantErr: ErrSourceContainsNoElements,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.source.First()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("First() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("First() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptimize_pushdown(t *testing.T) {
+	sorted := 0
+	query := FromSlice([]int{5, 4, 3, 2, 1}).Order(func(x, y int) int {
+		sorted++
+		return cmp.Compare(x, y)
+	}).Where(func(item int) bool {
+		return item > 3
+	})
+	if got, want := query.ToSlice(), []int{4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Order().Where() = %v, want %v", got, want)
+	}
+	if sorted != 1 {
+		t.Errorf("Order().Where() compared %d times, want 1 because the filter runs before the sort", sorted)
+	}
+	want := "Order [buffers, O(n log n)]\n" +
+		"└─ Where [O(n)]\n" +
+		"   └─ FromSlice(len=5) [O(n)]\n"
+	if got := Explain(query); got != want {
+		t.Errorf("Explain() = \n%v, want \n%v", got, want)
+	}
+}
+
+func benchmarkNumbers() []int {
+	numbers := make([]int, 100_000)
+	for i := range numbers {
+		numbers[i] = (i * 7919) % len(numbers)
+	}
+	return numbers
+}
+
+// Each benchmark runs a chain as written and, as "Unoptimized", with the descriptors that enable its rewrite hidden.
+
+func BenchmarkOrderTake(b *testing.B) {
+	numbers := benchmarkNumbers()
+	b.Run("Unoptimized", func(b *testing.B) {
+		for b.Loop() {
+			undescribed(FromSlice(numbers).Order()).Take(10).ToSlice()
+		}
+	})
+	b.Run("Optimized", func(b *testing.B) {
+		for b.Loop() {
+			FromSlice(numbers).Order().Take(10).ToSlice()
+		}
+	})
+}
+
+func BenchmarkOrderFirst(b *testing.B) {
+	numbers := benchmarkNumbers()
+	b.Run("Unoptimized", func(b *testing.B) {
+		for b.Loop() {
+			undescribed(FromSlice(numbers).Order()).First()
+		}
+	})
+	b.Run("Optimized", func(b *testing.B) {
+		for b.Loop() {
+			FromSlice(numbers).Order().First()
+		}
+	})
+}
+
+func BenchmarkOrderWhere(b *testing.B) {
+	numbers := benchmarkNumbers()
+	small := func(item int) bool {
+		return item < 10_000
+	}
+	b.Run("Unoptimized", func(b *testing.B) {
+		for b.Loop() {
+			undescribed(FromSlice(numbers).Order()).Where(small).Count()
+		}
+	})
+	b.Run("Optimized", func(b *testing.B) {
+		for b.Loop() {
+			FromSlice(numbers).Order().Where(small).Count()
+		}
+	})
+}
+
+func BenchmarkReverseFirst(b *testing.B) {
+	numbers := benchmarkNumbers()
+	b.Run("Unoptimized", func(b *testing.B) {
+		for b.Loop() {
+			undescribed(undescribed(FromSlice(numbers)).Reverse()).First()
+		}
+	})
+	b.Run("Optimized", func(b *testing.B) {
+		for b.Loop() {
+			undescribed(FromSlice(numbers)).Reverse().First()
+		}
+	})
+}
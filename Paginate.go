@@ -0,0 +1,295 @@
+package linq
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+// A page of a sequence, with the metadata needed to navigate to the other pages.
+type Page[TSource any] struct {
+
+	// The elements on the page, in the order of the source.
+	Items []TSource
+
+	// The one-based number of the page.
+	Number int
+
+	// The maximum number of elements on a page.
+	Size int
+
+	// The number of elements in the whole source.
+	TotalCount int
+
+	// The number of pages needed to hold the whole source; 0 if the source is empty.
+	TotalPages int
+
+	// Whether a page precedes this one.
+	HasPrevious bool
+
+	// Whether a page follows this one.
+	HasNext bool
+}
+
+// Returns a page of a sequence together with the total number of elements and pages, in a single enumeration of the source.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to paginate.
+//
+//	page int
+//
+// The one-based number of the page to return.
+//
+//	size int
+//
+// The maximum number of elements on a page.
+//
+// # Returns
+//
+//	result Page[TSource]
+//
+// The elements in [(page-1)*size, page*size) of source, and the metadata of the page.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrPageIsBelowOne - When page is less than 1.
+//
+// linq.ErrSizeIsBelowOne - When size is less than 1.
+//
+// # Example
+//
+//	page, _ := linq.Paginate(linq.Range(1, 10), 2, 4)
+//	fmt.Println(page.Items, page.TotalCount, page.TotalPages, page.HasPrevious, page.HasNext)
+//	/*This code produces the following output:
+//	[5 6 7 8] 10 3 true true
+//	*/
+//
+// # Remarks
+//
+// Paginate replaces Skip((page-1)*size).Take(size) followed by Count, which enumerates the source twice.
+// The source is enumerated once to the end, because the total count is needed; only the elements on the page are buffered.
+// If source is backed by a slice or Range, possibly through Select, Skip, Take and Reverse, only the elements on the page are read.
+// A page past the last one has no items, and HasNext is false.
+func Paginate[TSource any](source Iterator[TSource], page int, size int) (result Page[TSource], err error) {
+	if page < 1 {
+		return result, ErrPageIsBelowOne
+	}
+	if size < 1 {
+		return result, ErrSizeIsBelowOne
+	}
+	skip := math.MaxInt
+	if page-1 <= math.MaxInt/size {
+		skip = (page - 1) * size
+	}
+	result = Page[TSource]{
+		Number: page,
+		Size:   size,
+	}
+	if index, ok := indexOf(source); ok && index.at != nil {
+		result.TotalCount = index.length
+		from := min(skip, index.length)
+		to := from + min(size, index.length-from)
+		result.Items = make([]TSource, 0, to-from)
+		index.yieldRange(from, to, func(value TSource) bool {
+			result.Items = append(result.Items, value)
+			return true
+		})
+	} else {
+		result.Items = make([]TSource, 0)
+		for item := range source {
+			if result.TotalCount >= skip && len(result.Items) < size {
+				result.Items = append(result.Items, item)
+			}
+			result.TotalCount++
+		}
+	}
+	result.TotalPages = result.TotalCount / size
+	if result.TotalCount%size != 0 {
+		result.TotalPages++
+	}
+	result.HasPrevious = page > 1
+	result.HasNext = page < result.TotalPages
+	return result, nil
+}
+
+// A page of a sequence sorted by a key, with the key to continue from.
+type KeysetPage[TSource any, TKey any] struct {
+
+	// The elements on the page, in the order of the source.
+	Items []TSource
+
+	// Whether elements follow this page.
+	HasNext bool
+
+	// The key of the last element on the page, to pass as afterKey for the next page; the zero value if HasNext is false.
+	NextKey TKey
+}
+
+// Returns an opaque cursor that encodes NextKey, or an empty string if no page follows.
+//
+// # Returns
+//
+//	result string
+//
+// The cursor of the next page, to decode with DecodeCursor.
+//
+// # Error
+//
+//	err error
+//
+// The error returned by EncodeCursor.
+func (page KeysetPage[TSource, TKey]) NextCursor() (result string, err error) {
+	if !page.HasNext {
+		return "", nil
+	}
+	return EncodeCursor(page.NextKey)
+}
+
+// Returns the elements of a sorted sequence that follow a key, up to a page size.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// A sequence sorted in ascending order by the key, with no two elements sharing a key.
+//
+//	keySelector generic.ValueSelector[TSource, TKey]
+//
+// A function to extract the key from an element.
+//
+//	afterKey *TKey
+//
+// The key of the last element of the previous page, or nil for the first page.
+//
+//	size int
+//
+// The maximum number of elements on a page.
+//
+//	compare ...generic.Comparison[TKey] [OPTIONAL]
+//
+// A function to compare keys. If omitted, the natural order of TKey is used.
+//
+// # Returns
+//
+//	result KeysetPage[TSource, TKey]
+//
+// The first size elements of source whose key follows afterKey, whether more elements follow and the key to continue from.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrSizeIsBelowOne - When size is less than 1.
+//
+// linq.ErrTypeIsNotOrdered - When compare is omitted and TKey has no natural order.
+//
+// # Example
+//
+//	id := func(user User) int { return user.ID }
+//	page, _ := linq.PaginateKeyset(users, id, nil, 20)
+//	cursor, _ := page.NextCursor()
+//	// Later, for the next page:
+//	after, _ := linq.DecodeCursor[int](cursor)
+//	page, _ = linq.PaginateKeyset(users, id, &after, 20)
+//
+// # Remarks
+//
+// Unlike Paginate, the position of a page depends on a key rather than an offset, so pages do not shift when elements
+// are inserted or removed before them. The source is enumerated up to one element past the page, to find out
+// whether more elements follow, and is not counted.
+func PaginateKeyset[TSource any, TKey any](source Iterator[TSource], keySelector generic.ValueSelector[TSource, TKey], afterKey *TKey, size int, compare ...generic.Comparison[TKey]) (result KeysetPage[TSource, TKey], err error) {
+	if size < 1 {
+		return result, ErrSizeIsBelowOne
+	}
+	cmp, err := resolveComparison(compare...)
+	if err != nil {
+		return result, err
+	}
+	result.Items = make([]TSource, 0)
+	var last TKey
+	for item := range source {
+		key := keySelector(item)
+		if afterKey != nil && cmp(key, *afterKey) <= 0 {
+			continue
+		}
+		if len(result.Items) == size {
+			result.HasNext = true
+			result.NextKey = last
+			break
+		}
+		result.Items = append(result.Items, item)
+		last = key
+	}
+	return result, nil
+}
+
+// Encodes a key as an opaque, URL-safe cursor.
+//
+// # Parameters
+//
+//	key TKey
+//
+// The key to encode. It must be marshalable by encoding/json.
+//
+// # Returns
+//
+//	result string
+//
+// The base64url-encoded JSON representation of key, without padding.
+//
+// # Error
+//
+//	err error
+//
+// The error returned by json.Marshal.
+//
+// # Remarks
+//
+// A cursor is opaque but neither signed nor encrypted: a client can decode it and forge another one, so do not
+// put anything in the key that the client is not allowed to see, and check the decoded key as any other input.
+func EncodeCursor[TKey any](key TKey) (result string, err error) {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Decodes a cursor created by EncodeCursor.
+//
+// # Parameters
+//
+//	cursor string
+//
+// The cursor to decode.
+//
+// # Returns
+//
+//	result TKey
+//
+// The key encoded in cursor.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrInvalidCursor - When cursor is not base64url-encoded JSON or does not hold a TKey. The error wraps the underlying cause.
+func DecodeCursor[TKey any](cursor string) (result TKey, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return result, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		var zero TKey
+		return zero, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	return result, nil
+}
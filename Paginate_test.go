@@ -0,0 +1,265 @@
+package linq
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name   string
+		source Iterator[int]
+		page   int
+		size   int
+		want   Page[int]
+	}{
+		{
+			name:   "first page",
+			source: Range(1, 10),
+			page:   1,
+			size:   4,
+			want:   Page[int]{Items: []int{1, 2, 3, 4}, Number: 1, Size: 4, TotalCount: 10, TotalPages: 3, HasNext: true},
+		},
+		{
+			name:   "middle page",
+			source: Range(1, 10).Where(func(item int) bool { return true }),
+			page:   2,
+			size:   4,
+			want:   Page[int]{Items: []int{5, 6, 7, 8}, Number: 2, Size: 4, TotalCount: 10, TotalPages: 3, HasPrevious: true, HasNext: true},
+		},
+		{
+			name:   "last partial page",
+			source: Range(1, 10).Where(func(item int) bool { return true }),
+			page:   3,
+			size:   4,
+			want:   Page[int]{Items: []int{9, 10}, Number: 3, Size: 4, TotalCount: 10, TotalPages: 3, HasPrevious: true},
+		},
+		{
+			name:   "past the last page",
+			source: Range(1, 10),
+			page:   5,
+			size:   4,
+			want:   Page[int]{Items: []int{}, Number: 5, Size: 4, TotalCount: 10, TotalPages: 3, HasPrevious: true},
+		},
+		{
+			name:   "empty source",
+			source: Empty[int](),
+			page:   1,
+			size:   4,
+			want:   Page[int]{Items: []int{}, Number: 1, Size: 4},
+		},
+		{
+			name:   "exact pages",
+			source: FromSlice([]int{1, 2, 3, 4}).Where(func(item int) bool { return true }),
+			page:   2,
+			size:   2,
+			want:   Page[int]{Items: []int{3, 4}, Number: 2, Size: 2, TotalCount: 4, TotalPages: 2, HasPrevious: true},
+		},
+		{
+			name:   "page that overflows the offset",
+			source: FromSlice([]int{1, 2, 3}).Where(func(item int) bool { return true }),
+			page:   math.MaxInt,
+			size:   2,
+			want:   Page[int]{Items: []int{}, Number: math.MaxInt, Size: 2, TotalCount: 3, TotalPages: 2, HasPrevious: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Paginate(tt.source, tt.page, tt.size)
+			if err != nil {
+				t.Fatalf("Paginate() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Paginate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginateEnumeratesOnce(t *testing.T) {
+	enumerations, reads := 0, 0
+	source := Iterator[int](func(yield func(value int) bool) {
+		enumerations++
+		for i := range 7 {
+			reads++
+			if !yield(i) {
+				return
+			}
+		}
+	})
+	got, err := Paginate(source, 2, 3)
+	if err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+	if want := []int{3, 4, 5}; !reflect.DeepEqual(got.Items, want) || got.TotalCount != 7 {
+		t.Errorf("Paginate() = %+v, want items %v and total count 7", got, want)
+	}
+	if enumerations != 1 || reads != 7 {
+		t.Errorf("Paginate() enumerated the source %d times and read %d elements, want 1 and 7", enumerations, reads)
+	}
+
+	selected := 0
+	indexed := Select(FromSlice([]int{1, 2, 3, 4, 5, 6, 7}), func(item int) int {
+		selected++
+		return item * 10
+	})
+	got, err = Paginate(indexed, 3, 3)
+	if err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+	if want := []int{70}; !reflect.DeepEqual(got.Items, want) || got.TotalPages != 3 {
+		t.Errorf("Paginate() = %+v, want items %v and 3 total pages", got, want)
+	}
+	if selected != 1 {
+		t.Errorf("Paginate() selected %d elements of an indexed source, want 1", selected)
+	}
+}
+
+func TestPaginateErrors(t *testing.T) {
+	if _, err := Paginate(Range(0, 3), 0, 2); !errors.Is(err, ErrPageIsBelowOne) {
+		t.Errorf("Paginate() error = %v, want %v", err, ErrPageIsBelowOne)
+	}
+	if _, err := Paginate(Range(0, 3), 1, 0); !errors.Is(err, ErrSizeIsBelowOne) {
+		t.Errorf("Paginate() error = %v, want %v", err, ErrSizeIsBelowOne)
+	}
+}
+
+func TestPaginateKeyset(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	users := FromSlice([]user{{1, "Ann"}, {3, "Bob"}, {4, "Cid"}, {8, "Dee"}, {9, "Eve"}})
+	id := func(item user) int { return item.ID }
+	names := func(page KeysetPage[user, int]) []string {
+		return Select(FromSlice(page.Items), func(item user) string { return item.Name }).ToSlice()
+	}
+
+	var pages [][]string
+	var after *int
+	for {
+		page, err := PaginateKeyset(users, id, after, 2)
+		if err != nil {
+			t.Fatalf("PaginateKeyset() error = %v", err)
+		}
+		pages = append(pages, names(page))
+		if !page.HasNext {
+			if page.NextKey != 0 {
+				t.Errorf("PaginateKeyset().NextKey = %v on the last page, want 0", page.NextKey)
+			}
+			break
+		}
+		cursor, err := page.NextCursor()
+		if err != nil {
+			t.Fatalf("NextCursor() error = %v", err)
+		}
+		key, err := DecodeCursor[int](cursor)
+		if err != nil {
+			t.Fatalf("DecodeCursor() error = %v", err)
+		}
+		after = &key
+	}
+	if want := [][]string{{"Ann", "Bob"}, {"Cid", "Dee"}, {"Eve"}}; !reflect.DeepEqual(pages, want) {
+		t.Errorf("PaginateKeyset() pages = %v, want %v", pages, want)
+	}
+
+	missing := 5
+	page, err := PaginateKeyset(users, id, &missing, 10)
+	if err != nil {
+		t.Fatalf("PaginateKeyset() error = %v", err)
+	}
+	if got, want := names(page), []string{"Dee", "Eve"}; !reflect.DeepEqual(got, want) || page.HasNext {
+		t.Errorf("PaginateKeyset() after a missing key = %v, HasNext %v, want %v, false", got, page.HasNext, want)
+	}
+	if cursor, err := page.NextCursor(); cursor != "" || err != nil {
+		t.Errorf("NextCursor() = %q, %v, want \"\", nil", cursor, err)
+	}
+
+	descending := func(x, y int) int { return y - x }
+	reversed := users.Reverse()
+	last := 8
+	page, err = PaginateKeyset(reversed, id, &last, 2, descending)
+	if err != nil {
+		t.Fatalf("PaginateKeyset() error = %v", err)
+	}
+	if got, want := names(page), []string{"Cid", "Bob"}; !reflect.DeepEqual(got, want) || !page.HasNext || page.NextKey != 3 {
+		t.Errorf("PaginateKeyset() descending = %v, HasNext %v, NextKey %v, want %v, true, 3", got, page.HasNext, page.NextKey, want)
+	}
+}
+
+func TestPaginateKeysetStopsEarly(t *testing.T) {
+	reads := 0
+	source := Iterator[int](func(yield func(value int) bool) {
+		for i := 0; ; i++ {
+			reads++
+			if !yield(i) {
+				return
+			}
+		}
+	})
+	after := 10
+	page, err := PaginateKeyset(source, func(item int) int { return item }, &after, 3)
+	if err != nil {
+		t.Fatalf("PaginateKeyset() error = %v", err)
+	}
+	if want := []int{11, 12, 13}; !reflect.DeepEqual(page.Items, want) || !page.HasNext || page.NextKey != 13 {
+		t.Errorf("PaginateKeyset() = %+v, want items %v, HasNext and NextKey 13", page, want)
+	}
+	if reads != 15 {
+		t.Errorf("PaginateKeyset() read %d elements, want 15", reads)
+	}
+}
+
+func TestPaginateKeysetErrors(t *testing.T) {
+	identity := func(item int) int { return item }
+	if _, err := PaginateKeyset(Range(0, 3), identity, nil, 0); !errors.Is(err, ErrSizeIsBelowOne) {
+		t.Errorf("PaginateKeyset() error = %v, want %v", err, ErrSizeIsBelowOne)
+	}
+	type key struct{ A, B int }
+	if _, err := PaginateKeyset(Range(0, 3), func(item int) key { return key{item, item} }, nil, 2); !errors.Is(err, ErrTypeIsNotOrdered) {
+		t.Errorf("PaginateKeyset() error = %v, want %v", err, ErrTypeIsNotOrdered)
+	}
+}
+
+func TestCursor(t *testing.T) {
+	type key struct {
+		Name string
+		ID   int
+	}
+	want := key{Name: "Zoë & co/?", ID: 42}
+	cursor, err := EncodeCursor(want)
+	if err != nil {
+		t.Fatalf("EncodeCursor() error = %v", err)
+	}
+	if strings.ContainsAny(cursor, "+/=&? ") {
+		t.Errorf("EncodeCursor() = %q, want a URL-safe cursor", cursor)
+	}
+	got, err := DecodeCursor[key](cursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("DecodeCursor() = %v, want %v", got, want)
+	}
+
+	if _, err := EncodeCursor(func() {}); err == nil {
+		t.Errorf("EncodeCursor(func) error = nil, want an error")
+	}
+	for _, cursor := range []string{"not base64!", mustEncodeCursor(t, "text")} {
+		if _, err := DecodeCursor[int](cursor); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("DecodeCursor(%q) error = %v, want %v", cursor, err, ErrInvalidCursor)
+		}
+	}
+}
+
+func mustEncodeCursor(t *testing.T, key any) string {
+	t.Helper()
+	cursor, err := EncodeCursor(key)
+	if err != nil {
+		t.Fatalf("EncodeCursor() error = %v", err)
+	}
+	return cursor
+}
@@ -0,0 +1,124 @@
+package linq
+
+// A step of a query that turns a sequence of one type into a sequence of another, or of the same, type.
+//
+// Go methods cannot have type parameters, so only the operators that keep the element type can be chained as methods.
+// Stages lift every operator to a value that Pipe and Pipe2 through Pipe8 apply in order, so that a query reads top to bottom
+// and the compiler checks that the output of each stage is the input of the next:
+//
+//	names := linq.Pipe3(people,
+//		linq.Filter(isAdult),
+//		linq.SortBy(func(person Person) int { return person.Age }),
+//		linq.Map(func(person Person) string { return person.Name }),
+//	)
+//
+// Applying a stage builds the operator and enumerates nothing.
+type Stage[TIn any, TOut any] func(source Iterator[TIn]) Iterator[TOut]
+
+// Chains two stages into one.
+//
+// # Parameters
+//
+//	first Stage[TIn, TMiddle]
+//
+// The stage to apply first.
+//
+//	second Stage[TMiddle, TOut]
+//
+// The stage to apply to the output of first.
+//
+// # Returns
+//
+//	result Stage[TIn, TOut]
+//
+// A Stage that applies first, then second.
+func Then[TIn any, TMiddle any, TOut any](first Stage[TIn, TMiddle], second Stage[TMiddle, TOut]) (result Stage[TIn, TOut]) {
+	return func(source Iterator[TIn]) Iterator[TOut] {
+		return second(first(source))
+	}
+}
+
+// Applies stages that keep the element type to a sequence, in order.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The input of the first stage.
+//
+//	stages ...Stage[TSource, TSource]
+//
+// The stages to apply. Use Pipe2 through Pipe8 for stages that change the element type.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// The output of the last stage, or source if there are no stages.
+//
+// # Example
+//
+//	result := linq.Pipe(linq.Range(1, 10),
+//		linq.Filter(isEven),
+//		linq.SortDescending[int](),
+//		linq.Limit[int](3),
+//	).ToSlice()
+//	/*This code produces the following output result = []int{10, 8, 6}*/
+func Pipe[TSource any](source Iterator[TSource], stages ...Stage[TSource, TSource]) (result Iterator[TSource]) {
+	result = source
+	for _, stage := range stages {
+		result = stage(result)
+	}
+	return result
+}
+
+// Applies 2 stages to a sequence, in order. Pipe3 through Pipe8 do the same for 3 to 8 stages.
+//
+// # Parameters
+//
+//	source Iterator[TIn]
+//
+// The input of the first stage.
+//
+//	stage1 … stageN
+//
+// The stages to apply. The output type of each stage is the input type of the next.
+//
+// # Returns
+//
+//	result Iterator[TOut]
+//
+// The output of the last stage.
+func Pipe2[TIn any, T1 any, TOut any](source Iterator[TIn], stage1 Stage[TIn, T1], stage2 Stage[T1, TOut]) (result Iterator[TOut]) {
+	return stage2(stage1(source))
+}
+
+// Applies 3 stages to a sequence, in order. See Pipe2.
+func Pipe3[TIn any, T1 any, T2 any, TOut any](source Iterator[TIn], stage1 Stage[TIn, T1], stage2 Stage[T1, T2], stage3 Stage[T2, TOut]) (result Iterator[TOut]) {
+	return stage3(stage2(stage1(source)))
+}
+
+// Applies 4 stages to a sequence, in order. See Pipe2.
+func Pipe4[TIn any, T1 any, T2 any, T3 any, TOut any](source Iterator[TIn], stage1 Stage[TIn, T1], stage2 Stage[T1, T2], stage3 Stage[T2, T3], stage4 Stage[T3, TOut]) (result Iterator[TOut]) {
+	return stage4(stage3(stage2(stage1(source))))
+}
+
+// Applies 5 stages to a sequence, in order. See Pipe2.
+func Pipe5[TIn any, T1 any, T2 any, T3 any, T4 any, TOut any](source Iterator[TIn], stage1 Stage[TIn, T1], stage2 Stage[T1, T2], stage3 Stage[T2, T3], stage4 Stage[T3, T4], stage5 Stage[T4, TOut]) (result Iterator[TOut]) {
+	return stage5(stage4(stage3(stage2(stage1(source)))))
+}
+
+// Applies 6 stages to a sequence, in order. See Pipe2.
+func Pipe6[TIn any, T1 any, T2 any, T3 any, T4 any, T5 any, TOut any](source Iterator[TIn], stage1 Stage[TIn, T1], stage2 Stage[T1, T2], stage3 Stage[T2, T3], stage4 Stage[T3, T4], stage5 Stage[T4, T5], stage6 Stage[T5, TOut]) (result Iterator[TOut]) {
+	return stage6(stage5(stage4(stage3(stage2(stage1(source))))))
+}
+
+// Applies 7 stages to a sequence, in order. See Pipe2.
+func Pipe7[TIn any, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, TOut any](source Iterator[TIn], stage1 Stage[TIn, T1], stage2 Stage[T1, T2], stage3 Stage[T2, T3], stage4 Stage[T3, T4], stage5 Stage[T4, T5], stage6 Stage[T5, T6], stage7 Stage[T6, TOut]) (result Iterator[TOut]) {
+	return stage7(stage6(stage5(stage4(stage3(stage2(stage1(source)))))))
+}
+
+// Applies 8 stages to a sequence, in order. See Pipe2.
+func Pipe8[TIn any, T1 any, T2 any, T3 any, T4 any, T5 any, T6 any, T7 any, TOut any](source Iterator[TIn], stage1 Stage[TIn, T1], stage2 Stage[T1, T2], stage3 Stage[T2, T3], stage4 Stage[T3, T4], stage5 Stage[T4, T5], stage6 Stage[T5, T6], stage7 Stage[T6, T7], stage8 Stage[T7, TOut]) (result Iterator[TOut]) {
+	return stage8(stage7(stage6(stage5(stage4(stage3(stage2(stage1(source))))))))
+}
@@ -0,0 +1,115 @@
+package linq
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+func TestPipe(t *testing.T) {
+	isEven := func(item int) bool {
+		return item%2 == 0
+	}
+	if got, want := Pipe(Range(1, 10), Filter(isEven), SortDescending[int](), Limit[int](3)).ToSlice(), []int{10, 8, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Pipe() = %v, want %v", got, want)
+	}
+	if got, want := Pipe(Range(1, 3)).ToSlice(), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Pipe() without stages = %v, want %v", got, want)
+	}
+	query := Pipe(FromSlice([]int{3, 1, 2}), Filter(isEven), Limit[int](1))
+	if got, want := Explain(query), Explain(FromSlice([]int{3, 1, 2}).Where(isEven).Take(1)); got != want {
+		t.Errorf("Explain(Pipe()) = %q, want %q", got, want)
+	}
+}
+
+func TestPipeN(t *testing.T) {
+	people := FromSlice([]scored{{"Ann", 30}, {"Bob", 12}, {"Cid", 45}, {"Dee", 18}})
+	names := Pipe3(people,
+		Filter(func(person scored) bool {
+			return person.Score >= 18
+		}),
+		SortBy(func(person scored) int {
+			return person.Score
+		}),
+		Map(func(person scored) string {
+			return person.Name
+		}),
+	)
+	if got, want := names.ToSlice(), []string{"Dee", "Ann", "Cid"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Pipe3() = %v, want %v", got, want)
+	}
+	lengths := Pipe8(FromSlice([]string{"b", "aa", "ccc", "aa"}),
+		Unique[string](),
+		Map(func(item string) int {
+			return len(item)
+		}),
+		Sort[int](),
+		Map(strconv.Itoa),
+		Batch[string](2),
+		Map(func(chunk []string) Iterator[string] {
+			return FromSlice(chunk)
+		}),
+		Flat[string](),
+		Reversed[string](),
+	)
+	if got, want := lengths.ToSlice(), []string{"3", "2", "1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Pipe8() = %v, want %v", got, want)
+	}
+	doubled := Then(Map(func(item int) int {
+		return item * 2
+	}), Map(strconv.Itoa))
+	if got, want := Pipe2(Range(1, 3), doubled, ConcatWith(FromSlice([]string{"x"}))).ToSlice(), []string{"2", "4", "6", "x"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Pipe2() = %v, want %v", got, want)
+	}
+}
+
+func TestStages(t *testing.T) {
+	numbers := func() Iterator[int] {
+		return FromSlice([]int{5, 3, 8, 3, 1, 8})
+	}
+	isOdd := func(item int) bool {
+		return item%2 != 0
+	}
+	half := func(item int) int {
+		return item / 2
+	}
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"FlatMap", FlatMap(func(item int) []int { return []int{item, item} })(Range(1, 2)).ToSlice(), []int{1, 1, 2, 2}},
+		{"Convert", Convert[any, int]()(FromSlice([]any{1, 2})).ToSlice(), []int{1, 2}},
+		{"Sort", Sort[int]()(numbers()).ToSlice(), []int{1, 3, 3, 5, 8, 8}},
+		{"SortByDescending", Pipe2(numbers(), SortByDescending(half), Map(half)).ToSlice(), []int{4, 4, 2, 1, 1, 0}},
+		{"Top", Top[int](2)(numbers()).ToSlice(), []int{8, 8}},
+		{"Bottom", Bottom[int](2)(numbers()).ToSlice(), []int{1, 3}},
+		{"Group", Group(isOdd)(numbers()).Count(), 2},
+		{"JoinWith", JoinWith(Range(3, 3), identity, identity, func(outer int, inner int) int { return outer + inner })(numbers()).ToSlice(), []int{10, 6, 6}},
+		{"PairWith", PairWith[int](FromSlice([]string{"a"}))(numbers()).ToSlice(), []generic.ValuePair[int, string]{{Item1: 5, Item2: "a"}}},
+		{"CombineWith", CombineWith(Range(1, 2), func(first int, second int) int { return first * second })(numbers()).ToSlice(), []int{5, 6}},
+		{"Drop", Drop[int](4)(numbers()).ToSlice(), []int{1, 8}},
+		{"LimitLast", LimitLast[int](2)(numbers()).ToSlice(), []int{1, 8}},
+		{"DropLast", DropLast[int](4)(numbers()).ToSlice(), []int{5, 3}},
+		{"LimitWhile", LimitWhile(isEven)(numbers()).ToSlice(), numbers().TakeWhile(isEven).ToSlice()},
+		{"DropWhile", DropWhile(isEven)(numbers()).ToSlice(), numbers().SkipWhile(isEven).ToSlice()},
+		{"Appended", Appended(9)(numbers()).Count(), 7},
+		{"Prepended", Prepended(9)(numbers()).ToSlice()[0], 9},
+		{"Without", Without(Range(1, 5))(numbers()).ToSlice(), []int{8}},
+		{"CommonWith", CommonWith(Range(1, 5))(numbers()).ToSlice(), []int{5, 3, 1}},
+		{"UnionWith", UnionWith(Range(1, 2))(numbers()).ToSlice(), []int{5, 3, 8, 1, 2}},
+		{"Inspect", Pipe(numbers(), Inspect(func(int) {}), Cached[int]()).ToSlice(), numbers().ToSlice()},
+		{"Shuffled", Shuffled[int](rand.New(rand.NewPCG(1, 2)))(numbers()).Order().ToSlice(), []int{1, 3, 3, 5, 8, 8}},
+		{"Sampled", Sampled[int](6, rand.New(rand.NewPCG(1, 2)))(numbers()).Count(), 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("%s() = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
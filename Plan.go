@@ -0,0 +1,311 @@
+package linq
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+// Describes a stage of a composed query, as returned by Plan.
+type PlanNode struct {
+
+	// The name of the operator, for example "Where" or "OrderBy".
+	Operator string
+
+	// True if the operator holds elements in memory, for example to sort them; false if it streams them.
+	Buffers bool
+
+	// The time complexity of the operator in terms of the sizes of its inputs, for example "O(n)" or "O(n·m)".
+	Cost string
+
+	// The parameters of the operator that are known when the query is composed, for example "count=10".
+	Parameters []string
+
+	// The stages the operator reads from, in the order of its parameters.
+	Children []*PlanNode
+}
+
+// Renders the plan as an indented tree, one stage per line.
+func (node *PlanNode) String() string {
+	var builder strings.Builder
+	node.render(&builder, "", "")
+	return builder.String()
+}
+
+func (node *PlanNode) render(builder *strings.Builder, prefix string, childPrefix string) {
+	builder.WriteString(prefix)
+	builder.WriteString(node.Operator)
+	if len(node.Parameters) > 0 {
+		builder.WriteString("(")
+		builder.WriteString(strings.Join(node.Parameters, ", "))
+		builder.WriteString(")")
+	}
+	notes := make([]string, 0, 2)
+	if node.Buffers {
+		notes = append(notes, "buffers")
+	}
+	if node.Cost != "" {
+		notes = append(notes, node.Cost)
+	}
+	if len(notes) > 0 {
+		builder.WriteString(" [")
+		builder.WriteString(strings.Join(notes, ", "))
+		builder.WriteString("]")
+	}
+	builder.WriteString("\n")
+	for i, child := range node.Children {
+		if i == len(node.Children)-1 {
+			child.render(builder, childPrefix+"└─ ", childPrefix+"   ")
+		} else {
+			child.render(builder, childPrefix+"├─ ", childPrefix+"│  ")
+		}
+	}
+}
+
+// What an operator of this package knows about the sequence it returns, without enumerating it.
+type descriptor[TSource any] struct {
+
+	// Returns the plan node of the operator, with the plan nodes of its sources as children.
+	plan func() *PlanNode
+
+	// Returns the length of the sequence and, if it supports it, random access to its elements; nil if the sequence never
+	// carries either.
+	index func() (result indexed[TSource], ok bool)
+
+	// Returns the first count elements of the sequence with less work than enumerating all of it, as a sort does with a
+	// bounded heap; nil if the sequence has no such shortcut.
+	first func(count int) Iterator[TSource]
+
+	// Returns the elements of the sequence that satisfy predicate, filtered before the operator buffers them, as a sort
+	// can; nil if the operator does not buffer or cannot filter first.
+	filter func(predicate generic.Predicate[TSource]) Iterator[TSource]
+}
+
+// The optional parts of a descriptor.
+type capabilities uint8
+
+const (
+	hasIndex capabilities = 1 << iota
+	hasFirst
+	hasFilter
+)
+
+// The addresses of the code of the sequences describe has returned, mapped to the optional parts of their descriptors.
+// The address is the same for every sequence describe returns for the same shape of TSource and the same parts, so the
+// map stays small. It is replaced, never modified, so that it is read without a lock.
+var described struct {
+	codes atomic.Pointer[map[uintptr]capabilities]
+
+	// Serializes replacing codes.
+	sync.Mutex
+}
+
+// Returns sequence carrying its descriptor, which descriptorOf reads without enumerating sequence.
+//
+// The result enumerates sequence, except that enumerating it with a nil yield function, which no consumer passes,
+// panics with the descriptor, which descriptorOf recovers. describe records the address of the code of the result, so
+// that descriptorOf calls no other sequence with a nil yield function. Each combination of optional parts has its own
+// function literal, so that the address also tells which parts the descriptor has.
+func describe[TSource any](description descriptor[TSource], sequence Iterator[TSource]) (result Iterator[TSource]) {
+	var parts capabilities
+	if description.index != nil {
+		parts |= hasIndex
+	}
+	if description.first != nil {
+		parts |= hasFirst
+	}
+	if description.filter != nil {
+		parts |= hasFilter
+	}
+	switch parts {
+	case hasIndex:
+		result = func(yield func(value TSource) bool) {
+			if yield == nil {
+				panic(description)
+			}
+			sequence(yield)
+		}
+	case hasIndex | hasFirst:
+		result = func(yield func(value TSource) bool) {
+			if yield == nil {
+				panic(description)
+			}
+			sequence(yield)
+		}
+	case hasFirst | hasFilter:
+		result = func(yield func(value TSource) bool) {
+			if yield == nil {
+				panic(description)
+			}
+			sequence(yield)
+		}
+	default:
+		// No operator has another combination of parts. Should one, its parts are not advertised, and descriptorOf
+		// returns its descriptor only when no part is wanted.
+		parts = 0
+		result = func(yield func(value TSource) bool) {
+			if yield == nil {
+				panic(description)
+			}
+			sequence(yield)
+		}
+	}
+	if code := reflect.ValueOf(result).Pointer(); !isDescribed(code) {
+		addDescribed(code, parts)
+	}
+	return result
+}
+
+// Reports whether code is the address of the code of a sequence describe has returned.
+func isDescribed(code uintptr) bool {
+	_, ok := describedParts(code)
+	return ok
+}
+
+// Returns the optional parts of the descriptors of the sequences whose code is at code, and whether describe returned any.
+func describedParts(code uintptr) (parts capabilities, ok bool) {
+	if codes := described.codes.Load(); codes != nil {
+		parts, ok = (*codes)[code]
+	}
+	return parts, ok
+}
+
+func addDescribed(code uintptr, parts capabilities) {
+	described.Lock()
+	defer described.Unlock()
+	codes := make(map[uintptr]capabilities)
+	if previous := described.codes.Load(); previous != nil {
+		maps.Copy(codes, *previous)
+	}
+	codes[code] = parts
+	described.codes.Store(&codes)
+}
+
+// Returns the descriptor of source, or nil if source was not returned by describe.
+// Only a descriptor with all the optional parts in wanted is returned, and sequences without them are not asked.
+func descriptorOf[TSource any](source Iterator[TSource], wanted capabilities) (result *descriptor[TSource]) {
+	if source == nil {
+		return nil
+	}
+	if parts, ok := describedParts(reflect.ValueOf(source).Pointer()); !ok || parts&wanted != wanted {
+		return nil
+	}
+	defer func() {
+		switch recovered := recover().(type) {
+		case nil:
+		case descriptor[TSource]:
+			result = &recovered
+		default:
+			panic(recovered)
+		}
+	}()
+	source(nil)
+	return nil
+}
+
+// Returns the plan node of source, or an "Opaque" node if it was not returned by an operator of this package.
+// Called by Plan and by the descriptors of the operators that read from source.
+func askPlan[TSource any](source Iterator[TSource]) (result *PlanNode) {
+	if source == nil {
+		return &PlanNode{
+			Operator: "Nil",
+		}
+	}
+	description := descriptorOf(source, 0)
+	if description == nil {
+		return &PlanNode{
+			Operator: "Opaque",
+		}
+	}
+	return description.plan()
+}
+
+// Returns the plan nodes of sources, in order.
+func askPlans[TSource any](sources []Iterator[TSource]) (result []*PlanNode) {
+	result = make([]*PlanNode, len(sources))
+	for i, source := range sources {
+		result[i] = askPlan(source)
+	}
+	return result
+}
+
+// Returns the plan node of an operator.
+func node(operator string, buffers bool, cost string, parameters []string, children ...*PlanNode) *PlanNode {
+	return &PlanNode{
+		Operator:   operator,
+		Buffers:    buffers,
+		Cost:       cost,
+		Parameters: parameters,
+		Children:   children,
+	}
+}
+
+// Returns the query plan of a composed sequence.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to describe.
+//
+// # Returns
+//
+//	result *PlanNode
+//
+// The root of the plan tree: the operator that produced source, with the operators it reads from as its children.
+//
+// # Remarks
+//
+// The operators and sources of this package describe themselves without enumerating anything.
+// A sequence that does not come from one of them is shown as an "Opaque" node and is not enumerated either.
+//
+// Describing a query is not free, whether or not Plan is ever called. Composing an operator allocates the functions that
+// build its node and the wrapper that carries them, a few allocations per operator. Every enumeration of it costs one more
+// comparison and one more call, and, for operators that look for a rewrite or an index, such as Take and Count, one lookup
+// in a map shared by all sequences. Elements pass through the same loops as without descriptors, but the wrappers keep
+// the compiler from inlining a query into the loop that consumes it. BenchmarkDescribe compares a described query with
+// the same query without descriptors: the difference is fixed per composition and enumeration, so it matters only for
+// queries over a few elements that are composed in a hot loop.
+func Plan[TSource any](source Iterator[TSource]) (result *PlanNode) {
+	return askPlan(source)
+}
+
+// Returns the query plan of a composed sequence rendered as an indented tree.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to describe.
+//
+// # Returns
+//
+//	result string
+//
+// One line per stage. Stages that buffer their input and the cost of every stage are noted in brackets.
+//
+// # Example
+//
+//	query := linq.FromSlice(people).Where(isAdult).Take(10)
+//	fmt.Print(linq.Explain(query))
+//	/*This code produces the following output:
+//	Take(count=10) [O(n)]
+//	└─ Where [O(n)]
+//	   └─ FromSlice(len=100) [O(n)]
+//	*/
+//
+// # Remarks
+//
+// See Plan.
+func Explain[TSource any](source Iterator[TSource]) (result string) {
+	return Plan(source).String()
+}
+
+func parameter(name string, value any) string {
+	return fmt.Sprintf("%s=%v", name, value)
+}
@@ -0,0 +1,211 @@
+package linq
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+func TestPlan(t *testing.T) {
+	t.Run("Plan of composed operators", func(t *testing.T) {
+		query := FromSlice([]int{5, 3, 1, 4}).Where(func(item int) bool {
+			return item > 1
+		}).Order().Take(2)
+		want := &PlanNode{
+			Operator:   "Take",
+			Cost:       "O(n)",
+			Parameters: []string{"count=2"},
+			Children: []*PlanNode{
+				{
+					Operator: "Order",
+					Buffers:  true,
+					Cost:     "O(n log n)",
+					Children: []*PlanNode{
+						{
+							Operator: "Where",
+							Cost:     "O(n)",
+							Children: []*PlanNode{
+								{
+									Operator:   "FromSlice",
+									Cost:       "O(n)",
+									Parameters: []string{"len=4"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		if got := Plan(query); !reflect.DeepEqual(got, want) {
+			t.Errorf("Plan() = %v, want %v", got, want)
+		}
+		if got, want := query.ToSlice(), []int{3, 4}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Plan() changed the query to %v, want %v", got, want)
+		}
+	})
+	t.Run("Plan does not enumerate instrumented sources", func(t *testing.T) {
+		calls := 0
+		query := Select(Range(1, 3), func(item int) int {
+			calls++
+			return item
+		})
+		Plan(query)
+		if calls != 0 {
+			t.Errorf("Plan() called the selector %d times, want 0", calls)
+		}
+	})
+	t.Run("Plan of an opaque source", func(t *testing.T) {
+		pulled := 0
+		var source Iterator[int] = func(yield func(value int) bool) {
+			for i := range 3 {
+				pulled++
+				if !yield(i) {
+					return
+				}
+			}
+		}
+		got := Plan(source.Skip(1))
+		want := &PlanNode{
+			Operator:   "Skip",
+			Cost:       "O(n)",
+			Parameters: []string{"count=1"},
+			Children: []*PlanNode{
+				{
+					Operator: "Opaque",
+				},
+			},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Plan() = %v, want %v", got, want)
+		}
+		if pulled != 0 {
+			t.Errorf("Plan() pulled %d elements from an opaque source, want 0", pulled)
+		}
+	})
+	t.Run("Plan of observed and zipped sequences", func(t *testing.T) {
+		metrics := &Metrics{}
+		query := Zip(FromSlice([]int{1, 2}).Tap(func(item int) {}), Measure(metrics, "double", Range(1, 2), func(source Iterator[int]) Iterator[int] {
+			return Select(source, func(item int) int {
+				return item * 2
+			})
+		}))
+		want := "Zip [O(n)]\n" +
+			"├─ Tap [O(n)]\n" +
+			"│  └─ FromSlice(len=2) [O(n)]\n" +
+			"└─ Measure(name=double) [O(n)]\n" +
+			"   └─ Select [O(n)]\n" +
+			"      └─ Range(start=1, count=2) [O(n)]\n"
+		if got := Explain(query); got != want {
+			t.Errorf("Explain() = \n%v, want \n%v", got, want)
+		}
+	})
+	t.Run("Plan of a nil source", func(t *testing.T) {
+		if got := Plan[int](nil); got.Operator != "Nil" {
+			t.Errorf("Plan() = %v, want Nil", got)
+		}
+	})
+	t.Run("Plan does not enumerate opaque sources", func(t *testing.T) {
+		var source Iterator[int] = func(yield func(value int) bool) {
+			panic("boom")
+		}
+		if got := Plan(source.Where(func(item int) bool {
+			return true
+		})); got.Children[0].Operator != "Opaque" {
+			t.Errorf("Plan() = %v, want Where over Opaque", got)
+		}
+	})
+}
+
+func TestExplain(t *testing.T) {
+	type order struct {
+		Customer int
+		Amount   int
+	}
+	customers := FromMap(map[int]string{1: "Ada", 2: "Grace"})
+	orders := FromSlice([]order{{Customer: 1, Amount: 10}})
+	joined := Join(customers, orders, func(customer generic.KeyValuePair[int, string]) int {
+		return customer.Key
+	}, func(order order) int {
+		return order.Customer
+	}, func(customer generic.KeyValuePair[int, string], order order) string {
+		return customer.Value
+	})
+	want := "Distinct [buffers, O(n²)]\n" +
+		"└─ Join(match=DeepEqual) [buffers, O(n·m)]\n" +
+		"   ├─ FromMap(len=2) [O(n)]\n" +
+		"   └─ FromSlice(len=1) [O(n)]\n"
+	if got := Explain(joined.Distinct()); got != want {
+		t.Errorf("Explain() = \n%v, want \n%v", got, want)
+	}
+}
+
+// The sequences FromSlice, Where and Take would be without their descriptors, to measure what describing them costs.
+// They are not inlined, as the described operators are not, so that the benchmark measures the descriptors rather than
+// the inlining they prevent.
+
+//go:noinline
+func rawFromSlice[TSource any](source []TSource) Iterator[TSource] {
+	return func(yield func(value TSource) bool) {
+		for _, item := range source {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+//go:noinline
+func rawWhere[TSource any](source Iterator[TSource], predicate generic.Predicate[TSource]) Iterator[TSource] {
+	return func(yield func(value TSource) bool) {
+		for item := range source {
+			if predicate(item) && !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+//go:noinline
+func rawTake[TSource any](source Iterator[TSource], count int) Iterator[TSource] {
+	return func(yield func(value TSource) bool) {
+		if count <= 0 {
+			return
+		}
+		remaining := count
+		for item := range source {
+			if !yield(item) {
+				return
+			}
+			remaining--
+			if remaining == 0 {
+				return
+			}
+		}
+	}
+}
+
+// Composes and enumerates FromSlice, Where and Take with and without descriptors. The difference is paid once per
+// composition and enumeration, not per element, so it shrinks as n grows.
+func BenchmarkDescribe(b *testing.B) {
+	isEven := func(item int) bool { return item%2 == 0 }
+	for _, n := range []int{10, 1_000, 100_000} {
+		numbers := make([]int, n)
+		for i := range numbers {
+			numbers[i] = i
+		}
+		b.Run(fmt.Sprintf("Raw/n=%d", n), func(b *testing.B) {
+			for b.Loop() {
+				for range rawTake(rawWhere(rawFromSlice(numbers), isEven), n) {
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Described/n=%d", n), func(b *testing.B) {
+			for b.Loop() {
+				for range FromSlice(numbers).Where(isEven).Take(n) {
+				}
+			}
+		})
+	}
+}
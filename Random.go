@@ -0,0 +1,218 @@
+package linq
+
+import (
+	"container/heap"
+	"math"
+	"math/rand/v2"
+)
+
+// Randomizes the order of the elements of a sequence.
+//
+// # Parameters
+//
+//	rng *rand.Rand
+//
+// The source of randomness.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains the elements of the source sequence in random order.
+//
+// # Remarks
+//
+// The source is buffered when the result is enumerated. The elements are then drawn one by one with the Fisher-Yates algorithm,
+// so stopping early costs only the draws that were made.
+// For a given state of rng the order is deterministic. Every enumeration draws new numbers from rng, so enumerating the result again yields a different order.
+func (source Iterator[TSource]) Shuffle(rng *rand.Rand) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Shuffle", true, "O(n)", nil, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
+		items := source.ToSlice()
+		for i := range items {
+			j := i + rng.IntN(len(items)-i)
+			items[i], items[j] = items[j], items[i]
+			if !yield(items[i]) {
+				return
+			}
+		}
+	})
+}
+
+// Selects a random subset of elements of a sequence, in one pass.
+//
+// # Parameters
+//
+//	k int
+//
+// The number of elements to select.
+//
+//	rng *rand.Rand
+//
+// The source of randomness.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains k elements of the source sequence chosen uniformly at random, or all its elements if it has fewer than k.
+//
+// # Remarks
+//
+// The method uses reservoir sampling: the source is enumerated once and at most k elements are held in memory.
+// The reservoir grows with the source, so a k larger than the source costs no more than the source itself.
+// The selected elements are yielded in the order they have in the reservoir, which is not the order of the source.
+// If k is not a positive number, the result is empty.
+// For a given state of rng the selection is deterministic.
+func (source Iterator[TSource]) Sample(k int, rng *rand.Rand) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Sample", true, "O(n)", []string{parameter("k", k)}, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
+		if k <= 0 {
+			return
+		}
+		reservoir := make([]TSource, 0)
+		seen := 0
+		for item := range source {
+			seen++
+			if len(reservoir) < k {
+				reservoir = append(reservoir, item)
+				continue
+			}
+			if j := rng.IntN(seen); j < k {
+				reservoir[j] = item
+			}
+		}
+		for _, item := range reservoir {
+			if !yield(item) {
+				return
+			}
+		}
+	})
+}
+
+type weightedItem[TSource any] struct {
+	item TSource
+	key  float64
+}
+
+type weightedHeap[TSource any] []weightedItem[TSource]
+
+func (h weightedHeap[TSource]) Len() int           { return len(h) }
+func (h weightedHeap[TSource]) Less(i, j int) bool { return h[i].key < h[j].key }
+func (h weightedHeap[TSource]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *weightedHeap[TSource]) Push(x any)        { *h = append(*h, x.(weightedItem[TSource])) }
+func (h *weightedHeap[TSource]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// Selects a random subset of elements of a sequence, in one pass, where every element is chosen with a probability proportional to its weight.
+//
+// # Parameters
+//
+//	k int
+//
+// The number of elements to select.
+//
+//	weightSelector func(TSource) float64
+//
+// A function that returns the weight of an element.
+//
+//	rng *rand.Rand
+//
+// The source of randomness.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains k elements of the source sequence chosen at random, or all the elements with a positive weight if there are fewer than k of them.
+//
+// # Remarks
+//
+// The method uses the A-Res algorithm: every element gets the key u^(1/weight), where u is uniform in [0, 1),
+// and the k elements with the largest keys are selected. The source is enumerated once and at most k elements are held in memory.
+// The selected elements are yielded in descending order of their keys.
+// Elements whose weight is not a positive number are never selected. If k is not a positive number, the result is empty.
+// For a given state of rng the selection is deterministic.
+func (source Iterator[TSource]) WeightedSample(k int, weightSelector func(TSource) float64, rng *rand.Rand) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("WeightedSample", true, "O(n log k)", []string{parameter("k", k)}, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
+		if k <= 0 {
+			return
+		}
+		reservoir := make(weightedHeap[TSource], 0)
+		for item := range source {
+			weight := weightSelector(item)
+			if !(weight > 0) {
+				continue
+			}
+			key := math.Pow(rng.Float64(), 1/weight)
+			if len(reservoir) < k {
+				heap.Push(&reservoir, weightedItem[TSource]{item: item, key: key})
+				continue
+			}
+			if key > reservoir[0].key {
+				reservoir[0] = weightedItem[TSource]{item: item, key: key}
+				heap.Fix(&reservoir, 0)
+			}
+		}
+		items := make([]TSource, len(reservoir))
+		for i := len(items) - 1; i >= 0; i-- {
+			items[i] = heap.Pop(&reservoir).(weightedItem[TSource]).item
+		}
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	})
+}
+
+// Returns a random element of a sequence, in one pass.
+//
+// # Parameters
+//
+//	rng *rand.Rand
+//
+// The source of randomness.
+//
+// # Returns
+//
+//	result TSource
+//
+// An element of the source sequence chosen uniformly at random.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrSourceContainsNoElements - When the source contains no elements.
+//
+// # Remarks
+//
+// For a given state of rng the choice is deterministic.
+func (source Iterator[TSource]) RandomElement(rng *rand.Rand) (result TSource, err error) {
+	seen := 0
+	for item := range source {
+		seen++
+		if rng.IntN(seen) == 0 {
+			result = item
+		}
+	}
+	if seen == 0 {
+		return result, ErrSourceContainsNoElements
+	}
+	return result, nil
+}
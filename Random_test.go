@@ -0,0 +1,130 @@
+package linq
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func newRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+func TestIterator_Shuffle(t *testing.T) {
+	source := Range(0, 20).ToSlice()
+	got := FromSlice(source).Shuffle(newRand(1)).ToSlice()
+	if again := FromSlice(source).Shuffle(newRand(1)).ToSlice(); !reflect.DeepEqual(got, again) {
+		t.Errorf("Iterator.Shuffle() with the same seed = %v, want %v", again, got)
+	}
+	if reflect.DeepEqual(got, source) {
+		t.Errorf("Iterator.Shuffle() = %v, want a different order", got)
+	}
+	sorted := slices.Clone(got)
+	slices.Sort(sorted)
+	if !reflect.DeepEqual(sorted, source) {
+		t.Errorf("Iterator.Shuffle() = %v, want a permutation of %v", got, source)
+	}
+	if got := FromSlice([]int{}).Shuffle(newRand(1)).ToSlice(); len(got) != 0 {
+		t.Errorf("Iterator.Shuffle() of empty source = %v, want []", got)
+	}
+}
+
+func TestIterator_Sample(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  []int
+		k       int
+		wantLen int
+	}{
+		{
+			name:    "Sample empty source",
+			source:  []int{},
+			k:       3,
+			wantLen: 0,
+		},
+		{
+			name:    "Sample k is not positive",
+			source:  []int{1, 2, 3},
+			k:       0,
+			wantLen: 0,
+		},
+		{
+			name:    "Sample source shorter than k",
+			source:  []int{1, 2},
+			k:       3,
+			wantLen: 2,
+		},
+		{
+			name:    "Sample source",
+			source:  Range(0, 100).ToSlice(),
+			k:       10,
+			wantLen: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromSlice(tt.source).Sample(tt.k, newRand(7)).ToSlice()
+			if again := FromSlice(tt.source).Sample(tt.k, newRand(7)).ToSlice(); !reflect.DeepEqual(got, again) {
+				t.Errorf("Iterator.Sample() with the same seed = %v, want %v", again, got)
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("Iterator.Sample() = %v, want %d elements", got, tt.wantLen)
+			}
+			if !FromSlice(tt.source).ContainsAll(got) || FromSlice(got).Distinct().Count() != len(got) {
+				t.Errorf("Iterator.Sample() = %v, want distinct elements of %v", got, tt.source)
+			}
+		})
+	}
+}
+
+func TestIterator_WeightedSample(t *testing.T) {
+	source := Range(0, 100).ToSlice()
+	weight := func(item int) float64 {
+		if item%2 == 1 {
+			return 0
+		}
+		return float64(item)
+	}
+	got := FromSlice(source).WeightedSample(5, weight, newRand(3)).ToSlice()
+	if again := FromSlice(source).WeightedSample(5, weight, newRand(3)).ToSlice(); !reflect.DeepEqual(got, again) {
+		t.Errorf("Iterator.WeightedSample() with the same seed = %v, want %v", again, got)
+	}
+	if len(got) != 5 {
+		t.Errorf("Iterator.WeightedSample() = %v, want 5 elements", got)
+	}
+	if !FromSlice(got).All(func(item int) bool { return weight(item) > 0 }) {
+		t.Errorf("Iterator.WeightedSample() = %v, want only elements with a positive weight", got)
+	}
+	if got := FromSlice([]int{1, 2, 3, 4}).WeightedSample(5, weight, newRand(3)).Order().ToSlice(); !reflect.DeepEqual(got, []int{2, 4}) {
+		t.Errorf("Iterator.WeightedSample() = %v, want [2 4]", got)
+	}
+}
+
+func TestIterator_Sample_hugeK(t *testing.T) {
+	if got := FromSlice([]int{3, 1, 2}).Sample(math.MaxInt, newRand(1)).Order().ToSlice(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Iterator.Sample(math.MaxInt) = %v, want [1 2 3]", got)
+	}
+	weight := func(int) float64 { return 1 }
+	if got := FromSlice([]int{3, 1, 2}).WeightedSample(math.MaxInt, weight, newRand(1)).Order().ToSlice(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Iterator.WeightedSample(math.MaxInt) = %v, want [1 2 3]", got)
+	}
+}
+
+func TestIterator_RandomElement(t *testing.T) {
+	if _, err := FromSlice([]int{}).RandomElement(newRand(1)); !errors.Is(err, ErrSourceContainsNoElements) {
+		t.Errorf("Iterator.RandomElement() error = %v, want %v", err, ErrSourceContainsNoElements)
+	}
+	got, err := FromSlice([]int{1, 2, 3, 4, 5}).RandomElement(newRand(5))
+	if err != nil {
+		t.Fatalf("Iterator.RandomElement() error = %v", err)
+	}
+	if again, _ := FromSlice([]int{1, 2, 3, 4, 5}).RandomElement(newRand(5)); got != again {
+		t.Errorf("Iterator.RandomElement() with the same seed = %v, want %v", again, got)
+	}
+	if got < 1 || got > 5 {
+		t.Errorf("Iterator.RandomElement() = %v, want an element of the source", got)
+	}
+}
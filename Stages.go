@@ -0,0 +1,685 @@
+package linq
+
+import (
+	"math/rand/v2"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+// Returns a Stage that filters a sequence based on a predicate.
+//
+// # Parameters
+//
+//	predicate generic.Predicate[TSource]
+//
+// A function to test each element for a condition.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Where.
+func Filter[TSource any](predicate generic.Predicate[TSource]) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Where(predicate)
+	}
+}
+
+// Returns a Stage that projects each element of a sequence into a new form.
+//
+// # Parameters
+//
+//	valueSelector generic.ValueSelector[TSource, TResult]
+//
+// A transform function to apply to each element.
+//
+// # Returns
+//
+//	result Stage[TSource, TResult]
+//
+// A Stage that applies Select.
+func Map[TSource any, TResult any](valueSelector generic.ValueSelector[TSource, TResult]) (result Stage[TSource, TResult]) {
+	return func(source Iterator[TSource]) Iterator[TResult] {
+		return Select(source, valueSelector)
+	}
+}
+
+// Returns a Stage that projects each element of a sequence to a slice and flattens the slices into one sequence.
+//
+// # Parameters
+//
+//	valueSelector generic.ValueSelector[TSource, []TResult]
+//
+// A transform function to apply to each element.
+//
+// # Returns
+//
+//	result Stage[TSource, TResult]
+//
+// A Stage that applies SelectMany.
+func FlatMap[TSource any, TResult any](valueSelector generic.ValueSelector[TSource, []TResult]) (result Stage[TSource, TResult]) {
+	return func(source Iterator[TSource]) Iterator[TResult] {
+		return SelectMany(source, valueSelector)
+	}
+}
+
+// Returns a Stage that flattens a sequence of sequences.
+//
+// # Returns
+//
+//	result Stage[Iterator[TSource], TSource]
+//
+// A Stage that applies Flatten.
+func Flat[TSource any]() (result Stage[Iterator[TSource], TSource]) {
+	return func(source Iterator[Iterator[TSource]]) Iterator[TSource] {
+		return Flatten(source)
+	}
+}
+
+// Returns a Stage that converts the elements of a sequence to a type with a type assertion.
+//
+// # Returns
+//
+//	result Stage[TSource, TResult]
+//
+// A Stage that applies Cast.
+func Convert[TSource any, TResult any]() (result Stage[TSource, TResult]) {
+	return func(source Iterator[TSource]) Iterator[TResult] {
+		return Cast[TSource, TResult](source)
+	}
+}
+
+// Returns a Stage that sorts the elements of a sequence in ascending order.
+//
+// # Parameters
+//
+//	compare ...generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Order.
+func Sort[TSource any](compare ...generic.Comparison[TSource]) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Order(compare...)
+	}
+}
+
+// Returns a Stage that sorts the elements of a sequence in descending order.
+//
+// # Parameters
+//
+//	compare ...generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.OrderDescending.
+func SortDescending[TSource any](compare ...generic.Comparison[TSource]) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.OrderDescending(compare...)
+	}
+}
+
+// Returns a Stage that sorts the elements of a sequence in ascending order of a key.
+//
+// # Parameters
+//
+//	valueSelector generic.ValueSelector[TSource, TValue]
+//
+// A function to extract the key from an element.
+//
+//	compare ...generic.Comparison[TValue]
+//
+// A function to compare keys. [OPTIONAL]
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies OrderBy.
+func SortBy[TSource any, TValue generic.Comparable](valueSelector generic.ValueSelector[TSource, TValue], compare ...generic.Comparison[TValue]) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return OrderBy(source, valueSelector, compare...)
+	}
+}
+
+// Returns a Stage that sorts the elements of a sequence in descending order of a key.
+//
+// # Parameters
+//
+//	valueSelector generic.ValueSelector[TSource, TValue]
+//
+// A function to extract the key from an element.
+//
+//	compare ...generic.Comparison[TValue]
+//
+// A function to compare keys. [OPTIONAL]
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies OrderByDescending.
+func SortByDescending[TSource any, TValue generic.Comparable](valueSelector generic.ValueSelector[TSource, TValue], compare ...generic.Comparison[TValue]) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return OrderByDescending(source, valueSelector, compare...)
+	}
+}
+
+// Returns a Stage that selects the k greatest elements of a sequence, greatest first.
+//
+// # Parameters
+//
+//	k int
+//
+// The number of elements to select.
+//
+//	compare ...generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies TopK.
+func Top[TSource any](k int, compare ...generic.Comparison[TSource]) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return TopK(source, k, compare...)
+	}
+}
+
+// Returns a Stage that selects the k least elements of a sequence, least first.
+//
+// # Parameters
+//
+//	k int
+//
+// The number of elements to select.
+//
+//	compare ...generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies BottomK.
+func Bottom[TSource any](k int, compare ...generic.Comparison[TSource]) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return BottomK(source, k, compare...)
+	}
+}
+
+// Returns a Stage that groups the elements of a sequence by a key.
+//
+// # Parameters
+//
+//	keySelector generic.KeySelector[TSource, TKey]
+//
+// A function to extract the key from an element.
+//
+//	options SpillOptions[TSource]
+//
+// The memory budget beyond which the elements are spilled to disk. [OPTIONAL]
+//
+// # Returns
+//
+//	result Stage[TSource, generic.KeyValuePair[TKey, Iterator[TSource]]]
+//
+// A Stage that applies GroupBy.
+func Group[TSource any, TKey comparable](keySelector generic.KeySelector[TSource, TKey], options ...SpillOptions[TSource]) (result Stage[TSource, generic.KeyValuePair[TKey, Iterator[TSource]]]) {
+	return func(source Iterator[TSource]) Iterator[generic.KeyValuePair[TKey, Iterator[TSource]]] {
+		return GroupBy(source, keySelector, options...)
+	}
+}
+
+// Returns a Stage that correlates the elements of a sequence with the elements of another one by matching keys.
+//
+// # Parameters
+//
+//	inner Iterator[TInner]
+//
+// The sequence to join to the input.
+//
+//	outerKeySelector generic.ValueSelector[TOuter, TKey]
+//
+// A function to extract the join key from an element of the input.
+//
+//	innerKeySelector generic.ValueSelector[TInner, TKey]
+//
+// A function to extract the join key from an element of inner.
+//
+//	resultSelector func(outer TOuter, inner TInner) TResult
+//
+// A function to create a result element from two matching elements.
+//
+//	comparer ...generic.Equality[TKey]
+//
+// An Equality function to compare keys. [OPTIONAL]
+//
+// # Returns
+//
+//	result Stage[TOuter, TResult]
+//
+// A Stage that applies Join.
+func JoinWith[TOuter any, TInner any, TKey any, TResult any](inner Iterator[TInner], outerKeySelector generic.ValueSelector[TOuter, TKey], innerKeySelector generic.ValueSelector[TInner, TKey], resultSelector func(outer TOuter, inner TInner) TResult, comparer ...generic.Equality[TKey]) (result Stage[TOuter, TResult]) {
+	return func(source Iterator[TOuter]) Iterator[TResult] {
+		return Join(source, inner, outerKeySelector, innerKeySelector, resultSelector, comparer...)
+	}
+}
+
+// Returns a Stage that pairs the elements of a sequence with the elements of another one.
+//
+// # Parameters
+//
+//	sequence Iterator[TSecond]
+//
+// The sequence to pair the input with.
+//
+// # Returns
+//
+//	result Stage[TFirst, generic.ValuePair[TFirst, TSecond]]
+//
+// A Stage that applies Zip.
+func PairWith[TFirst any, TSecond any](sequence Iterator[TSecond]) (result Stage[TFirst, generic.ValuePair[TFirst, TSecond]]) {
+	return func(source Iterator[TFirst]) Iterator[generic.ValuePair[TFirst, TSecond]] {
+		return Zip(source, sequence)
+	}
+}
+
+// Returns a Stage that combines the elements of a sequence with the elements of another one.
+//
+// # Parameters
+//
+//	sequence Iterator[TSecond]
+//
+// The sequence to combine the input with.
+//
+//	resultSelector func(first TFirst, second TSecond) TResult
+//
+// A function to create a result element from two elements at the same position.
+//
+// # Returns
+//
+//	result Stage[TFirst, TResult]
+//
+// A Stage that applies ZipWith.
+func CombineWith[TFirst any, TSecond any, TResult any](sequence Iterator[TSecond], resultSelector func(first TFirst, second TSecond) TResult) (result Stage[TFirst, TResult]) {
+	return func(source Iterator[TFirst]) Iterator[TResult] {
+		return ZipWith(source, sequence, resultSelector)
+	}
+}
+
+// Returns a Stage that splits a sequence into chunks of at most size elements.
+//
+// # Parameters
+//
+//	size int
+//
+// The greatest number of elements in a chunk. It must be at least 1.
+//
+// # Returns
+//
+//	result Stage[TSource, []TSource]
+//
+// A Stage that applies Chunk.
+func Batch[TSource any](size int) (result Stage[TSource, []TSource]) {
+	return func(source Iterator[TSource]) Iterator[[]TSource] {
+		return Chunk(source, size)
+	}
+}
+
+// Returns a Stage that takes a number of elements from the start of a sequence.
+//
+// # Parameters
+//
+//	count int
+//
+// The number of elements.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Take.
+func Limit[TSource any](count int) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Take(count)
+	}
+}
+
+// Returns a Stage that bypasses a number of elements at the start of a sequence.
+//
+// # Parameters
+//
+//	count int
+//
+// The number of elements.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Skip.
+func Drop[TSource any](count int) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Skip(count)
+	}
+}
+
+// Returns a Stage that takes a number of elements from the end of a sequence.
+//
+// # Parameters
+//
+//	count int
+//
+// The number of elements.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.TakeLast.
+func LimitLast[TSource any](count int) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.TakeLast(count)
+	}
+}
+
+// Returns a Stage that omits a number of elements at the end of a sequence.
+//
+// # Parameters
+//
+//	count int
+//
+// The number of elements.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.SkipLast.
+func DropLast[TSource any](count int) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.SkipLast(count)
+	}
+}
+
+// Returns a Stage that takes the leading elements of a sequence, as selected by a predicate.
+//
+// # Parameters
+//
+//	predicate generic.Predicate[TSource]
+//
+// A function to test each element for a condition.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.TakeWhile.
+func LimitWhile[TSource any](predicate generic.Predicate[TSource]) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.TakeWhile(predicate)
+	}
+}
+
+// Returns a Stage that bypasses the leading elements of a sequence, as selected by a predicate.
+//
+// # Parameters
+//
+//	predicate generic.Predicate[TSource]
+//
+// A function to test each element for a condition.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.SkipWhile.
+func DropWhile[TSource any](predicate generic.Predicate[TSource]) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.SkipWhile(predicate)
+	}
+}
+
+// Returns a Stage that removes repeated elements from a sequence.
+//
+// # Parameters
+//
+//	comparer ...generic.Equality[TSource]
+//
+// An Equality function to compare values. [OPTIONAL]
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Distinct.
+func Unique[TSource any](comparer ...generic.Equality[TSource]) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Distinct(comparer...)
+	}
+}
+
+// Returns a Stage that inverts the order of the elements of a sequence.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Reverse.
+func Reversed[TSource any]() (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Reverse()
+	}
+}
+
+// Returns a Stage that appends another sequence to a sequence.
+//
+// # Parameters
+//
+//	sequence Iterator[TSource]
+//
+// The other sequence.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Concat.
+func ConcatWith[TSource any](sequence Iterator[TSource]) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Concat(sequence)
+	}
+}
+
+// Returns a Stage that adds elements to the end of a sequence.
+//
+// # Parameters
+//
+//	elements ...TSource
+//
+// The elements to add.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Append.
+func Appended[TSource any](elements ...TSource) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Append(elements...)
+	}
+}
+
+// Returns a Stage that adds elements to the start of a sequence.
+//
+// # Parameters
+//
+//	elements ...TSource
+//
+// The elements to add.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Prepend.
+func Prepended[TSource any](elements ...TSource) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Prepend(elements...)
+	}
+}
+
+// Returns a Stage that produces the set difference of a sequence and another one.
+//
+// # Parameters
+//
+//	sequence Iterator[TSource]
+//
+// The other sequence.
+//
+//	comparer ...generic.Equality[TSource]
+//
+// An Equality function to compare values. [OPTIONAL]
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Except.
+func Without[TSource any](sequence Iterator[TSource], comparer ...generic.Equality[TSource]) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Except(sequence, comparer...)
+	}
+}
+
+// Returns a Stage that produces the set intersection of a sequence and another one.
+//
+// # Parameters
+//
+//	sequence Iterator[TSource]
+//
+// The other sequence.
+//
+//	comparer ...generic.Equality[TSource]
+//
+// An Equality function to compare values. [OPTIONAL]
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Intersect.
+func CommonWith[TSource any](sequence Iterator[TSource], comparer ...generic.Equality[TSource]) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Intersect(sequence, comparer...)
+	}
+}
+
+// Returns a Stage that produces the set union of a sequence and another one.
+//
+// # Parameters
+//
+//	sequence Iterator[TSource]
+//
+// The other sequence.
+//
+//	comparer ...generic.Equality[TSource]
+//
+// An Equality function to compare values. [OPTIONAL]
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Union.
+func UnionWith[TSource any](sequence Iterator[TSource], comparer ...generic.Equality[TSource]) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Union(sequence, comparer...)
+	}
+}
+
+// Returns a Stage that calls an action for each element of a sequence as it passes through.
+//
+// # Parameters
+//
+//	action func(TSource)
+//
+// The action to call.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Tap.
+func Inspect[TSource any](action func(TSource)) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Tap(action)
+	}
+}
+
+// Returns a Stage that enumerates a sequence at most once and replays its elements.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Memoize.
+func Cached[TSource any]() (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Memoize()
+	}
+}
+
+// Returns a Stage that randomizes the order of the elements of a sequence.
+//
+// # Parameters
+//
+//	rng *rand.Rand
+//
+// The source of randomness.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Shuffle.
+func Shuffled[TSource any](rng *rand.Rand) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Shuffle(rng)
+	}
+}
+
+// Returns a Stage that selects a random subset of the elements of a sequence.
+//
+// # Parameters
+//
+//	k int
+//
+// The number of elements to select.
+//
+//	rng *rand.Rand
+//
+// The source of randomness.
+//
+// # Returns
+//
+//	result Stage[TSource, TSource]
+//
+// A Stage that applies Iterator.Sample.
+func Sampled[TSource any](k int, rng *rand.Rand) (result Stage[TSource, TSource]) {
+	return func(source Iterator[TSource]) Iterator[TSource] {
+		return source.Sample(k, rng)
+	}
+}
@@ -0,0 +1,145 @@
+package linq
+
+import (
+	"sync"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+// Splits a sequence into n sequences that share a single enumeration of the source.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to split.
+//
+//	n int
+//
+// The number of sequences to return.
+//
+//	capacity ...int
+//
+// Optional. The most elements the buffer holds. If omitted, the buffer has no capacity limit.
+//
+// # Returns
+//
+//	result []Iterator[TSource]
+//
+// The n sequences. Each of them contains all the elements of the source sequence.
+//
+// # Remarks
+//
+// Tee is the package-level form of Iterator.Publish, and returns the same sequences: see Iterator.Publish for how
+// elements are buffered, when a sequence waits for the others and what happens to a sequence that stops early.
+func Tee[TSource any](source Iterator[TSource], n int, capacity ...int) (result []Iterator[TSource]) {
+	return source.Publish(n, capacity...)
+}
+
+type partition[TSource any] struct {
+	mutex     sync.Mutex
+	source    *puller[TSource]
+	predicate generic.Predicate[TSource]
+	queues    [2][]TSource
+	active    [2]bool
+}
+
+// Returns the next element for the branch that receives elements for which the predicate returns matched.
+func (p *partition[TSource]) pull(matched bool) (value TSource, ok bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	this, other := 1, 0
+	if matched {
+		this, other = 0, 1
+	}
+	if !p.active[this] {
+		return value, false
+	}
+	if len(p.queues[this]) > 0 {
+		value = p.queues[this][0]
+		p.queues[this][0] = *new(TSource)
+		p.queues[this] = p.queues[this][1:]
+		return value, true
+	}
+	for {
+		value, ok = p.source.pull()
+		if !ok {
+			return value, false
+		}
+		if p.predicate(value) == matched {
+			return value, true
+		}
+		if p.active[other] {
+			p.queues[other] = append(p.queues[other], value)
+		}
+	}
+}
+
+func (p *partition[TSource]) detach(matched bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	this, other := 1, 0
+	if matched {
+		this, other = 0, 1
+	}
+	p.active[this] = false
+	p.queues[this] = nil
+	if !p.active[other] {
+		p.source.close()
+	}
+}
+
+func (p *partition[TSource]) branch(matched bool) Iterator[TSource] {
+	return func(yield func(value TSource) bool) {
+		for {
+			value, ok := p.pull(matched)
+			if !ok {
+				return
+			}
+			if !yield(value) {
+				p.detach(matched)
+				return
+			}
+		}
+	}
+}
+
+// Splits a sequence into the elements that satisfy a condition and the elements that do not, with a single enumeration of the source.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to split.
+//
+//	predicate generic.Predicate[TSource]
+//
+// A function to test each element for a condition. It is called once per element.
+//
+// # Returns
+//
+//	matched Iterator[TSource]
+//
+// The elements that satisfy the condition, in source order.
+//
+//	unmatched Iterator[TSource]
+//
+// The elements that do not satisfy the condition, in source order.
+//
+// # Remarks
+//
+// The source is enumerated at most once. When one of the sequences pulls elements that belong to the other one,
+// those elements are buffered until the other sequence receives them.
+//
+// A sequence that stops early is detached: it yields nothing when enumerated again and the elements that belong to it are no longer buffered.
+// The other sequence is not affected. When both sequences have finished or stopped, the source is released.
+//
+// Each returned sequence can be enumerated only once. They may be enumerated one after another or from different goroutines.
+func Partition[TSource any](source Iterator[TSource], predicate generic.Predicate[TSource]) (matched Iterator[TSource], unmatched Iterator[TSource]) {
+	p := &partition[TSource]{
+		source:    newPuller(source),
+		predicate: predicate,
+		active:    [2]bool{true, true},
+	}
+	return p.branch(true), p.branch(false)
+}
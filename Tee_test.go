@@ -0,0 +1,142 @@
+package linq
+
+import (
+	"iter"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTee(t *testing.T) {
+	t.Run("Tee branches enumerated one after another", func(t *testing.T) {
+		branches := Tee(oneShot(1, 2, 3), 2)
+		if got, want := branches[0].ToSlice(), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Tee() branch 0 = %v, want %v", got, want)
+		}
+		if got, want := branches[1].ToSlice(), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Tee() branch 1 = %v, want %v", got, want)
+		}
+	})
+	t.Run("Tee branches enumerated side by side", func(t *testing.T) {
+		branches := Tee(oneShot(1, 2, 3), 2)
+		got := ZipWith(branches[0], branches[1].Skip(1), func(x, y int) int {
+			return x*10 + y
+		}).ToSlice()
+		if want := []int{12, 23}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Tee() = %v, want %v", got, want)
+		}
+	})
+	t.Run("Tee branch stops early", func(t *testing.T) {
+		branches := Tee(oneShot(1, 2, 3, 4), 2)
+		if got, want := branches[0].Take(2).ToSlice(), []int{1, 2}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Tee() branch 0 = %v, want %v", got, want)
+		}
+		if got, want := branches[0].ToSlice(), []int{}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Tee() branch 0 after early stop = %v, want %v", got, want)
+		}
+		if got, want := branches[1].ToSlice(), []int{1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Tee() branch 1 = %v, want %v", got, want)
+		}
+	})
+	t.Run("Tee buffers only for the slowest active branch", func(t *testing.T) {
+		shared := newBroadcast(oneShot(1, 2, 3, 4, 5), 2, 0)
+		branches := shared.branches()
+		branches[0].Take(1).ToSlice()
+		branches[1].Take(4).ToSlice()
+		if len(shared.buffer) != 0 {
+			t.Errorf("Tee() buffered %v after every branch stopped, want nothing", shared.buffer)
+		}
+		shared = newBroadcast(oneShot(1, 2, 3, 4, 5), 2, 0)
+		next0, stop0 := iter.Pull(iter.Seq[int](shared.branch(0)))
+		defer stop0()
+		next1, stop1 := iter.Pull(iter.Seq[int](shared.branch(1)))
+		defer stop1()
+		for range 3 {
+			next0()
+		}
+		next1()
+		if got, want := shared.buffer, []int{2, 3}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Tee() buffered %v, want %v", got, want)
+		}
+		stop1()
+		if got, want := shared.buffer, []int{}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Tee() buffered %v after a branch stopped, want %v", got, want)
+		}
+	})
+	t.Run("Tee with a capacity makes the fastest branch wait for the slowest", func(t *testing.T) {
+		const capacity = 3
+		shared := newBroadcast(oneShot(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), 2, capacity)
+		buffered := func() int {
+			shared.mutex.Lock()
+			defer shared.mutex.Unlock()
+			return len(shared.buffer)
+		}
+		fast := make(chan []int)
+		go func() {
+			fast <- shared.branch(0).ToSlice()
+		}()
+		next, stop := iter.Pull(iter.Seq[int](shared.branch(1)))
+		defer stop()
+		slow := []int{}
+		for i := range 10 {
+			want := min(capacity, 10-i)
+			for deadline := time.Now().Add(time.Second); buffered() < want && time.Now().Before(deadline); {
+				time.Sleep(time.Millisecond)
+			}
+			if got := buffered(); got != want {
+				t.Fatalf("Tee() buffered %d elements after the slow branch received %d, want %d", got, i, want)
+			}
+			value, _ := next()
+			slow = append(slow, value)
+		}
+		want := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+		if got := <-fast; !reflect.DeepEqual(got, want) {
+			t.Errorf("Tee() fast branch = %v, want %v", got, want)
+		}
+		if !reflect.DeepEqual(slow, want) {
+			t.Errorf("Tee() slow branch = %v, want %v", slow, want)
+		}
+	})
+	t.Run("Tee with a capacity below 1", func(t *testing.T) {
+		defer func() {
+			if got := recover(); got != ErrSizeIsBelowOne {
+				t.Errorf("Tee() panicked with %v, want %v", got, ErrSizeIsBelowOne)
+			}
+		}()
+		Tee(oneShot(1, 2, 3), 2, 0)
+	})
+}
+
+func TestPartition(t *testing.T) {
+	even := func(item int) bool {
+		return item%2 == 0
+	}
+	t.Run("Partition", func(t *testing.T) {
+		calls := 0
+		matched, unmatched := Partition(oneShot(1, 2, 3, 4, 5, 6, 7), func(item int) bool {
+			calls++
+			return even(item)
+		})
+		if got, want := unmatched.ToSlice(), []int{1, 3, 5, 7}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Partition() unmatched = %v, want %v", got, want)
+		}
+		if got, want := matched.ToSlice(), []int{2, 4, 6}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Partition() matched = %v, want %v", got, want)
+		}
+		if calls != 7 {
+			t.Errorf("Partition() called predicate %d times, want 7", calls)
+		}
+	})
+	t.Run("Partition branch stops early", func(t *testing.T) {
+		matched, unmatched := Partition(oneShot(1, 2, 3, 4, 5, 6, 7), even)
+		if got, want := matched.Take(1).ToSlice(), []int{2}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Partition() matched = %v, want %v", got, want)
+		}
+		if got, want := matched.ToSlice(), []int{}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Partition() matched after early stop = %v, want %v", got, want)
+		}
+		if got, want := unmatched.ToSlice(), []int{1, 3, 5, 7}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Partition() unmatched = %v, want %v", got, want)
+		}
+	})
+}
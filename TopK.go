@@ -0,0 +1,591 @@
+package linq
+
+import (
+	"container/heap"
+	"slices"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+type boundedItem[TSource any] struct {
+	value TSource
+	index int
+}
+
+// A max-heap of the smallest elements offered so far. Elements that compare equal are ordered by their index in the source,
+// so the heap keeps the earliest of them and sorted returns them in source order.
+type boundedHeap[TSource any] struct {
+	compare generic.Comparison[TSource]
+	items   []boundedItem[TSource]
+}
+
+func (h *boundedHeap[TSource]) less(x, y boundedItem[TSource]) bool {
+	if c := h.compare(x.value, y.value); c != 0 {
+		return c < 0
+	}
+	return x.index < y.index
+}
+
+func (h *boundedHeap[TSource]) Len() int { return len(h.items) }
+
+func (h *boundedHeap[TSource]) Less(i, j int) bool { return h.less(h.items[j], h.items[i]) }
+
+func (h *boundedHeap[TSource]) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *boundedHeap[TSource]) Push(x any) { h.items = append(h.items, x.(boundedItem[TSource])) }
+
+func (h *boundedHeap[TSource]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// Adds an element if fewer than k elements are kept or it is smaller than the largest of them.
+func (h *boundedHeap[TSource]) offer(value TSource, index int, k int) {
+	item := boundedItem[TSource]{
+		value: value,
+		index: index,
+	}
+	if len(h.items) < k {
+		heap.Push(h, item)
+		return
+	}
+	if h.less(item, h.items[0]) {
+		h.items[0] = item
+		heap.Fix(h, 0)
+	}
+}
+
+// Returns the kept elements in ascending order.
+func (h *boundedHeap[TSource]) sorted() []TSource {
+	slices.SortFunc(h.items, func(x, y boundedItem[TSource]) int {
+		if h.less(x, y) {
+			return -1
+		}
+		return 1
+	})
+	result := make([]TSource, len(h.items))
+	for i, item := range h.items {
+		result[i] = item.value
+	}
+	return result
+}
+
+// Returns the k smallest elements of source in ascending order; equal elements keep their source order and the earliest of them are kept.
+func bottomK[TSource any](source Iterator[TSource], k int, compare generic.Comparison[TSource]) Iterator[TSource] {
+	return func(yield func(value TSource) bool) {
+		if k <= 0 {
+			return
+		}
+		kept := &boundedHeap[TSource]{
+			compare: compare,
+		}
+		index := 0
+		for item := range source {
+			kept.offer(item, index, k)
+			index++
+		}
+		for _, item := range kept.sorted() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+func bottomKBy[TSource any, TValue any](source Iterator[TSource], k int, valueSelector generic.ValueSelector[TSource, TValue], compare generic.Comparison[TValue]) Iterator[TSource] {
+	pairs := Select(source, func(item TSource) generic.ValuePair[TSource, TValue] {
+		return generic.ValuePair[TSource, TValue]{
+			Item1: item,
+			Item2: valueSelector(item),
+		}
+	})
+	return Select(bottomK(pairs, k, func(x, y generic.ValuePair[TSource, TValue]) int {
+		return compare(x.Item2, y.Item2)
+	}), func(pair generic.ValuePair[TSource, TValue]) TSource {
+		return pair.Item1
+	})
+}
+
+func descending[TSource any](compare generic.Comparison[TSource]) generic.Comparison[TSource] {
+	return func(x, y TSource) int {
+		return compare(y, x)
+	}
+}
+
+// Returns the k largest elements of a sequence.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to take the elements from.
+//
+//	k int
+//
+// The number of elements to return.
+//
+//	compare generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// The k largest elements in descending order, or all the elements if the sequence has fewer than k.
+// Elements that compare equal are yielded in source order, and when only some of them fit, the earliest are kept.
+// The result is the same as that of a stable descending sort followed by Take(k).
+//
+// # Panics
+//
+// With linq.ErrTypeIsNotOrdered, when TopK is called, if compare is omitted and TSource has no natural order (see DefaultComparison).
+// TopK is a convenience for types that are known to be ordered: call TryTopK to have the error returned instead.
+//
+// # Remarks
+//
+// The source is enumerated once when the result is enumerated; only k elements are held in a bounded heap,
+// which takes O(n log k) time and O(k) memory. If k is not a positive number, the result is empty and the source is not enumerated.
+func TopK[TSource any](source Iterator[TSource], k int, compare ...generic.Comparison[TSource]) (result Iterator[TSource]) {
+	return must(TryTopK(source, k, compare...))
+}
+
+// Returns the k largest elements of a sequence, or reports that they cannot be ordered.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to take the elements from.
+//
+//	k int
+//
+// The number of elements to return.
+//
+//	compare generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// The k largest elements, as by TopK; nil if err is not nil.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrTypeIsNotOrdered - When compare is omitted and TSource has no natural order (see DefaultComparison).
+func TryTopK[TSource any](source Iterator[TSource], k int, compare ...generic.Comparison[TSource]) (result Iterator[TSource], err error) {
+	Compare, err := resolveComparison(compare...)
+	if err != nil {
+		return nil, err
+	}
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("TopK", true, "O(n log k)", []string{parameter("k", k)}, askPlan(source))
+		},
+	}, bottomK(source, k, descending(Compare))), nil
+}
+
+// Returns the k smallest elements of a sequence.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to take the elements from.
+//
+//	k int
+//
+// The number of elements to return.
+//
+//	compare generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// The k smallest elements in ascending order, or all the elements if the sequence has fewer than k.
+// Elements that compare equal are yielded in source order, and when only some of them fit, the earliest are kept.
+// The result is the same as that of a stable ascending sort followed by Take(k).
+//
+// # Panics
+//
+// See TopK. Call TryBottomK to have the error returned instead.
+//
+// # Remarks
+//
+// See TopK.
+func BottomK[TSource any](source Iterator[TSource], k int, compare ...generic.Comparison[TSource]) (result Iterator[TSource]) {
+	return must(TryBottomK(source, k, compare...))
+}
+
+// Returns the k smallest elements of a sequence, or reports that they cannot be ordered.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to take the elements from.
+//
+//	k int
+//
+// The number of elements to return.
+//
+//	compare generic.Comparison[TSource]
+//
+// A function to compare elements. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// The k smallest elements, as by BottomK; nil if err is not nil.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrTypeIsNotOrdered - When compare is omitted and TSource has no natural order (see DefaultComparison).
+func TryBottomK[TSource any](source Iterator[TSource], k int, compare ...generic.Comparison[TSource]) (result Iterator[TSource], err error) {
+	Compare, err := resolveComparison(compare...)
+	if err != nil {
+		return nil, err
+	}
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("BottomK", true, "O(n log k)", []string{parameter("k", k)}, askPlan(source))
+		},
+	}, bottomK(source, k, Compare)), nil
+}
+
+// Returns the k elements of a sequence with the largest values.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to take the elements from.
+//
+//	k int
+//
+// The number of elements to return.
+//
+//	valueSelector generic.ValueSelector[TSource, TValue]
+//
+// A function to extract the value to compare from an element. It is called once per element.
+//
+//	compare generic.Comparison[TValue]
+//
+// A function to compare values. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// The k elements with the largest values, in descending order of value. Ties are handled as by TopK.
+//
+// # Panics
+//
+// With linq.ErrTypeIsNotOrdered, when TopKBy is called, if compare is omitted and TValue has no natural order.
+// Call TryTopKBy to have the error returned instead.
+//
+// # Example
+//
+//	oldest := linq.TopKBy(people, 3, func(person Person) int {
+//		return person.Age
+//	})
+func TopKBy[TSource any, TValue any](source Iterator[TSource], k int, valueSelector generic.ValueSelector[TSource, TValue], compare ...generic.Comparison[TValue]) (result Iterator[TSource]) {
+	return must(TryTopKBy(source, k, valueSelector, compare...))
+}
+
+// Returns the k elements of a sequence with the largest values, or reports that the values cannot be ordered.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to take the elements from.
+//
+//	k int
+//
+// The number of elements to return.
+//
+//	valueSelector generic.ValueSelector[TSource, TValue]
+//
+// A function to extract the value to compare from an element. It is called once per element.
+//
+//	compare generic.Comparison[TValue]
+//
+// A function to compare values. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// The k elements with the largest values, as by TopKBy; nil if err is not nil.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrTypeIsNotOrdered - When compare is omitted and TValue has no natural order (see DefaultComparison).
+func TryTopKBy[TSource any, TValue any](source Iterator[TSource], k int, valueSelector generic.ValueSelector[TSource, TValue], compare ...generic.Comparison[TValue]) (result Iterator[TSource], err error) {
+	Compare, err := resolveComparison(compare...)
+	if err != nil {
+		return nil, err
+	}
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("TopKBy", true, "O(n log k)", []string{parameter("k", k)}, askPlan(source))
+		},
+	}, bottomKBy(source, k, valueSelector, descending(Compare))), nil
+}
+
+// Returns the k elements of a sequence with the smallest values.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to take the elements from.
+//
+//	k int
+//
+// The number of elements to return.
+//
+//	valueSelector generic.ValueSelector[TSource, TValue]
+//
+// A function to extract the value to compare from an element. It is called once per element.
+//
+//	compare generic.Comparison[TValue]
+//
+// A function to compare values. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// The k elements with the smallest values, in ascending order of value. Ties are handled as by BottomK.
+//
+// # Panics
+//
+// With linq.ErrTypeIsNotOrdered, when BottomKBy is called, if compare is omitted and TValue has no natural order.
+// Call TryBottomKBy to have the error returned instead.
+func BottomKBy[TSource any, TValue any](source Iterator[TSource], k int, valueSelector generic.ValueSelector[TSource, TValue], compare ...generic.Comparison[TValue]) (result Iterator[TSource]) {
+	return must(TryBottomKBy(source, k, valueSelector, compare...))
+}
+
+// Returns the k elements of a sequence with the smallest values, or reports that the values cannot be ordered.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// The sequence to take the elements from.
+//
+//	k int
+//
+// The number of elements to return.
+//
+//	valueSelector generic.ValueSelector[TSource, TValue]
+//
+// A function to extract the value to compare from an element. It is called once per element.
+//
+//	compare generic.Comparison[TValue]
+//
+// A function to compare values. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// The k elements with the smallest values, as by BottomKBy; nil if err is not nil.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrTypeIsNotOrdered - When compare is omitted and TValue has no natural order (see DefaultComparison).
+func TryBottomKBy[TSource any, TValue any](source Iterator[TSource], k int, valueSelector generic.ValueSelector[TSource, TValue], compare ...generic.Comparison[TValue]) (result Iterator[TSource], err error) {
+	Compare, err := resolveComparison(compare...)
+	if err != nil {
+		return nil, err
+	}
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("BottomKBy", true, "O(n log k)", []string{parameter("k", k)}, askPlan(source))
+		},
+	}, bottomKBy(source, k, valueSelector, Compare)), nil
+}
+
+// A sequence whose elements are known to be in ascending order.
+//
+// An OrderedIterator is created by AsOrdered. TopK and BottomK on it do not need a heap,
+// and BottomK stops enumerating the source after k elements.
+type OrderedIterator[TSource any] struct {
+	source  Iterator[TSource]
+	compare generic.Comparison[TSource]
+}
+
+// Declares that a sequence is sorted in ascending order.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// A sequence that is sorted according to compare, for example rows read with an ORDER BY clause. The result of Order needs
+// no AsOrdered: Take on it already keeps only the first elements in a bounded heap.
+//
+//	compare generic.Comparison[TSource]
+//
+// The comparison the sequence is sorted by. [OPTIONAL]
+//
+// # Returns
+//
+//	result OrderedIterator[TSource]
+//
+// The sequence, with its order recorded.
+//
+// # Panics
+//
+// With linq.ErrTypeIsNotOrdered, when AsOrdered is called, if compare is omitted and TSource has no natural order.
+// Call TryAsOrdered to have the error returned instead.
+//
+// # Remarks
+//
+// The order is trusted, not checked. The default comparison is the one of Iterator.Order.
+func AsOrdered[TSource any](source Iterator[TSource], compare ...generic.Comparison[TSource]) (result OrderedIterator[TSource]) {
+	return must(TryAsOrdered(source, compare...))
+}
+
+// Declares that a sequence is sorted in ascending order, or reports that it cannot be ordered.
+//
+// # Parameters
+//
+//	source Iterator[TSource]
+//
+// A sequence that is sorted according to compare.
+//
+//	compare generic.Comparison[TSource]
+//
+// The comparison the sequence is sorted by. [OPTIONAL]
+//
+// # Returns
+//
+//	result OrderedIterator[TSource]
+//
+// The sequence, with its order recorded, as by AsOrdered.
+//
+// # Error
+//
+//	err error
+//
+// linq.ErrTypeIsNotOrdered - When compare is omitted and TSource has no natural order (see DefaultComparison).
+func TryAsOrdered[TSource any](source Iterator[TSource], compare ...generic.Comparison[TSource]) (result OrderedIterator[TSource], err error) {
+	Compare, err := resolveComparison(compare...)
+	if err != nil {
+		return result, err
+	}
+	return OrderedIterator[TSource]{
+		source:  source,
+		compare: Compare,
+	}, nil
+}
+
+// Returns the elements of the sequence typed as Iterator[TSource].
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// The sequence, in ascending order.
+func (ordered OrderedIterator[TSource]) Iterator() (result Iterator[TSource]) {
+	return ordered.source
+}
+
+// Returns the k smallest elements of the sequence, with the same result as BottomK.
+//
+// # Parameters
+//
+//	k int
+//
+// The number of elements to return.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// The first k elements of the sequence.
+//
+// # Remarks
+//
+// Runs in O(k) time and memory.
+func (ordered OrderedIterator[TSource]) BottomK(k int) (result Iterator[TSource]) {
+	return ordered.source.Take(k)
+}
+
+// Returns the k largest elements of the sequence, with the same result as TopK.
+//
+// # Parameters
+//
+//	k int
+//
+// The number of elements to return.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// The last k elements of the sequence, in descending order. Elements that compare equal are yielded in source order,
+// and when only some of them fit, the earliest are kept.
+//
+// # Remarks
+//
+// Runs in O(n) time without comparing elements more than once each. It holds the last k elements,
+// plus at most k elements that compare equal to the smallest of them, so at most 2·k elements.
+func (ordered OrderedIterator[TSource]) TopK(k int) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("TopK", true, "O(n)", []string{parameter("k", k), "ordered"}, askPlan(ordered.source))
+		},
+	}, func(yield func(value TSource) bool) {
+		if k <= 0 {
+			return
+		}
+		// The tail of the sequence, split into runs of equal elements. Runs are dropped from the front
+		// as long as the runs after them still hold k elements. Only the earliest elements of a run can be yielded,
+		// so a run holds no more than k of them.
+		var runs [][]TSource
+		kept := 0
+		for item := range ordered.source {
+			if len(runs) > 0 && ordered.compare(runs[len(runs)-1][0], item) == 0 {
+				if len(runs[len(runs)-1]) == k {
+					continue
+				}
+				runs[len(runs)-1] = append(runs[len(runs)-1], item)
+			} else {
+				runs = append(runs, []TSource{item})
+			}
+			kept++
+			for len(runs) > 1 && kept-len(runs[0]) >= k {
+				kept -= len(runs[0])
+				runs[0] = nil
+				runs = runs[1:]
+			}
+		}
+		remaining := k
+		for i := len(runs) - 1; i >= 0; i-- {
+			for _, item := range runs[i] {
+				if remaining == 0 || !yield(item) {
+					return
+				}
+				remaining--
+			}
+		}
+	})
+}
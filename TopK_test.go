@@ -0,0 +1,220 @@
+package linq
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func sortedStable(values []scored, compare func(x, y scored) int) []scored {
+	result := slices.Clone(values)
+	slices.SortStableFunc(result, compare)
+	return result
+}
+
+func TestTopK(t *testing.T) {
+	ascending := sortedStable(scores, byScore)
+	descending := sortedStable(scores, func(x, y scored) int {
+		return byScore(y, x)
+	})
+	tests := []struct {
+		name string
+		got  Iterator[scored]
+		want []scored
+	}{
+		{
+			name: "TopK",
+			got:  TopK(FromSlice(scores), 4, byScore),
+			want: descending[:4],
+		},
+		{
+			name: "BottomK",
+			got:  BottomK(FromSlice(scores), 3, byScore),
+			want: ascending[:3],
+		},
+		{
+			name: "TopK with k greater than the count",
+			got:  TopK(FromSlice(scores), 20, byScore),
+			want: descending,
+		},
+		{
+			name: "TopK with k = 0",
+			got:  TopK(FromSlice(scores), 0, byScore),
+			want: []scored{},
+		},
+		{
+			name: "TopKBy",
+			got: TopKBy(FromSlice(scores), 5, func(item scored) int {
+				return item.Score
+			}),
+			want: descending[:5],
+		},
+		{
+			name: "BottomKBy",
+			got: BottomKBy(oneShot(scores...), 2, func(item scored) int {
+				return item.Score
+			}),
+			want: ascending[:2],
+		},
+		{
+			name: "OrderedIterator.TopK",
+			got:  AsOrdered(FromSlice(ascending), byScore).TopK(4),
+			want: descending[:4],
+		},
+		{
+			name: "OrderedIterator.TopK cutting a run of equal elements",
+			got:  AsOrdered(FromSlice(ascending), byScore).TopK(2),
+			want: descending[:2],
+		},
+		{
+			name: "OrderedIterator.TopK with k greater than the count",
+			got:  AsOrdered(FromSlice(ascending), byScore).TopK(20),
+			want: descending,
+		},
+		{
+			name: "OrderedIterator.BottomK",
+			got:  AsOrdered(FromSlice(ascending), byScore).BottomK(3),
+			want: ascending[:3],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.ToSlice(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderedIterator_TopK_ties(t *testing.T) {
+	type tagged struct{ Value, Position int }
+	byValue := func(x, y tagged) int {
+		return x.Value - y.Value
+	}
+	equal := make([]tagged, 1000)
+	for i := range equal {
+		equal[i] = tagged{Value: 7, Position: i}
+	}
+	if got, want := AsOrdered(FromSlice(equal), byValue).TopK(3).ToSlice(), equal[:3]; !reflect.DeepEqual(got, want) {
+		t.Errorf("OrderedIterator.TopK() over equal elements = %v, want %v", got, want)
+	}
+	mixed := []tagged{{1, 0}, {1, 1}, {1, 2}, {2, 3}, {2, 4}, {2, 5}, {2, 6}, {3, 7}}
+	if got, want := AsOrdered(FromSlice(mixed), byValue).TopK(3).ToSlice(), []tagged{{3, 7}, {2, 3}, {2, 4}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("OrderedIterator.TopK() = %v, want %v", got, want)
+	}
+	if got, want := AsOrdered(FromSlice(mixed[:5]), byValue).TopK(4).ToSlice(), []tagged{{2, 3}, {2, 4}, {1, 0}, {1, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("OrderedIterator.TopK() = %v, want %v", got, want)
+	}
+}
+
+func TestTopK_default(t *testing.T) {
+	if got, want := TopK(FromSlice([]int{4, 9, 1, 7, 3}), 2).ToSlice(), []int{9, 7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TopK() = %v, want %v", got, want)
+	}
+	if got, want := BottomK(FromSlice([]string{"d", "a", "c", "b"}), 3).ToSlice(), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BottomK() = %v, want %v", got, want)
+	}
+}
+
+func TestOrder_Take(t *testing.T) {
+	score := func(item scored) int {
+		return item.Score
+	}
+	ascending := sortedStable(scores, byScore)
+	descending := sortedStable(scores, func(x, y scored) int {
+		return byScore(y, x)
+	})
+	tests := []struct {
+		name string
+		got  Iterator[scored]
+		want []scored
+	}{
+		{
+			name: "Order, Take",
+			got:  FromSlice(scores).Order(byScore).Take(4),
+			want: ascending[:4],
+		},
+		{
+			name: "OrderDescending, Take",
+			got:  FromSlice(scores).OrderDescending(byScore).Take(4),
+			want: descending[:4],
+		},
+		{
+			name: "OrderBy, Take",
+			got:  OrderBy(FromSlice(scores), score).Take(5),
+			want: ascending[:5],
+		},
+		{
+			name: "OrderByDescending, Take",
+			got:  OrderByDescending(FromSlice(scores), score).Take(5),
+			want: descending[:5],
+		},
+		{
+			name: "Order, Take more than the count",
+			got:  FromSlice(scores).Order(byScore).Take(20),
+			want: ascending,
+		},
+		{
+			name: "Order, Take 0",
+			got:  FromSlice(scores).Order(byScore).Take(0),
+			want: []scored{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.ToSlice(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Take() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	t.Run("Package-level Order, Take", func(t *testing.T) {
+		numbers := []int{4, 9, 1, 7, 3}
+		if got, want := Order(FromSlice(numbers)).Take(2).ToSlice(), []int{1, 3}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Take() = %v, want %v", got, want)
+		}
+		if got, want := OrderDescending(FromSlice(numbers)).Take(2).ToSlice(), []int{9, 7}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Take() = %v, want %v", got, want)
+		}
+	})
+	t.Run("Take does not sort the whole source", func(t *testing.T) {
+		numbers := benchmarkNumbers()
+		compared := 0
+		counting := func(x, y int) int {
+			compared++
+			return cmp.Compare(x, y)
+		}
+		got := FromSlice(numbers).Order(counting).Take(1).ToSlice()
+		if want := slices.Min(numbers); !reflect.DeepEqual(got, []int{want}) {
+			t.Errorf("Take() = %v, want [%v]", got, want)
+		}
+		if compared > 2*len(numbers) {
+			t.Errorf("Take() compared %d times, want at most %d", compared, 2*len(numbers))
+		}
+	})
+	t.Run("First", func(t *testing.T) {
+		if got, err := FromSlice(scores).OrderDescending(byScore).First(); err != nil || got != descending[0] {
+			t.Errorf("First() = %v, %v, want %v, nil", got, err, descending[0])
+		}
+		if got := OrderBy(FromSlice(scores), score).FirstOrDefault(); got != ascending[0] {
+			t.Errorf("FirstOrDefault() = %v, want %v", got, ascending[0])
+		}
+		if got := FromSlice([]scored{}).Order(byScore).FirstOrFallback(scores[0]); got != scores[0] {
+			t.Errorf("FirstOrFallback() = %v, want %v", got, scores[0])
+		}
+	})
+}
+
+func BenchmarkTopK(b *testing.B) {
+	numbers := benchmarkNumbers()
+	b.Run("OrderDescending.Take", func(b *testing.B) {
+		for b.Loop() {
+			FromSlice(numbers).OrderDescending().Take(10).ToSlice()
+		}
+	})
+	b.Run("TopK", func(b *testing.B) {
+		for b.Loop() {
+			TopK(FromSlice(numbers), 10).ToSlice()
+		}
+	})
+}
@@ -0,0 +1,431 @@
+package linq
+
+import "github.com/thereisnoplanb/generic"
+
+// Describes a single element visited during a tree or graph traversal.
+type TraversalNode[TSource any] struct {
+
+	// The visited element.
+	Value TSource
+
+	// The distance from the root. The root has depth 0.
+	Depth int
+
+	// The elements on the way from the root to the visited element, both inclusive.
+	Path []TSource
+}
+
+type traversal[TSource any] struct {
+	childrenSelector generic.ValueSelector[TSource, Iterator[TSource]]
+	visit            func(node TSource) bool
+	withPath         bool
+}
+
+func (t traversal[TSource]) depthFirst(node TSource, depth int, path []TSource, yield func(value TraversalNode[TSource]) bool) bool {
+	if t.visit != nil && !t.visit(node) {
+		return true
+	}
+	if t.withPath {
+		path = append(path[:len(path):len(path)], node)
+	}
+	if !yield(TraversalNode[TSource]{
+		Value: node,
+		Depth: depth,
+		Path:  path,
+	}) {
+		return false
+	}
+	children := t.childrenSelector(node)
+	if children == nil {
+		return true
+	}
+	for child := range children {
+		if !t.depthFirst(child, depth+1, path, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t traversal[TSource]) breadthFirst(root TSource, yield func(value TraversalNode[TSource]) bool) {
+	queue := []TraversalNode[TSource]{{Value: root}}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue[0] = TraversalNode[TSource]{}
+		queue = queue[1:]
+		if t.visit != nil && !t.visit(node.Value) {
+			continue
+		}
+		if t.withPath {
+			node.Path = append(node.Path[:len(node.Path):len(node.Path)], node.Value)
+		}
+		if !yield(node) {
+			return
+		}
+		children := t.childrenSelector(node.Value)
+		if children == nil {
+			continue
+		}
+		for child := range children {
+			queue = append(queue, TraversalNode[TSource]{
+				Value: child,
+				Depth: node.Depth + 1,
+				Path:  node.Path,
+			})
+		}
+	}
+}
+
+func visited[TSource any, TKey comparable](keySelector generic.KeySelector[TSource, TKey]) func(node TSource) bool {
+	keys := make(map[TKey]struct{})
+	return func(node TSource) bool {
+		key := keySelector(node)
+		if _, ok := keys[key]; ok {
+			return false
+		}
+		keys[key] = struct{}{}
+		return true
+	}
+}
+
+// Traverses a tree in depth-first pre-order, starting at the root.
+//
+// # Parameters
+//
+//	root TSource
+//
+// The element to start the traversal at.
+//
+//	childrenSelector generic.ValueSelector[TSource, Iterator[TSource]]
+//
+// A function that returns the children of an element. A nil result means that the element has no children.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains the root and all its descendants in depth-first pre-order.
+//
+// # Remarks
+//
+// The traversal is lazy. The children of an element are requested only after the element has been yielded,
+// and each children sequence is enumerated only as far as needed.
+// The traversal does not detect cycles. Use TraverseDepthFirstBy when the structure can contain cycles.
+func TraverseDepthFirst[TSource any](root TSource, childrenSelector generic.ValueSelector[TSource, Iterator[TSource]]) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("TraverseDepthFirst", true, "O(n)", nil)
+		},
+	}, func(yield func(value TSource) bool) {
+		traversal[TSource]{
+			childrenSelector: childrenSelector,
+		}.depthFirst(root, 0, nil, func(node TraversalNode[TSource]) bool {
+			return yield(node.Value)
+		})
+	})
+}
+
+// Traverses a graph in depth-first pre-order, starting at the root, and visits every element at most once.
+//
+// # Parameters
+//
+//	root TSource
+//
+// The element to start the traversal at.
+//
+//	childrenSelector generic.ValueSelector[TSource, Iterator[TSource]]
+//
+// A function that returns the children of an element. A nil result means that the element has no children.
+//
+//	keySelector generic.KeySelector[TSource, TKey]
+//
+// A function to extract the key that identifies an element.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains every element reachable from the root in depth-first pre-order.
+//
+// # Remarks
+//
+// Elements whose key has already been visited are skipped together with their children, so cycles terminate.
+// The set of visited keys is created anew for every enumeration.
+func TraverseDepthFirstBy[TSource any, TKey comparable](root TSource, childrenSelector generic.ValueSelector[TSource, Iterator[TSource]], keySelector generic.KeySelector[TSource, TKey]) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("TraverseDepthFirstBy", true, "O(n)", nil)
+		},
+	}, func(yield func(value TSource) bool) {
+		traversal[TSource]{
+			childrenSelector: childrenSelector,
+			visit:            visited(keySelector),
+		}.depthFirst(root, 0, nil, func(node TraversalNode[TSource]) bool {
+			return yield(node.Value)
+		})
+	})
+}
+
+// Traverses a tree in depth-first pre-order, starting at the root, and reports the depth and the path of every element.
+//
+// # Parameters
+//
+//	root TSource
+//
+// The element to start the traversal at.
+//
+//	childrenSelector generic.ValueSelector[TSource, Iterator[TSource]]
+//
+// A function that returns the children of an element. A nil result means that the element has no children.
+//
+// # Returns
+//
+//	result Iterator[TraversalNode[TSource]]
+//
+// An Iterator[TraversalNode[TSource]] that describes the root and all its descendants in depth-first pre-order.
+//
+// # Remarks
+//
+// The Path of every yielded node is a copy of its own, so it may be kept or modified. Copying it costs O(depth) per element.
+func TraverseDepthFirstWithPath[TSource any](root TSource, childrenSelector generic.ValueSelector[TSource, Iterator[TSource]]) (result Iterator[TraversalNode[TSource]]) {
+	return describe(descriptor[TraversalNode[TSource]]{
+		plan: func() *PlanNode {
+			return node("TraverseDepthFirstWithPath", true, "O(n·depth)", nil)
+		},
+	}, func(yield func(value TraversalNode[TSource]) bool) {
+		traversal[TSource]{
+			childrenSelector: childrenSelector,
+			withPath:         true,
+		}.depthFirst(root, 0, nil, yield)
+	})
+}
+
+// Traverses a graph in depth-first pre-order, starting at the root, visits every element at most once, and reports the depth and the path of every element.
+//
+// # Parameters
+//
+//	root TSource
+//
+// The element to start the traversal at.
+//
+//	childrenSelector generic.ValueSelector[TSource, Iterator[TSource]]
+//
+// A function that returns the children of an element. A nil result means that the element has no children.
+//
+//	keySelector generic.KeySelector[TSource, TKey]
+//
+// A function to extract the key that identifies an element.
+//
+// # Returns
+//
+//	result Iterator[TraversalNode[TSource]]
+//
+// An Iterator[TraversalNode[TSource]] that describes every element reachable from the root in depth-first pre-order.
+//
+// # Remarks
+//
+// Elements whose key has already been visited are skipped together with their children, so cycles terminate.
+// The set of visited keys is created anew for every enumeration.
+// The Path of every yielded node is a copy of its own, so it may be kept or modified. Copying it costs O(depth) per element.
+func TraverseDepthFirstByWithPath[TSource any, TKey comparable](root TSource, childrenSelector generic.ValueSelector[TSource, Iterator[TSource]], keySelector generic.KeySelector[TSource, TKey]) (result Iterator[TraversalNode[TSource]]) {
+	return describe(descriptor[TraversalNode[TSource]]{
+		plan: func() *PlanNode {
+			return node("TraverseDepthFirstByWithPath", true, "O(n·depth)", nil)
+		},
+	}, func(yield func(value TraversalNode[TSource]) bool) {
+		traversal[TSource]{
+			childrenSelector: childrenSelector,
+			visit:            visited(keySelector),
+			withPath:         true,
+		}.depthFirst(root, 0, nil, yield)
+	})
+}
+
+// Traverses a tree level by level, starting at the root.
+//
+// # Parameters
+//
+//	root TSource
+//
+// The element to start the traversal at.
+//
+//	childrenSelector generic.ValueSelector[TSource, Iterator[TSource]]
+//
+// A function that returns the children of an element. A nil result means that the element has no children.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains the root and all its descendants in breadth-first order.
+//
+// # Remarks
+//
+// The traversal is lazy. The children of an element are requested only after the element has been yielded.
+// The elements of the level that follows the current one are buffered.
+// The traversal does not detect cycles. Use TraverseBreadthFirstBy when the structure can contain cycles.
+func TraverseBreadthFirst[TSource any](root TSource, childrenSelector generic.ValueSelector[TSource, Iterator[TSource]]) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("TraverseBreadthFirst", true, "O(n)", nil)
+		},
+	}, func(yield func(value TSource) bool) {
+		traversal[TSource]{
+			childrenSelector: childrenSelector,
+		}.breadthFirst(root, func(node TraversalNode[TSource]) bool {
+			return yield(node.Value)
+		})
+	})
+}
+
+// Traverses a graph level by level, starting at the root, and visits every element at most once.
+//
+// # Parameters
+//
+//	root TSource
+//
+// The element to start the traversal at.
+//
+//	childrenSelector generic.ValueSelector[TSource, Iterator[TSource]]
+//
+// A function that returns the children of an element. A nil result means that the element has no children.
+//
+//	keySelector generic.KeySelector[TSource, TKey]
+//
+// A function to extract the key that identifies an element.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains every element reachable from the root in breadth-first order.
+//
+// # Remarks
+//
+// Elements whose key has already been visited are skipped together with their children, so cycles terminate.
+// The set of visited keys is created anew for every enumeration.
+func TraverseBreadthFirstBy[TSource any, TKey comparable](root TSource, childrenSelector generic.ValueSelector[TSource, Iterator[TSource]], keySelector generic.KeySelector[TSource, TKey]) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("TraverseBreadthFirstBy", true, "O(n)", nil)
+		},
+	}, func(yield func(value TSource) bool) {
+		traversal[TSource]{
+			childrenSelector: childrenSelector,
+			visit:            visited(keySelector),
+		}.breadthFirst(root, func(node TraversalNode[TSource]) bool {
+			return yield(node.Value)
+		})
+	})
+}
+
+// Traverses a tree level by level, starting at the root, and reports the depth and the path of every element.
+//
+// # Parameters
+//
+//	root TSource
+//
+// The element to start the traversal at.
+//
+//	childrenSelector generic.ValueSelector[TSource, Iterator[TSource]]
+//
+// A function that returns the children of an element. A nil result means that the element has no children.
+//
+// # Returns
+//
+//	result Iterator[TraversalNode[TSource]]
+//
+// An Iterator[TraversalNode[TSource]] that describes the root and all its descendants in breadth-first order.
+//
+// # Remarks
+//
+// The Path of every yielded node is a copy of its own, so it may be kept or modified. Copying it costs O(depth) per element.
+func TraverseBreadthFirstWithPath[TSource any](root TSource, childrenSelector generic.ValueSelector[TSource, Iterator[TSource]]) (result Iterator[TraversalNode[TSource]]) {
+	return describe(descriptor[TraversalNode[TSource]]{
+		plan: func() *PlanNode {
+			return node("TraverseBreadthFirstWithPath", true, "O(n·depth)", nil)
+		},
+	}, func(yield func(value TraversalNode[TSource]) bool) {
+		traversal[TSource]{
+			childrenSelector: childrenSelector,
+			withPath:         true,
+		}.breadthFirst(root, yield)
+	})
+}
+
+// Traverses a graph level by level, starting at the root, visits every element at most once, and reports the depth and the path of every element.
+//
+// # Parameters
+//
+//	root TSource
+//
+// The element to start the traversal at.
+//
+//	childrenSelector generic.ValueSelector[TSource, Iterator[TSource]]
+//
+// A function that returns the children of an element. A nil result means that the element has no children.
+//
+//	keySelector generic.KeySelector[TSource, TKey]
+//
+// A function to extract the key that identifies an element.
+//
+// # Returns
+//
+//	result Iterator[TraversalNode[TSource]]
+//
+// An Iterator[TraversalNode[TSource]] that describes every element reachable from the root in breadth-first order.
+//
+// # Remarks
+//
+// Elements whose key has already been visited are skipped together with their children, so cycles terminate.
+// The set of visited keys is created anew for every enumeration.
+// The Path of every yielded node is a copy of its own, so it may be kept or modified. Copying it costs O(depth) per element.
+func TraverseBreadthFirstByWithPath[TSource any, TKey comparable](root TSource, childrenSelector generic.ValueSelector[TSource, Iterator[TSource]], keySelector generic.KeySelector[TSource, TKey]) (result Iterator[TraversalNode[TSource]]) {
+	return describe(descriptor[TraversalNode[TSource]]{
+		plan: func() *PlanNode {
+			return node("TraverseBreadthFirstByWithPath", true, "O(n·depth)", nil)
+		},
+	}, func(yield func(value TraversalNode[TSource]) bool) {
+		traversal[TSource]{
+			childrenSelector: childrenSelector,
+			visit:            visited(keySelector),
+			withPath:         true,
+		}.breadthFirst(root, yield)
+	})
+}
+
+// Flattens a sequence of sequences into one sequence.
+//
+// # Parameters
+//
+//	source Iterator[Iterator[TSource]]
+//
+// The sequence of sequences to flatten.
+//
+// # Returns
+//
+//	result Iterator[TSource]
+//
+// An Iterator[TSource] that contains the elements of every inner sequence, in order.
+//
+// # Remarks
+//
+// Nil inner sequences are treated as empty.
+func Flatten[TSource any](source Iterator[Iterator[TSource]]) (result Iterator[TSource]) {
+	return describe(descriptor[TSource]{
+		plan: func() *PlanNode {
+			return node("Flatten", false, "O(n)", nil, askPlan(source))
+		},
+	}, func(yield func(value TSource) bool) {
+		for inner := range source {
+			if inner == nil {
+				continue
+			}
+			for item := range inner {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	})
+}
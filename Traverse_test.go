@@ -0,0 +1,246 @@
+package linq
+
+import (
+	"reflect"
+	"testing"
+)
+
+type treeNode struct {
+	Name     string
+	Children []*treeNode
+}
+
+func (node *treeNode) children() Iterator[*treeNode] {
+	return FromSlice(node.Children)
+}
+
+func testTree() *treeNode {
+	return &treeNode{
+		Name: "a",
+		Children: []*treeNode{
+			{
+				Name: "b",
+				Children: []*treeNode{
+					{Name: "d"},
+					{Name: "e"},
+				},
+			},
+			{
+				Name: "c",
+				Children: []*treeNode{
+					{Name: "f"},
+				},
+			},
+		},
+	}
+}
+
+func testGraph() map[int][]int {
+	return map[int][]int{
+		1: {2, 3},
+		2: {4},
+		3: {4, 1},
+		4: {2},
+	}
+}
+
+func names(source Iterator[*treeNode]) []string {
+	return Select(source, func(node *treeNode) string {
+		return node.Name
+	}).ToSlice()
+}
+
+func TestTraverseDepthFirst(t *testing.T) {
+	tests := []struct {
+		name string
+		take int
+		want []string
+	}{
+		{
+			name: "TraverseDepthFirst whole tree",
+			take: 100,
+			want: []string{"a", "b", "d", "e", "c", "f"},
+		},
+		{
+			name: "TraverseDepthFirst stopped early",
+			take: 3,
+			want: []string{"a", "b", "d"},
+		},
+		{
+			name: "TraverseDepthFirst stopped at root",
+			take: 1,
+			want: []string{"a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(TraverseDepthFirst(testTree(), (*treeNode).children).Take(tt.take)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TraverseDepthFirst() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTraverseBreadthFirst(t *testing.T) {
+	tests := []struct {
+		name string
+		take int
+		want []string
+	}{
+		{
+			name: "TraverseBreadthFirst whole tree",
+			take: 100,
+			want: []string{"a", "b", "c", "d", "e", "f"},
+		},
+		{
+			name: "TraverseBreadthFirst stopped early",
+			take: 4,
+			want: []string{"a", "b", "c", "d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(TraverseBreadthFirst(testTree(), (*treeNode).children).Take(tt.take)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TraverseBreadthFirst() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTraverseBy(t *testing.T) {
+	graph := testGraph()
+	children := func(node int) Iterator[int] {
+		return FromSlice(graph[node])
+	}
+	key := func(node int) int {
+		return node
+	}
+	if got, want := TraverseDepthFirstBy(1, children, key).ToSlice(), []int{1, 2, 4, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TraverseDepthFirstBy() = %v, want %v", got, want)
+	}
+	if got, want := TraverseBreadthFirstBy(1, children, key).ToSlice(), []int{1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TraverseBreadthFirstBy() = %v, want %v", got, want)
+	}
+	traversal := TraverseDepthFirstBy(1, children, key)
+	if got, want := traversal.Count(), traversal.Count(); got != want {
+		t.Errorf("TraverseDepthFirstBy() second enumeration = %v, want %v", got, want)
+	}
+	want := []TraversalNode[int]{
+		{Value: 1, Depth: 0, Path: []int{1}},
+		{Value: 2, Depth: 1, Path: []int{1, 2}},
+		{Value: 4, Depth: 2, Path: []int{1, 2, 4}},
+		{Value: 3, Depth: 1, Path: []int{1, 3}},
+	}
+	if got := TraverseDepthFirstByWithPath(1, children, key).ToSlice(); !reflect.DeepEqual(got, want) {
+		t.Errorf("TraverseDepthFirstByWithPath() = %v, want %v", got, want)
+	}
+	want = []TraversalNode[int]{
+		{Value: 1, Depth: 0, Path: []int{1}},
+		{Value: 2, Depth: 1, Path: []int{1, 2}},
+		{Value: 3, Depth: 1, Path: []int{1, 3}},
+		{Value: 4, Depth: 2, Path: []int{1, 2, 4}},
+	}
+	if got := TraverseBreadthFirstByWithPath(1, children, key).ToSlice(); !reflect.DeepEqual(got, want) {
+		t.Errorf("TraverseBreadthFirstByWithPath() = %v, want %v", got, want)
+	}
+}
+
+func TestTraverseWithPath(t *testing.T) {
+	type step struct {
+		Name  string
+		Depth int
+		Path  []string
+	}
+	steps := func(source Iterator[TraversalNode[*treeNode]]) []step {
+		return Select(source, func(node TraversalNode[*treeNode]) step {
+			return step{
+				Name:  node.Value.Name,
+				Depth: node.Depth,
+				Path:  names(FromSlice(node.Path)),
+			}
+		}).ToSlice()
+	}
+	tests := []struct {
+		name string
+		got  Iterator[TraversalNode[*treeNode]]
+		want []step
+	}{
+		{
+			name: "TraverseDepthFirstWithPath",
+			got:  TraverseDepthFirstWithPath(testTree(), (*treeNode).children),
+			want: []step{
+				{"a", 0, []string{"a"}},
+				{"b", 1, []string{"a", "b"}},
+				{"d", 2, []string{"a", "b", "d"}},
+				{"e", 2, []string{"a", "b", "e"}},
+				{"c", 1, []string{"a", "c"}},
+				{"f", 2, []string{"a", "c", "f"}},
+			},
+		},
+		{
+			name: "TraverseBreadthFirstWithPath",
+			got:  TraverseBreadthFirstWithPath(testTree(), (*treeNode).children),
+			want: []step{
+				{"a", 0, []string{"a"}},
+				{"b", 1, []string{"a", "b"}},
+				{"c", 1, []string{"a", "c"}},
+				{"d", 2, []string{"a", "b", "d"}},
+				{"e", 2, []string{"a", "b", "e"}},
+				{"f", 2, []string{"a", "c", "f"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := steps(tt.got); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+		t.Run(tt.name+" path is a copy", func(t *testing.T) {
+			nodes := tt.got.ToSlice()
+			for i, node := range nodes {
+				for j := range node.Path {
+					node.Path[j] = &treeNode{Name: "x"}
+				}
+				if got, want := steps(FromSlice(nodes[i+1:])), tt.want[i+1:]; !reflect.DeepEqual(got, want) {
+					t.Errorf("%s() after modifying the path of %s = %v, want %v", tt.name, node.Value.Name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		name   string
+		source Iterator[Iterator[int]]
+		take   int
+		want   []int
+	}{
+		{
+			name:   "Flatten empty source",
+			source: FromSlice([]Iterator[int]{}),
+			take:   100,
+			want:   []int{},
+		},
+		{
+			name:   "Flatten source with empty and nil sequences",
+			source: FromSlice([]Iterator[int]{FromSlice([]int{1, 2}), nil, FromSlice([]int{}), FromSlice([]int{3})}),
+			take:   100,
+			want:   []int{1, 2, 3},
+		},
+		{
+			name:   "Flatten stopped early",
+			source: FromSlice([]Iterator[int]{FromSlice([]int{1, 2}), FromSlice([]int{3, 4})}),
+			take:   3,
+			want:   []int{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Flatten(tt.source).Take(tt.take).ToSlice(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Flatten() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package linq
+
+import (
+	"iter"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+// A pair of elements produced by ZipLongest.
+type ZipLongestPair[TFirst any, TSecond any] struct {
+
+	// The element of the first sequence, or the fallback when the first sequence has ended.
+	Item1 TFirst
+
+	// The element of the second sequence, or the fallback when the second sequence has ended.
+	Item2 TSecond
+
+	// True if Item1 was taken from the first sequence; false if the first sequence has ended.
+	HasItem1 bool
+
+	// True if Item2 was taken from the second sequence; false if the second sequence has ended.
+	HasItem2 bool
+}
+
+// A triple of values.
+type ValueTriple[TObject1 any, TObject2 any, TObject3 any] struct {
+	Item1 TObject1
+	Item2 TObject2
+	Item3 TObject3
+}
+
+// Produces a sequence of pairs with elements from the two specified sequences, until both of them are exhausted.
+//
+// # Parameters
+//
+//	source Iterator[TFirst]
+//
+// The first sequence to merge.
+//
+//	sequence Iterator[TSecond]
+//
+// The second sequence to merge.
+//
+//	fallback generic.ValuePair[TFirst, TSecond]
+//
+// The values that stand in for the elements of the sequence that ended first. [OPTIONAL]
+//
+// # Returns
+//
+//	result Iterator[ZipLongestPair[TFirst, TSecond]]
+//
+// A sequence of pairs with elements taken from the first and second sequences, in that order.
+//
+// # Remarks
+//
+// If the fallback parameter is omitted, zero values stand in for the elements of the sequence that ended first.
+// HasItem1 and HasItem2 report which of the sequences has ended.
+func ZipLongest[TFirst any, TSecond any](source Iterator[TFirst], sequence Iterator[TSecond], fallback ...generic.ValuePair[TFirst, TSecond]) (result Iterator[ZipLongestPair[TFirst, TSecond]]) {
+	return describe(descriptor[ZipLongestPair[TFirst, TSecond]]{
+		plan: func() *PlanNode {
+			return node("ZipLongest", false, "O(n+m)", nil, askPlan(source), askPlan(sequence))
+		},
+	}, func(yield func(value ZipLongestPair[TFirst, TSecond]) bool) {
+		var Fallback generic.ValuePair[TFirst, TSecond]
+		if len(fallback) > 0 {
+			Fallback = fallback[0]
+		}
+		next, stop := iter.Pull(iter.Seq[TSecond](sequence))
+		defer stop()
+		for item1 := range source {
+			item2, ok := next()
+			if !ok {
+				item2 = Fallback.Item2
+			}
+			if !yield(ZipLongestPair[TFirst, TSecond]{
+				Item1:    item1,
+				Item2:    item2,
+				HasItem1: true,
+				HasItem2: ok,
+			}) {
+				return
+			}
+		}
+		for {
+			item2, ok := next()
+			if !ok {
+				return
+			}
+			if !yield(ZipLongestPair[TFirst, TSecond]{
+				Item1:    Fallback.Item1,
+				Item2:    item2,
+				HasItem1: false,
+				HasItem2: true,
+			}) {
+				return
+			}
+		}
+	})
+}
+
+// Produces a sequence of pairs with elements from the two specified sequences and reports an error if the sequences have different lengths.
+//
+// # Parameters
+//
+//	source Iterator[TFirst]
+//
+// The first sequence to merge.
+//
+//	sequence Iterator[TSecond]
+//
+// The second sequence to merge.
+//
+// # Returns
+//
+//	result ErrorIterator[generic.ValuePair[TFirst, TSecond]]
+//
+// A sequence of pairs with elements taken from the first and second sequences, in that order.
+//
+// # Error
+//
+// linq.ErrLengthMismatch - When one of the sequences ends before the other one. The error is yielded after the last pair.
+func ZipStrict[TFirst any, TSecond any](source Iterator[TFirst], sequence Iterator[TSecond]) (result ErrorIterator[generic.ValuePair[TFirst, TSecond]]) {
+	return func(yield func(value generic.ValuePair[TFirst, TSecond], err error) bool) {
+		next, stop := iter.Pull(iter.Seq[TSecond](sequence))
+		defer stop()
+		for item1 := range source {
+			item2, ok := next()
+			if !ok {
+				yield(generic.ValuePair[TFirst, TSecond]{}, ErrLengthMismatch)
+				return
+			}
+			if !yield(generic.ValuePair[TFirst, TSecond]{
+				Item1: item1,
+				Item2: item2,
+			}, nil) {
+				return
+			}
+		}
+		if _, ok := next(); ok {
+			yield(generic.ValuePair[TFirst, TSecond]{}, ErrLengthMismatch)
+		}
+	}
+}
+
+// Produces a sequence of triples with elements from the three specified sequences.
+//
+// # Parameters
+//
+//	first Iterator[TFirst]
+//
+// The first sequence to merge.
+//
+//	second Iterator[TSecond]
+//
+// The second sequence to merge.
+//
+//	third Iterator[TThird]
+//
+// The third sequence to merge.
+//
+// # Returns
+//
+//	result Iterator[ValueTriple[TFirst, TSecond, TThird]]
+//
+// A sequence of triples with elements taken from the first, second and third sequences, in that order.
+//
+// # Remarks
+//
+// The sequence ends as soon as any of the input sequences ends.
+func Zip3[TFirst any, TSecond any, TThird any](first Iterator[TFirst], second Iterator[TSecond], third Iterator[TThird]) (result Iterator[ValueTriple[TFirst, TSecond, TThird]]) {
+	return describe(descriptor[ValueTriple[TFirst, TSecond, TThird]]{
+		plan: func() *PlanNode {
+			return node("Zip3", false, "O(n)", nil, askPlan(first), askPlan(second), askPlan(third))
+		},
+	}, func(yield func(value ValueTriple[TFirst, TSecond, TThird]) bool) {
+		next2, stop2 := iter.Pull(iter.Seq[TSecond](second))
+		defer stop2()
+		next3, stop3 := iter.Pull(iter.Seq[TThird](third))
+		defer stop3()
+		for item1 := range first {
+			item2, ok := next2()
+			if !ok {
+				return
+			}
+			item3, ok := next3()
+			if !ok {
+				return
+			}
+			if !yield(ValueTriple[TFirst, TSecond, TThird]{
+				Item1: item1,
+				Item2: item2,
+				Item3: item3,
+			}) {
+				return
+			}
+		}
+	})
+}
+
+// Produces a sequence of slices with one element from each of the specified sequences.
+//
+// # Parameters
+//
+//	sources ...Iterator[TSource]
+//
+// The sequences to merge.
+//
+// # Returns
+//
+//	result Iterator[[]TSource]
+//
+// A sequence of slices. The i-th element of every slice is taken from the i-th source.
+//
+// # Remarks
+//
+// The sequence ends as soon as any of the sources ends. Without sources, the sequence is empty.
+// Every yielded slice is newly allocated.
+func ZipN[TSource any](sources ...Iterator[TSource]) (result Iterator[[]TSource]) {
+	return describe(descriptor[[]TSource]{
+		plan: func() *PlanNode {
+			return node("ZipN", false, "O(n)", nil, askPlans(sources)...)
+		},
+	}, func(yield func(value []TSource) bool) {
+		if len(sources) == 0 {
+			return
+		}
+		next, stop := pullAll(sources)
+		defer stop()
+		for {
+			values := make([]TSource, len(next))
+			for i, pull := range next {
+				value, ok := pull()
+				if !ok {
+					return
+				}
+				values[i] = value
+			}
+			if !yield(values) {
+				return
+			}
+		}
+	})
+}
+
+// Applies a specified function to the corresponding elements of two sequences, producing a sequence of the results.
+//
+// # Parameters
+//
+//	source Iterator[TFirst]
+//
+// The first sequence to merge.
+//
+//	sequence Iterator[TSecond]
+//
+// The second sequence to merge.
+//
+//	resultSelector func(first TFirst, second TSecond) TResult
+//
+// A function that specifies how to merge the elements from the two sequences.
+//
+// # Returns
+//
+//	result Iterator[TResult]
+//
+// An Iterator[TResult] that contains merged elements of two input sequences.
+//
+// # Remarks
+//
+// The sequence ends as soon as any of the input sequences ends.
+func ZipWith[TFirst any, TSecond any, TResult any](source Iterator[TFirst], sequence Iterator[TSecond], resultSelector func(first TFirst, second TSecond) TResult) (result Iterator[TResult]) {
+	return describe(descriptor[TResult]{
+		plan: func() *PlanNode {
+			return node("ZipWith", false, "O(n)", nil, askPlan(source), askPlan(sequence))
+		},
+	}, func(yield func(value TResult) bool) {
+		next, stop := iter.Pull(iter.Seq[TSecond](sequence))
+		defer stop()
+		for item1 := range source {
+			item2, ok := next()
+			if !ok {
+				return
+			}
+			if !yield(resultSelector(item1, item2)) {
+				return
+			}
+		}
+	})
+}
+
+// Splits a sequence of pairs into two slices.
+//
+// # Parameters
+//
+//	source Iterator[generic.ValuePair[TFirst, TSecond]]
+//
+// The sequence of pairs to split.
+//
+// # Returns
+//
+//	first []TFirst
+//
+// The first items of the pairs.
+//
+//	second []TSecond
+//
+// The second items of the pairs.
+func Unzip[TFirst any, TSecond any](source Iterator[generic.ValuePair[TFirst, TSecond]]) (first []TFirst, second []TSecond) {
+	first = make([]TFirst, 0)
+	second = make([]TSecond, 0)
+	for item := range source {
+		first = append(first, item.Item1)
+		second = append(second, item.Item2)
+	}
+	return first, second
+}
+
+// Splits a sequence of pairs into two sequences that share a single enumeration of the source.
+//
+// # Parameters
+//
+//	source Iterator[generic.ValuePair[TFirst, TSecond]]
+//
+// The sequence of pairs to split.
+//
+// # Returns
+//
+//	first Iterator[TFirst]
+//
+// The first items of the pairs.
+//
+//	second Iterator[TSecond]
+//
+// The second items of the pairs.
+//
+// # Remarks
+//
+// The source is enumerated at most once, while first and second are enumerated.
+// The items that one of the sequences has already received and the other one has not are buffered.
+// Each of the returned sequences can be enumerated only once. A sequence that stops early no longer holds items in the buffer.
+// The returned sequences may be enumerated from different goroutines.
+func UnzipLazy[TFirst any, TSecond any](source Iterator[generic.ValuePair[TFirst, TSecond]]) (first Iterator[TFirst], second Iterator[TSecond]) {
+	shared := newBroadcast(source, 2, 0)
+	first = Select(shared.branch(0), func(item generic.ValuePair[TFirst, TSecond]) TFirst {
+		return item.Item1
+	})
+	second = Select(shared.branch(1), func(item generic.ValuePair[TFirst, TSecond]) TSecond {
+		return item.Item2
+	})
+	return first, second
+}
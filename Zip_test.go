@@ -0,0 +1,227 @@
+package linq
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+func TestZipLongest(t *testing.T) {
+	type args struct {
+		source   Iterator[int]
+		sequence Iterator[string]
+		fallback []generic.ValuePair[int, string]
+	}
+	tests := []struct {
+		name string
+		args args
+		want []ZipLongestPair[int, string]
+	}{
+		{
+			name: "ZipLongest empty source with empty sequence",
+			args: args{
+				source:   FromSlice([]int{}),
+				sequence: FromSlice([]string{}),
+			},
+			want: []ZipLongestPair[int, string]{},
+		},
+		{
+			name: "ZipLongest source longer than sequence",
+			args: args{
+				source:   FromSlice([]int{1, 2, 3}),
+				sequence: FromSlice([]string{"a"}),
+			},
+			want: []ZipLongestPair[int, string]{
+				{Item1: 1, Item2: "a", HasItem1: true, HasItem2: true},
+				{Item1: 2, Item2: "", HasItem1: true, HasItem2: false},
+				{Item1: 3, Item2: "", HasItem1: true, HasItem2: false},
+			},
+		},
+		{
+			name: "ZipLongest sequence longer than source, with fallback",
+			args: args{
+				source:   FromSlice([]int{1}),
+				sequence: FromSlice([]string{"a", "b"}),
+				fallback: []generic.ValuePair[int, string]{{Item1: -1, Item2: "?"}},
+			},
+			want: []ZipLongestPair[int, string]{
+				{Item1: 1, Item2: "a", HasItem1: true, HasItem2: true},
+				{Item1: -1, Item2: "b", HasItem1: false, HasItem2: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ZipLongest(tt.args.source, tt.args.sequence, tt.args.fallback...).ToSlice(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ZipLongest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZipStrict(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   Iterator[int]
+		sequence Iterator[string]
+		want     []generic.ValuePair[int, string]
+		wantErr  error
+	}{
+		{
+			name:     "ZipStrict same length",
+			source:   FromSlice([]int{1, 2}),
+			sequence: FromSlice([]string{"a", "b"}),
+			want:     []generic.ValuePair[int, string]{{Item1: 1, Item2: "a"}, {Item1: 2, Item2: "b"}},
+		},
+		{
+			name:     "ZipStrict source longer than sequence",
+			source:   FromSlice([]int{1, 2}),
+			sequence: FromSlice([]string{"a"}),
+			want:     []generic.ValuePair[int, string]{{Item1: 1, Item2: "a"}},
+			wantErr:  ErrLengthMismatch,
+		},
+		{
+			name:     "ZipStrict sequence longer than source",
+			source:   FromSlice([]int{1}),
+			sequence: FromSlice([]string{"a", "b"}),
+			want:     []generic.ValuePair[int, string]{{Item1: 1, Item2: "a"}},
+			wantErr:  ErrLengthMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ZipStrict(tt.source, tt.sequence).ToSlice()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ZipStrict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ZipStrict() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZip3(t *testing.T) {
+	got := Zip3(FromSlice([]int{1, 2, 3}), FromSlice([]string{"a", "b"}), FromSlice([]bool{true, false, true})).ToSlice()
+	want := []ValueTriple[int, string, bool]{
+		{Item1: 1, Item2: "a", Item3: true},
+		{Item1: 2, Item2: "b", Item3: false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Zip3() = %v, want %v", got, want)
+	}
+}
+
+func TestZipN(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []Iterator[int]
+		want    [][]int
+	}{
+		{
+			name:    "ZipN no sources",
+			sources: []Iterator[int]{},
+			want:    [][]int{},
+		},
+		{
+			name: "ZipN sources",
+			sources: []Iterator[int]{
+				FromSlice([]int{1, 2, 3}),
+				FromSlice([]int{10, 20}),
+				FromSlice([]int{100, 200, 300}),
+			},
+			want: [][]int{{1, 10, 100}, {2, 20, 200}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ZipN(tt.sources...).ToSlice(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ZipN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZipWith(t *testing.T) {
+	got := ZipWith(FromSlice([]int{1, 2, 3}), FromSlice([]int{10, 20}), func(first, second int) int {
+		return first + second
+	}).ToSlice()
+	if want := []int{11, 22}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ZipWith() = %v, want %v", got, want)
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	source := FromSlice([]generic.ValuePair[int, string]{{Item1: 1, Item2: "a"}, {Item1: 2, Item2: "b"}})
+	first, second := Unzip(source)
+	if want := []int{1, 2}; !reflect.DeepEqual(first, want) {
+		t.Errorf("Unzip() first = %v, want %v", first, want)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(second, want) {
+		t.Errorf("Unzip() second = %v, want %v", second, want)
+	}
+}
+
+func TestUnzipLazy(t *testing.T) {
+	pairs := []generic.ValuePair[int, string]{{Item1: 1, Item2: "a"}, {Item1: 2, Item2: "b"}, {Item1: 3, Item2: "c"}}
+	t.Run("UnzipLazy enumerates source once", func(t *testing.T) {
+		enumerations := 0
+		source := Iterator[generic.ValuePair[int, string]](func(yield func(generic.ValuePair[int, string]) bool) {
+			enumerations++
+			FromSlice(pairs)(yield)
+		})
+		first, second := UnzipLazy(source)
+		if got, want := first.ToSlice(), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+			t.Errorf("UnzipLazy() first = %v, want %v", got, want)
+		}
+		if got, want := second.ToSlice(), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("UnzipLazy() second = %v, want %v", got, want)
+		}
+		if enumerations != 1 {
+			t.Errorf("UnzipLazy() enumerated source %d times, want 1", enumerations)
+		}
+	})
+	t.Run("UnzipLazy interleaved", func(t *testing.T) {
+		first, second := UnzipLazy(FromSlice(pairs))
+		got := ZipWith(first, second, func(number int, letter string) generic.ValuePair[int, string] {
+			return generic.ValuePair[int, string]{Item1: number, Item2: letter}
+		}).ToSlice()
+		if !reflect.DeepEqual(got, pairs) {
+			t.Errorf("UnzipLazy() = %v, want %v", got, pairs)
+		}
+	})
+	t.Run("UnzipLazy one sequence stops early", func(t *testing.T) {
+		first, second := UnzipLazy(FromSlice(pairs))
+		if got, want := first.Take(1).ToSlice(), []int{1}; !reflect.DeepEqual(got, want) {
+			t.Errorf("UnzipLazy() first = %v, want %v", got, want)
+		}
+		if got, want := second.ToSlice(), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("UnzipLazy() second = %v, want %v", got, want)
+		}
+	})
+	t.Run("UnzipLazy concurrent", func(t *testing.T) {
+		first, second := UnzipLazy(FromSlice(pairs))
+		var firstGot []int
+		var secondGot []string
+		var wait sync.WaitGroup
+		wait.Add(2)
+		go func() {
+			defer wait.Done()
+			firstGot = first.ToSlice()
+		}()
+		go func() {
+			defer wait.Done()
+			secondGot = second.ToSlice()
+		}()
+		wait.Wait()
+		if want := []int{1, 2, 3}; !reflect.DeepEqual(firstGot, want) {
+			t.Errorf("UnzipLazy() first = %v, want %v", firstGot, want)
+		}
+		if want := []string{"a", "b", "c"}; !reflect.DeepEqual(secondGot, want) {
+			t.Errorf("UnzipLazy() second = %v, want %v", secondGot, want)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+// Package linq provides lazy, composable queries over iter.Seq sequences.
+//
+// # Composition
+//
+// Operators that keep the element type are methods and chain from left to right. Operators that change it, such as Select,
+// GroupBy or Join, are functions, because Go methods cannot have type parameters. Each operator is also available as a Stage,
+// for example Filter, Map and Sort, and Pipe and Pipe2 through Pipe8 apply stages in order, so that every query reads top to bottom.
+// Stage constructors are named for what they do, such as Filter, Limit, Drop or Unique, and never after a method or function
+// of the package, so that a name that returns an Iterator never also returns a Stage.
+//
+// # Re-enumeration
+//
+// Every Iterator returned by this package can be enumerated any number of times, and every enumeration
+// yields the same elements, provided that the sources it reads from do the same. Operators keep no state
+// between enumerations: buffers, counters and positions are created anew each time. An enumeration stops as soon as
+// yield returns false and never calls yield after that. The linqtest package checks these guarantees.
+//
+// The exceptions are stated below. Operators that draw random numbers (Shuffle, Sample, WeightedSample) yield a different
+// selection or order on every enumeration, because they keep drawing from the same rng, and Generate yields whatever its
+// function returns.
+//
+// # Rewrites
+//
+// Operators recognize the operators they are applied to and rewrite the chain, with the same results:
+//
+//	Order(...).Take(k)       - a bounded heap of k elements instead of a sort, also for OrderDescending, OrderBy and OrderByDescending
+//	Order(...).First()       - a heap of one element, that is a minimum scan
+//	Order(...).Where(p)      - the filter runs before the sort, so only the elements that satisfy p are sorted
+//	Reverse().Take(k)        - the last k elements, as TakeLast keeps them, instead of the whole sequence
+//	Reverse().First()        - the last element, without buffering
+//
+// Consecutive Where and Select stages need no rewrite: each element is pushed through all of them by one loop over
+// the source, without intermediate sequences.
+//
+// # Buffering and one-shot behavior
+//
+//	Streaming, no buffer:
+//	  FromSlice, FromIterator, FromMap, FromString, FromFS, FromDirRecursive, FromGlob, Repeat, Range,
+//	  RangeStep, DateRange, MonthRange, Empty, Iterate, Unfold, Generate, Append, Prepend, Cast, Concat,
+//	  Select, SelectMany, Where, Skip, SkipWhile, Take, TakeWhile, Zip, ZipWith, ZipLongest, ZipStrict,
+//	  Zip3, ZipN, MergeSorted, Interleave, Alternate, Flatten, Tap, Trace, Measure, Share,
+//	  AsOrdered(...).Iterator, AsOrdered(...).BottomK.
+//	Buffers a window of count elements:
+//	  SkipLast, TakeLast, Chunk (one chunk).
+//	Buffers the distinct elements seen so far:
+//	  Distinct, Union, TraverseDepthFirstBy, TraverseBreadthFirstBy, TraverseDepthFirstByWithPath,
+//	  TraverseBreadthFirstByWithPath.
+//	Buffers the second sequence, then streams the first:
+//	  Join.
+//	Buffers the distinct elements of both sequences:
+//	  Except, Intersect.
+//	Buffers the source while yielding its first cycle:
+//	  Cycle.
+//	Buffers the whole source before yielding:
+//	  Order, OrderDescending, OrderBy, OrderByDescending, Reverse, GroupBy, Shuffle.
+//	Buffers k elements:
+//	  TopK, BottomK, TopKBy, BottomKBy, Sample, WeightedSample.
+//	Buffers the last k elements, plus up to k elements that compare equal to the smallest of them:
+//	  AsOrdered(...).TopK.
+//	Buffers one page:
+//	  Paginate, PaginateKeyset.
+//	Buffers the path or the next level of a traversal:
+//	  TraverseDepthFirst, TraverseDepthFirstWithPath, TraverseBreadthFirst, TraverseBreadthFirstWithPath.
+//	Buffers the graph when the function is called:
+//	  TopologicalSort, TransitiveClosure, ConnectedComponents, ShortestPathBFS.
+//	Spills to disk beyond SpillOptions.MaxBytesInMemory bytes:
+//	  OrderExternal, OrderByExternal, GroupByExternal, DistinctExternal, and GroupBy and Distinct given SpillOptions.
+//	Buffers what the source has yielded so far, shared by all enumerations:
+//	  Memoize.
+//
+// Reverse, SkipLast, TakeLast and Cycle do not buffer when their source is backed by a slice or a range.
+//
+//	One-shot, each result can be enumerated only once:
+//	  Tee, Publish, Partition, UnzipLazy.
+//	Consumes a shared cursor, each enumeration continues where the previous one stopped:
+//	  Share, FromEnumerator, Enumerator.Remaining.
+//
+// See the Remarks of each function for the details.
+package linq
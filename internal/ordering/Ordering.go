@@ -0,0 +1,61 @@
+// Package ordering compares the field values that linqexpr expressions and linqquery queries evaluate, so that both
+// order and compare them alike.
+package ordering
+
+import (
+	"cmp"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// Compares two values that can be compared with each other: two booleans, two strings, two time.Time values, or two
+// numbers of any integer or floating-point kinds.
+//
+// False is less than true. Integers are compared exactly, also between signed and unsigned kinds; a float and an
+// integer are compared as float64.
+func Compare(x reflect.Value, y reflect.Value) int {
+	if x.Type() == timeType {
+		return x.Interface().(time.Time).Compare(y.Interface().(time.Time))
+	}
+	switch x.Kind() {
+	case reflect.Bool:
+		return cmp.Compare(BoolRank(x.Bool()), BoolRank(y.Bool()))
+	case reflect.String:
+		return strings.Compare(x.String(), y.String())
+	}
+	switch {
+	case x.CanInt() && y.CanInt():
+		return cmp.Compare(x.Int(), y.Int())
+	case x.CanUint() && y.CanUint():
+		return cmp.Compare(x.Uint(), y.Uint())
+	case x.CanInt() && y.CanUint():
+		if x.Int() < 0 {
+			return -1
+		}
+		return cmp.Compare(uint64(x.Int()), y.Uint())
+	case x.CanUint() && y.CanInt():
+		return -Compare(y, x)
+	}
+	return cmp.Compare(float(x), float(y))
+}
+
+// Returns 1 for true and 0 for false, so that false orders first.
+func BoolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func float(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
@@ -0,0 +1,40 @@
+package ordering
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	type celsius float32
+	type name string
+	now := time.Now()
+	tests := []struct {
+		name string
+		x    any
+		y    any
+		want int
+	}{
+		{name: "false and true", x: false, y: true, want: -1},
+		{name: "equal booleans", x: true, y: true, want: 0},
+		{name: "strings", x: "b", y: "a", want: 1},
+		{name: "named strings", x: name("a"), y: name("b"), want: -1},
+		{name: "times", x: now, y: now.Add(time.Second), want: -1},
+		{name: "ints of different sizes", x: int8(-3), y: int64(2), want: -1},
+		{name: "uints", x: uint(7), y: uint16(7), want: 0},
+		{name: "negative int and uint", x: -1, y: uint64(math.MaxUint64), want: -1},
+		{name: "int and large uint", x: math.MaxInt64, y: uint64(math.MaxUint64), want: -1},
+		{name: "uint and int", x: uint64(math.MaxUint64), y: math.MaxInt64, want: 1},
+		{name: "int and float", x: 2, y: 2.5, want: -1},
+		{name: "named float and uint", x: celsius(3), y: uint8(3), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Compare(reflect.ValueOf(tt.x), reflect.ValueOf(tt.y)); got != tt.want {
+				t.Errorf("Compare(%v, %v) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
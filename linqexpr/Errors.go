@@ -0,0 +1,6 @@
+package linqexpr
+
+import "errors"
+
+var ErrUnknownField = errors.New("unknown field")
+var ErrType = errors.New("type mismatch")
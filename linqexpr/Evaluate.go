@@ -0,0 +1,401 @@
+package linqexpr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/thereisnoplanb/generic"
+	"github.com/thereisnoplanb/linq/internal/ordering"
+)
+
+// The kinds of values expressions compare.
+type category int
+
+const (
+	categoryNone category = iota
+	categoryBool
+	categoryNumber
+	categoryString
+	categoryTime
+)
+
+var boolType = reflect.TypeFor[bool]()
+var timeType = reflect.TypeFor[time.Time]()
+
+func categoryOf(t reflect.Type) category {
+	t = indirect(t)
+	if t == timeType {
+		return categoryTime
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return categoryBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return categoryNumber
+	case reflect.String:
+		return categoryString
+	}
+	return categoryNone
+}
+
+// Returns the type a chain of pointers points to.
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// Returns the value a chain of pointers points to, or the zero Value if one of them is nil.
+func dereference(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func (field FieldExpression) typeOf(element reflect.Type) (result reflect.Type, err error) {
+	element = indirect(element)
+	if element.Kind() == reflect.Struct {
+		if f, ok := element.FieldByName(field.Name); ok && f.IsExported() {
+			return f.Type, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s is not a field of %v", ErrUnknownField, field.Name, element)
+}
+
+func (field FieldExpression) evaluate(element reflect.Value) (result reflect.Value) {
+	element = dereference(element)
+	if !element.IsValid() {
+		return reflect.Value{}
+	}
+	f, _ := element.Type().FieldByName(field.Name)
+	result, err := element.FieldByIndexErr(f.Index)
+	if err != nil {
+		return reflect.Value{}
+	}
+	return dereference(result)
+}
+
+func (constant ConstantExpression) typeOf(element reflect.Type) (result reflect.Type, err error) {
+	return reflect.TypeOf(constant.Value), nil
+}
+
+func (constant ConstantExpression) evaluate(element reflect.Value) (result reflect.Value) {
+	return dereference(reflect.ValueOf(constant.Value))
+}
+
+// Reports whether an expression is the constant nil.
+func isNil(expression Expression) bool {
+	constant, ok := expression.(ConstantExpression)
+	return ok && constant.Value == nil
+}
+
+func (expression BinaryExpression) typeOf(element reflect.Type) (result reflect.Type, err error) {
+	left, err := expression.Left.typeOf(element)
+	if err != nil {
+		return nil, err
+	}
+	right, err := expression.Right.typeOf(element)
+	if err != nil {
+		return nil, err
+	}
+	mismatch := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %v: %s", ErrType, expression, fmt.Sprintf(format, args...))
+	}
+	switch expression.Operator {
+	case AndAlso, OrElse:
+		if left == nil || categoryOf(left) != categoryBool || right == nil || categoryOf(right) != categoryBool {
+			return nil, mismatch("%s applies to conditions", expression.Operator)
+		}
+		return boolType, nil
+	case Equal, NotEqual, Less, LessOrEqual, Greater, GreaterOrEqual, Contains, HasPrefix, HasSuffix:
+	default:
+		return nil, mismatch("unknown operator")
+	}
+	if isNil(expression.Left) || isNil(expression.Right) {
+		other := left
+		if isNil(expression.Left) {
+			other = right
+		}
+		switch {
+		case expression.Operator != Equal && expression.Operator != NotEqual:
+			return nil, mismatch("nil can only be compared with == and !=")
+		case other != nil && other.Kind() != reflect.Pointer:
+			return nil, mismatch("a %v is never nil", other)
+		}
+		return boolType, nil
+	}
+	if left == nil || right == nil {
+		return nil, mismatch("cannot compare nil")
+	}
+	leftCategory, rightCategory := categoryOf(left), categoryOf(right)
+	switch {
+	case leftCategory == categoryNone || rightCategory == categoryNone:
+		return nil, mismatch("cannot compare %v with %v", left, right)
+	case leftCategory != rightCategory:
+		return nil, mismatch("cannot compare %v with %v", left, right)
+	case leftCategory != categoryString && (expression.Operator == Contains || expression.Operator == HasPrefix || expression.Operator == HasSuffix):
+		return nil, mismatch("%s applies to strings", expression.Operator)
+	case leftCategory == categoryBool && expression.Operator != Equal && expression.Operator != NotEqual:
+		return nil, mismatch("bool values are not ordered")
+	}
+	return boolType, nil
+}
+
+func (expression BinaryExpression) evaluate(element reflect.Value) (result reflect.Value) {
+	return reflect.ValueOf(expression.test(element))
+}
+
+func (expression BinaryExpression) test(element reflect.Value) bool {
+	switch expression.Operator {
+	case AndAlso:
+		return truth(expression.Left.evaluate(element)) && truth(expression.Right.evaluate(element))
+	case OrElse:
+		return truth(expression.Left.evaluate(element)) || truth(expression.Right.evaluate(element))
+	}
+	left := expression.Left.evaluate(element)
+	right := expression.Right.evaluate(element)
+	if isNil(expression.Left) || isNil(expression.Right) {
+		null := !left.IsValid() && !right.IsValid()
+		return null == (expression.Operator == Equal)
+	}
+	if !left.IsValid() || !right.IsValid() {
+		return false
+	}
+	switch expression.Operator {
+	case Contains:
+		return strings.Contains(left.String(), right.String())
+	case HasPrefix:
+		return strings.HasPrefix(left.String(), right.String())
+	case HasSuffix:
+		return strings.HasSuffix(left.String(), right.String())
+	}
+	order := ordering.Compare(left, right)
+	switch expression.Operator {
+	case Equal:
+		return order == 0
+	case NotEqual:
+		return order != 0
+	case Less:
+		return order < 0
+	case LessOrEqual:
+		return order <= 0
+	case Greater:
+		return order > 0
+	}
+	return order >= 0
+}
+
+func (expression NotExpression) typeOf(element reflect.Type) (result reflect.Type, err error) {
+	operand, err := expression.Operand.typeOf(element)
+	if err != nil {
+		return nil, err
+	}
+	if operand == nil || categoryOf(operand) != categoryBool {
+		return nil, fmt.Errorf("%w: %v: ! applies to conditions", ErrType, expression)
+	}
+	return boolType, nil
+}
+
+func (expression NotExpression) evaluate(element reflect.Value) (result reflect.Value) {
+	return reflect.ValueOf(!truth(expression.Operand.evaluate(element)))
+}
+
+// Reports whether a condition holds. A nil condition does not.
+func truth(v reflect.Value) bool {
+	return v.IsValid() && v.Bool()
+}
+
+// Checks that a condition applies to elements of a type.
+func checkCondition(condition Expression, element reflect.Type) error {
+	t, err := condition.typeOf(element)
+	if err != nil {
+		return err
+	}
+	if t == nil || categoryOf(t) != categoryBool {
+		return fmt.Errorf("%w: %v is not a condition", ErrType, condition)
+	}
+	return nil
+}
+
+// Checks that a key of an ordering applies to elements of a type.
+func checkKey(key Expression, element reflect.Type) error {
+	t, err := key.typeOf(element)
+	if err != nil {
+		return err
+	}
+	if t == nil || categoryOf(t) == categoryNone {
+		return fmt.Errorf("%w: %v is not ordered", ErrType, key)
+	}
+	return nil
+}
+
+// Returns a function that creates a value of the result type from an element, as the assignments say.
+func project(element reflect.Type, result reflect.Type, assignments []Assignment) (projection func(reflect.Value) reflect.Value, err error) {
+	target := result
+	if target.Kind() == reflect.Pointer {
+		target = target.Elem()
+	}
+	if target.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: the result type %v is not a struct", ErrType, result)
+	}
+	if len(assignments) == 0 {
+		for _, f := range reflect.VisibleFields(target) {
+			if _, err := Field(f.Name).typeOf(element); err == nil && f.IsExported() && !f.Anonymous {
+				assignments = append(assignments, Field(f.Name).As(f.Name))
+			}
+		}
+	}
+	indices := make([][]int, len(assignments))
+	for i, assignment := range assignments {
+		f, ok := target.FieldByName(assignment.Name)
+		if !ok || !f.IsExported() {
+			return nil, fmt.Errorf("%w: %s is not a field of %v", ErrUnknownField, assignment.Name, target)
+		}
+		t, err := assignment.Value.typeOf(element)
+		if err != nil {
+			return nil, err
+		}
+		if t != nil && !assignable(t, f.Type) {
+			return nil, fmt.Errorf("%w: %v: cannot assign a %v to a %v", ErrType, assignment, t, f.Type)
+		}
+		indices[i] = f.Index
+	}
+	return func(item reflect.Value) reflect.Value {
+		value := reflect.New(target).Elem()
+		for i, assignment := range assignments {
+			set(value.FieldByIndex(indices[i]), assignment.Value.evaluate(item))
+		}
+		if result.Kind() == reflect.Pointer {
+			return value.Addr()
+		}
+		return value
+	}, nil
+}
+
+// Reports whether a value of one type can be assigned to a field of another by set.
+func assignable(from reflect.Type, to reflect.Type) bool {
+	from = indirect(from)
+	if to.Kind() == reflect.Pointer {
+		to = to.Elem()
+	}
+	if from == to {
+		return true
+	}
+	c := categoryOf(from)
+	return c != categoryNone && c != categoryTime && c == categoryOf(to) && from.ConvertibleTo(to)
+}
+
+// Sets a field to a value, allocating the field if it is a pointer. A nil value leaves the field zero.
+func set(target reflect.Value, value reflect.Value) {
+	if !value.IsValid() {
+		return
+	}
+	if target.Kind() == reflect.Pointer && value.Type() != target.Type() {
+		pointer := reflect.New(target.Type().Elem())
+		pointer.Elem().Set(value.Convert(target.Type().Elem()))
+		target.Set(pointer)
+		return
+	}
+	target.Set(value.Convert(target.Type()))
+}
+
+// Evaluates an expression over an element.
+//
+// # Parameters
+//
+//	expression Expression
+//
+// The expression to evaluate.
+//
+//	element TSource
+//
+// The element whose fields the expression reads. TSource is a struct type or a pointer to one.
+//
+// # Returns
+//
+//	result any
+//
+// The value of the expression. It is nil if the expression reads a nil pointer, and never a pointer otherwise.
+//
+// # Error
+//
+//	err error
+//
+// ErrUnknownField or ErrType if the expression does not apply to TSource.
+func Evaluate[TSource any](expression Expression, element TSource) (result any, err error) {
+	if _, err := expression.typeOf(reflect.TypeFor[TSource]()); err != nil {
+		return nil, err
+	}
+	value := expression.evaluate(reflect.ValueOf(&element).Elem())
+	if !value.IsValid() {
+		return nil, nil
+	}
+	return value.Interface(), nil
+}
+
+// Compiles a condition into a predicate.
+//
+// # Parameters
+//
+//	condition Expression
+//
+// The condition, for example Field("Age").Gt(30).
+//
+// # Returns
+//
+//	result generic.Predicate[TSource]
+//
+// A predicate that reports whether the condition holds for an element. It never holds for a nil element.
+//
+// # Error
+//
+//	err error
+//
+// ErrUnknownField or ErrType if the condition does not apply to TSource.
+func Predicate[TSource any](condition Expression) (result generic.Predicate[TSource], err error) {
+	if err := checkCondition(condition, reflect.TypeFor[TSource]()); err != nil {
+		return nil, err
+	}
+	return func(element TSource) bool {
+		return truth(condition.evaluate(reflect.ValueOf(&element).Elem()))
+	}, nil
+}
+
+// Compiles assignments into a selector.
+//
+// # Parameters
+//
+//	assignments ...Assignment
+//
+// The fields of the result and the expressions that compute them, for example Field("Name").As("Title").
+// Without assignments, every field of TResult is copied from the field of the same name of TSource, if there is one.
+//
+// # Returns
+//
+//	result generic.ValueSelector[TSource, TResult]
+//
+// A selector that creates a TResult from an element. TResult is a struct type or a pointer to one.
+//
+// # Error
+//
+//	err error
+//
+// ErrUnknownField or ErrType if an assignment does not apply to TSource and TResult.
+func Selector[TSource any, TResult any](assignments ...Assignment) (result generic.ValueSelector[TSource, TResult], err error) {
+	projection, err := project(reflect.TypeFor[TSource](), reflect.TypeFor[TResult](), assignments)
+	if err != nil {
+		return nil, err
+	}
+	return func(element TSource) TResult {
+		return projection(reflect.ValueOf(&element).Elem()).Interface().(TResult)
+	}, nil
+}
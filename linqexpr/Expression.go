@@ -0,0 +1,221 @@
+package linqexpr
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// An expression over the fields of an element, such as Field("Age").Gt(30).
+//
+// An expression can be evaluated with Evaluate, Predicate and Selector, and inspected by a type switch on its node types:
+// FieldExpression, ConstantExpression, BinaryExpression and NotExpression.
+type Expression interface {
+
+	// Returns the expression as text, for example `(Age > 30 && Name hasPrefix "A")`.
+	String() string
+
+	// Returns the type of the value of the expression over elements of a struct type, or nil for the constant nil.
+	typeOf(element reflect.Type) (result reflect.Type, err error)
+
+	// Returns the value of the expression over an element, or the zero Value if it is nil.
+	evaluate(element reflect.Value) (result reflect.Value)
+}
+
+// The operator of a BinaryExpression.
+type Operator string
+
+const (
+	Equal          Operator = "=="
+	NotEqual       Operator = "!="
+	Less           Operator = "<"
+	LessOrEqual    Operator = "<="
+	Greater        Operator = ">"
+	GreaterOrEqual Operator = ">="
+	Contains       Operator = "contains"
+	HasPrefix      Operator = "hasPrefix"
+	HasSuffix      Operator = "hasSuffix"
+	AndAlso        Operator = "&&"
+	OrElse         Operator = "||"
+)
+
+// A field of the element, by the name of the exported struct field.
+type FieldExpression struct {
+	Name string
+}
+
+// A constant value.
+type ConstantExpression struct {
+	Value any
+}
+
+// An operator applied to two expressions.
+type BinaryExpression struct {
+	Operator Operator
+	Left     Expression
+	Right    Expression
+}
+
+// The negation of a condition.
+type NotExpression struct {
+	Operand Expression
+}
+
+// A field of the result of Select and the expression that computes it.
+type Assignment struct {
+	Name  string
+	Value Expression
+}
+
+// Returns an expression that reads a field of the element.
+//
+// # Parameters
+//
+//	name string
+//
+// The name of an exported field of the element type. The fields of embedded structs are promoted.
+//
+// # Returns
+//
+//	result FieldExpression
+//
+// An expression whose value is the field. A nil pointer field has the value nil.
+func Field(name string) (result FieldExpression) {
+	return FieldExpression{Name: name}
+}
+
+// Returns an expression whose value is the given value.
+func Constant(value any) (result ConstantExpression) {
+	return ConstantExpression{Value: value}
+}
+
+// Returns a condition that holds when all the conditions hold, or always if there are none.
+func And(conditions ...Expression) (result Expression) {
+	return combine(AndAlso, true, conditions)
+}
+
+// Returns a condition that holds when any of the conditions holds, and never if there are none.
+func Or(conditions ...Expression) (result Expression) {
+	return combine(OrElse, false, conditions)
+}
+
+// Returns a condition that holds when the condition does not.
+func Not(condition Expression) (result NotExpression) {
+	return NotExpression{Operand: condition}
+}
+
+func combine(operator Operator, empty bool, conditions []Expression) Expression {
+	if len(conditions) == 0 {
+		return Constant(empty)
+	}
+	result := conditions[0]
+	for _, condition := range conditions[1:] {
+		result = BinaryExpression{Operator: operator, Left: result, Right: condition}
+	}
+	return result
+}
+
+// Wraps a value in a ConstantExpression, unless it is already an Expression.
+func operand(value any) Expression {
+	if expression, ok := value.(Expression); ok {
+		return expression
+	}
+	return Constant(value)
+}
+
+// Returns a condition that holds when the field equals the value. The value is an Expression or a constant; nil matches nil pointers.
+func (field FieldExpression) Eq(value any) (result BinaryExpression) {
+	return BinaryExpression{Operator: Equal, Left: field, Right: operand(value)}
+}
+
+// Returns a condition that holds when the field differs from the value. The value is an Expression or a constant; nil matches non-nil pointers.
+func (field FieldExpression) Ne(value any) (result BinaryExpression) {
+	return BinaryExpression{Operator: NotEqual, Left: field, Right: operand(value)}
+}
+
+// Returns a condition that holds when the field is less than the value. The value is an Expression or a constant.
+func (field FieldExpression) Lt(value any) (result BinaryExpression) {
+	return BinaryExpression{Operator: Less, Left: field, Right: operand(value)}
+}
+
+// Returns a condition that holds when the field is less than or equal to the value. The value is an Expression or a constant.
+func (field FieldExpression) Le(value any) (result BinaryExpression) {
+	return BinaryExpression{Operator: LessOrEqual, Left: field, Right: operand(value)}
+}
+
+// Returns a condition that holds when the field is greater than the value. The value is an Expression or a constant.
+func (field FieldExpression) Gt(value any) (result BinaryExpression) {
+	return BinaryExpression{Operator: Greater, Left: field, Right: operand(value)}
+}
+
+// Returns a condition that holds when the field is greater than or equal to the value. The value is an Expression or a constant.
+func (field FieldExpression) Ge(value any) (result BinaryExpression) {
+	return BinaryExpression{Operator: GreaterOrEqual, Left: field, Right: operand(value)}
+}
+
+// Returns a condition that holds when the string field contains the value. The value is an Expression or a string.
+func (field FieldExpression) Contains(value any) (result BinaryExpression) {
+	return BinaryExpression{Operator: Contains, Left: field, Right: operand(value)}
+}
+
+// Returns a condition that holds when the string field starts with the value. The value is an Expression or a string.
+func (field FieldExpression) HasPrefix(value any) (result BinaryExpression) {
+	return BinaryExpression{Operator: HasPrefix, Left: field, Right: operand(value)}
+}
+
+// Returns a condition that holds when the string field ends with the value. The value is an Expression or a string.
+func (field FieldExpression) HasSuffix(value any) (result BinaryExpression) {
+	return BinaryExpression{Operator: HasSuffix, Left: field, Right: operand(value)}
+}
+
+// Returns an assignment of the field to the field of the result of Select with the given name.
+func (field FieldExpression) As(name string) (result Assignment) {
+	return Assignment{Name: name, Value: field}
+}
+
+// Returns a condition that holds when both this condition and the other hold.
+func (expression BinaryExpression) And(other Expression) (result BinaryExpression) {
+	return BinaryExpression{Operator: AndAlso, Left: expression, Right: other}
+}
+
+// Returns a condition that holds when this condition or the other holds.
+func (expression BinaryExpression) Or(other Expression) (result BinaryExpression) {
+	return BinaryExpression{Operator: OrElse, Left: expression, Right: other}
+}
+
+func (field FieldExpression) String() string {
+	return field.Name
+}
+
+func (constant ConstantExpression) String() string {
+	switch value := constant.Value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(value)
+	case fmt.Stringer:
+		return strconv.Quote(value.String())
+	}
+	return fmt.Sprint(constant.Value)
+}
+
+func (expression BinaryExpression) String() string {
+	text := fmt.Sprintf("%v %s %v", expression.Left, expression.Operator, expression.Right)
+	if expression.Operator == AndAlso || expression.Operator == OrElse {
+		return "(" + text + ")"
+	}
+	return text
+}
+
+func (expression NotExpression) String() string {
+	text := expression.Operand.String()
+	if !strings.HasPrefix(text, "(") {
+		text = "(" + text + ")"
+	}
+	return "!" + text
+}
+
+func (assignment Assignment) String() string {
+	return fmt.Sprintf("%v as %s", assignment.Value, assignment.Name)
+}
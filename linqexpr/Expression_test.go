@@ -0,0 +1,186 @@
+package linqexpr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type account struct {
+	Name    string
+	Email   *string
+	Age     int
+	Score   float64
+	Admin   bool
+	Created time.Time
+	Level   uint8
+	secret  string
+}
+
+type contact struct {
+	Name  string
+	Email *string
+	Years int64
+}
+
+func text(s string) *string {
+	return &s
+}
+
+func day(d int) time.Time {
+	return time.Date(2024, time.January, d, 0, 0, 0, 0, time.UTC)
+}
+
+var alice = account{Name: "Alice", Email: text("alice@example.com"), Age: 34, Score: 9.5, Admin: true, Created: day(3), Level: 3}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression Expression
+		want       any
+	}{
+		{name: "field", expression: Field("Age"), want: 34},
+		{name: "pointer field", expression: Field("Email"), want: "alice@example.com"},
+		{name: "constant", expression: Constant(7), want: 7},
+		{name: "greater", expression: Field("Age").Gt(30), want: true},
+		{name: "mixed numbers", expression: Field("Score").Ge(Field("Age")), want: false},
+		{name: "int and uint", expression: Field("Level").Lt(-1), want: false},
+		{name: "time", expression: Field("Created").Lt(day(4)), want: true},
+		{name: "prefix", expression: Field("Name").HasPrefix("A"), want: true},
+		{name: "contains", expression: Field("Email").Contains("@example"), want: true},
+		{name: "suffix", expression: Field("Name").HasSuffix("x"), want: false},
+		{name: "is nil", expression: Field("Email").Eq(nil), want: false},
+		{name: "is not nil", expression: Field("Email").Ne(nil), want: true},
+		{name: "and", expression: Field("Age").Gt(30).And(Field("Admin")), want: true},
+		{name: "or", expression: Field("Age").Lt(30).Or(Not(Field("Admin"))), want: false},
+		{name: "empty and", expression: And(), want: true},
+		{name: "empty or", expression: Or(), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Evaluate(tt.expression, alice)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluate_nil(t *testing.T) {
+	anonymous := account{Name: "Anonymous"}
+	tests := []struct {
+		name       string
+		expression Expression
+		want       any
+	}{
+		{name: "field", expression: Field("Email"), want: nil},
+		{name: "is nil", expression: Field("Email").Eq(nil), want: true},
+		{name: "equal", expression: Field("Email").Eq("x"), want: false},
+		{name: "not equal", expression: Field("Email").Ne("x"), want: false},
+		{name: "contains", expression: Field("Email").Contains(""), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Evaluate(tt.expression, &anonymous)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got, _ := Evaluate(Field("Name"), (*account)(nil)); got != nil {
+		t.Errorf("Evaluate() = %v, want nil for a nil element", got)
+	}
+}
+
+func TestEvaluate_errors(t *testing.T) {
+	tests := []struct {
+		expression Expression
+		want       error
+	}{
+		{expression: Field("Agee"), want: ErrUnknownField},
+		{expression: Field("secret"), want: ErrUnknownField},
+		{expression: Field("Age").Gt("30"), want: ErrType},
+		{expression: Field("Age").Contains("3"), want: ErrType},
+		{expression: Field("Admin").Lt(true), want: ErrType},
+		{expression: Field("Age").Eq(nil), want: ErrType},
+		{expression: Field("Email").Lt(nil), want: ErrType},
+		{expression: Field("Age").Eq(struct{}{}), want: ErrType},
+		{expression: Field("Age").Gt(1).And(Field("Name")), want: ErrType},
+		{expression: Not(Field("Age")), want: ErrType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression.String(), func(t *testing.T) {
+			if _, err := Evaluate(tt.expression, alice); !errors.Is(err, tt.want) {
+				t.Errorf("Evaluate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpression_String(t *testing.T) {
+	tests := []struct {
+		expression Expression
+		want       string
+	}{
+		{expression: Field("Age").Gt(30), want: `Age > 30`},
+		{expression: And(Field("Age").Gt(30), Field("Name").HasPrefix("A")), want: `(Age > 30 && Name hasPrefix "A")`},
+		{expression: Not(Field("Email").Eq(nil)), want: `!(Email == nil)`},
+		{expression: Not(Or(Field("Admin"), Field("Age").Gt(1))), want: `!(Admin || Age > 1)`},
+		{expression: Field("Created").Lt(day(1)), want: `Created < "2024-01-01 00:00:00 +0000 UTC"`},
+	}
+	for _, tt := range tests {
+		if got := tt.expression.String(); got != tt.want {
+			t.Errorf("Expression.String() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestPredicate(t *testing.T) {
+	predicate, err := Predicate[account](Field("Age").Ge(18).And(Field("Name").Ne("Bob")))
+	if err != nil {
+		t.Fatalf("Predicate() error = %v", err)
+	}
+	if !predicate(alice) || predicate(account{Name: "Bob", Age: 40}) || predicate(account{Name: "Carol", Age: 17}) {
+		t.Errorf("Predicate() does not match the condition")
+	}
+	if _, err := Predicate[account](Field("Age")); !errors.Is(err, ErrType) {
+		t.Errorf("Predicate() error = %v, want %v", err, ErrType)
+	}
+}
+
+func TestSelector(t *testing.T) {
+	selector, err := Selector[account, contact](Field("Name").As("Name"), Field("Email").As("Email"), Field("Age").As("Years"))
+	if err != nil {
+		t.Fatalf("Selector() error = %v", err)
+	}
+	want := contact{Name: "Alice", Email: text("alice@example.com"), Years: 34}
+	if got := selector(alice); !reflect.DeepEqual(got, want) {
+		t.Errorf("Selector() = %v, want %v", got, want)
+	}
+
+	copier, err := Selector[*account, *contact]()
+	if err != nil {
+		t.Fatalf("Selector() error = %v", err)
+	}
+	want = contact{Name: "Alice", Email: text("alice@example.com")}
+	if got := copier(&alice); !reflect.DeepEqual(*got, want) {
+		t.Errorf("Selector() = %v, want %v", *got, want)
+	}
+
+	if _, err := Selector[account, contact](Field("Name").As("Title")); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Selector() error = %v, want %v", err, ErrUnknownField)
+	}
+	if _, err := Selector[account, contact](Field("Age").As("Name")); !errors.Is(err, ErrType) {
+		t.Errorf("Selector() error = %v, want %v", err, ErrType)
+	}
+	if _, err := Selector[account, int](); !errors.Is(err, ErrType) {
+		t.Errorf("Selector() error = %v, want %v", err, ErrType)
+	}
+}
@@ -0,0 +1,107 @@
+package linqexpr
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/thereisnoplanb/linq"
+	"github.com/thereisnoplanb/linq/internal/ordering"
+)
+
+// A provider that yields the elements of an iterator and leaves every operation to be applied in memory.
+type memory[TSource any] struct {
+	source linq.Iterator[TSource]
+}
+
+func (provider memory[TSource]) Execute(ctx context.Context, query Query) (result linq.ErrorIterator[any], executed int) {
+	return func(yield func(value any, err error) bool) {
+		for item := range provider.source {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}, 0
+}
+
+// Applies the operations of a query from executed on, in memory.
+// Fails with ErrUnknownField or ErrType if a projection does not apply to the elements it receives.
+func apply(values linq.Iterator[any], query Query, executed int) (result linq.Iterator[any], err error) {
+	element := query.ElementType
+	operations := query.Operations
+	for _, operation := range operations[:executed] {
+		if operation, ok := operation.(SelectOperation); ok {
+			element = operation.ResultType
+		}
+	}
+	for i := executed; i < len(operations); i++ {
+		switch operation := operations[i].(type) {
+		case WhereOperation:
+			values = values.Where(func(item any) bool {
+				return truth(operation.Condition.evaluate(reflect.ValueOf(item)))
+			})
+		case OrderOperation:
+			keys := []OrderOperation{operation}
+		earlier:
+			for j := i - 1; j >= 0 && keys[0].Then; j-- {
+				switch previous := operations[j].(type) {
+				case WhereOperation:
+					// Filtering does not change the order of the elements it keeps, so the ordering continues past it, as in SQL.
+				case OrderOperation:
+					keys = append([]OrderOperation{previous}, keys...)
+				default:
+					break earlier
+				}
+			}
+			for i+1 < len(operations) {
+				next, ok := operations[i+1].(OrderOperation)
+				if !ok || !next.Then {
+					break
+				}
+				keys = append(keys, next)
+				i++
+			}
+			values = values.Order(func(x any, y any) int {
+				return compareKeys(keys, reflect.ValueOf(x), reflect.ValueOf(y))
+			})
+		case SkipOperation:
+			values = values.Skip(operation.Count)
+		case TakeOperation:
+			values = values.Take(operation.Count)
+		case SelectOperation:
+			projection, err := project(element, operation.ResultType, operation.Assignments)
+			if err != nil {
+				return nil, err
+			}
+			values = linq.Select(values, func(item any) any {
+				return projection(reflect.ValueOf(item)).Interface()
+			})
+			element = operation.ResultType
+		}
+	}
+	return values, nil
+}
+
+// Compares two elements by the keys of an ordering. Nil keys are less than all others.
+func compareKeys(keys []OrderOperation, x reflect.Value, y reflect.Value) int {
+	for _, key := range keys {
+		first, second := key.Key.evaluate(x), key.Key.evaluate(y)
+		order := 0
+		switch {
+		case !first.IsValid() || !second.IsValid():
+			order = ordering.BoolRank(first.IsValid()) - ordering.BoolRank(second.IsValid())
+		default:
+			order = ordering.Compare(first, second)
+		}
+		if key.Descending {
+			order = -order
+		}
+		if order != 0 {
+			return order
+		}
+	}
+	return 0
+}
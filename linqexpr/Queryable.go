@@ -0,0 +1,320 @@
+package linqexpr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+// An operation of a query: a WhereOperation, an OrderOperation, a SkipOperation, a TakeOperation or a SelectOperation.
+type Operation interface {
+
+	// Returns the operation as text, for example `Where(Age > 30)`.
+	String() string
+
+	operation()
+}
+
+// Keeps the elements for which the condition holds.
+type WhereOperation struct {
+	Condition Expression
+}
+
+// Sorts the elements by a key. Unless Then is set, the operation replaces the keys of the orderings before it.
+type OrderOperation struct {
+	Key        Expression
+	Descending bool
+	Then       bool
+}
+
+// Bypasses Count elements.
+type SkipOperation struct {
+	Count int
+}
+
+// Keeps at most Count elements.
+type TakeOperation struct {
+	Count int
+}
+
+// Creates a value of ResultType from each element, as the assignments say.
+type SelectOperation struct {
+	Assignments []Assignment
+	ResultType  reflect.Type
+}
+
+func (WhereOperation) operation()  {}
+func (OrderOperation) operation()  {}
+func (SkipOperation) operation()   {}
+func (TakeOperation) operation()   {}
+func (SelectOperation) operation() {}
+
+func (operation WhereOperation) String() string {
+	return fmt.Sprintf("Where(%v)", operation.Condition)
+}
+
+func (operation OrderOperation) String() string {
+	name := "OrderBy"
+	if operation.Then {
+		name = "ThenBy"
+	}
+	if operation.Descending {
+		name += "Descending"
+	}
+	return fmt.Sprintf("%s(%v)", name, operation.Key)
+}
+
+func (operation SkipOperation) String() string {
+	return fmt.Sprintf("Skip(%d)", operation.Count)
+}
+
+func (operation TakeOperation) String() string {
+	return fmt.Sprintf("Take(%d)", operation.Count)
+}
+
+func (operation SelectOperation) String() string {
+	assignments := make([]string, len(operation.Assignments))
+	for i, assignment := range operation.Assignments {
+		assignments[i] = assignment.String()
+	}
+	return fmt.Sprintf("Select[%v](%s)", operation.ResultType, strings.Join(assignments, ", "))
+}
+
+// A query as a Provider sees it: the type of the elements of the source and the operations applied to them, in order.
+type Query struct {
+	ElementType reflect.Type
+	Operations  []Operation
+}
+
+// Returns the operations of the query as text, for example `Where(Age > 30).OrderBy(Name).Take(10)`.
+func (query Query) String() string {
+	operations := make([]string, len(query.Operations))
+	for i, operation := range query.Operations {
+		operations[i] = operation.String()
+	}
+	return strings.Join(operations, ".")
+}
+
+// Checks that every operation applies to the elements it receives.
+func (query Query) check() error {
+	element := query.ElementType
+	for _, operation := range query.Operations {
+		switch operation := operation.(type) {
+		case WhereOperation:
+			if err := checkCondition(operation.Condition, element); err != nil {
+				return err
+			}
+		case OrderOperation:
+			if err := checkKey(operation.Key, element); err != nil {
+				return err
+			}
+		case SelectOperation:
+			if _, err := project(element, operation.ResultType, operation.Assignments); err != nil {
+				return err
+			}
+			element = operation.ResultType
+		}
+	}
+	return nil
+}
+
+// Runs queries against a data source.
+type Provider interface {
+
+	// Runs a prefix of the operations of a query at the source.
+	//
+	// The elements are values of the element type of the query, or of the result type of the last executed SelectOperation.
+	// The operations from executed on are applied in memory, so a provider that cannot translate an operation
+	// stops before it. A provider that translates nothing returns the elements of the source and 0.
+	//
+	// The operations of the query have been checked against its element type.
+	Execute(ctx context.Context, query Query) (result linq.ErrorIterator[any], executed int)
+}
+
+// A query whose operations are kept as data, so that a Provider can run them at the source, for example as SQL.
+//
+// A Queryable is immutable: every method returns a new Queryable and leaves the receiver as it was.
+type Queryable[TSource any] struct {
+	provider Provider
+	query    Query
+}
+
+// Creates a Queryable[TSource] over the elements of a provider.
+//
+// # Parameters
+//
+//	provider Provider
+//
+// The provider that runs the query, for example the SQL provider of the linqsql package.
+//
+// # Returns
+//
+//	result Queryable[TSource]
+//
+// A query with no operations. TSource is a struct type or a pointer to one.
+func NewQueryable[TSource any](provider Provider) (result Queryable[TSource]) {
+	return Queryable[TSource]{
+		provider: provider,
+		query: Query{
+			ElementType: reflect.TypeFor[TSource](),
+		},
+	}
+}
+
+// Creates a Queryable[TSource] over an Iterator[TSource], which runs all its operations in memory.
+//
+// # Parameters
+//
+//	source linq.Iterator[TSource]
+//
+// The sequence to query. TSource is a struct type or a pointer to one.
+//
+// # Returns
+//
+//	result Queryable[TSource]
+//
+// A query with no operations over the elements of source.
+func AsQueryable[TSource any](source linq.Iterator[TSource]) (result Queryable[TSource]) {
+	return NewQueryable[TSource](memory[TSource]{source})
+}
+
+func (source Queryable[TSource]) with(operation Operation) Queryable[TSource] {
+	source.query.Operations = append(slices.Clip(source.query.Operations), operation)
+	return source
+}
+
+// Filters the elements by a condition, such as Field("Age").Gt(30).
+func (source Queryable[TSource]) Where(condition Expression) (result Queryable[TSource]) {
+	return source.with(WhereOperation{Condition: condition})
+}
+
+// Sorts the elements by a key in ascending order, replacing any previous ordering. Nil keys sort first.
+func (source Queryable[TSource]) OrderBy(key Expression) (result Queryable[TSource]) {
+	return source.with(OrderOperation{Key: key})
+}
+
+// Sorts the elements by a key in descending order, replacing any previous ordering. Nil keys sort last.
+func (source Queryable[TSource]) OrderByDescending(key Expression) (result Queryable[TSource]) {
+	return source.with(OrderOperation{Key: key, Descending: true})
+}
+
+// Sorts the elements that have equal keys in the previous ordering by another key in ascending order.
+// Where operations between the ordering and ThenBy do not end the ordering; Skip, Take and Select do.
+// Without a previous ordering, ThenBy is the same as OrderBy.
+func (source Queryable[TSource]) ThenBy(key Expression) (result Queryable[TSource]) {
+	return source.with(OrderOperation{Key: key, Then: true})
+}
+
+// Sorts the elements that have equal keys in the previous ordering by another key in descending order.
+// Where operations between the ordering and ThenByDescending do not end the ordering; Skip, Take and Select do.
+// Without a previous ordering, ThenByDescending is the same as OrderByDescending.
+func (source Queryable[TSource]) ThenByDescending(key Expression) (result Queryable[TSource]) {
+	return source.with(OrderOperation{Key: key, Descending: true, Then: true})
+}
+
+// Bypasses a number of elements.
+func (source Queryable[TSource]) Skip(count int) (result Queryable[TSource]) {
+	return source.with(SkipOperation{Count: count})
+}
+
+// Keeps at most a number of elements.
+func (source Queryable[TSource]) Take(count int) (result Queryable[TSource]) {
+	return source.with(TakeOperation{Count: count})
+}
+
+// Returns the query as its provider sees it.
+func (source Queryable[TSource]) Query() (result Query) {
+	result = source.query
+	result.Operations = slices.Clone(result.Operations)
+	return result
+}
+
+// Returns the operations of the query as text.
+func (source Queryable[TSource]) String() string {
+	return source.query.String()
+}
+
+// Runs the query.
+//
+// # Parameters
+//
+//	ctx context.Context
+//
+// The context of the query, passed to the provider.
+//
+// # Returns
+//
+//	result linq.ErrorIterator[TSource]
+//
+// The elements the query selects. Every enumeration runs the query again.
+//
+// # Error
+//
+// The enumeration fails with ErrUnknownField or ErrType if an operation does not apply to the elements it receives,
+// and with the error of the provider if the source fails.
+//
+// # Remarks
+//
+// The provider runs the operations it can translate; the rest are applied in memory with Where, Order, Skip, Take and Select.
+// If the source fails after an in-memory ordering has begun, the elements sorted so far are yielded before the error.
+func (source Queryable[TSource]) Iterator(ctx context.Context) (result linq.ErrorIterator[TSource]) {
+	query := source.Query()
+	return func(yield func(value TSource, err error) bool) {
+		var zero TSource
+		if err := query.check(); err != nil {
+			yield(zero, err)
+			return
+		}
+		elements, executed := source.provider.Execute(ctx, query)
+		values, failure := elements.Values()
+		values, err := apply(values, query, executed)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		for item := range values {
+			if !yield(item.(TSource), nil) {
+				return
+			}
+		}
+		if err := failure(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// Projects the elements of a query into a new form.
+//
+// # Parameters
+//
+//	source Queryable[TSource]
+//
+// The query to project.
+//
+//	assignments ...Assignment
+//
+// The fields of the result and the expressions that compute them, for example Field("Name").As("Title").
+// Without assignments, every field of TResult is copied from the field of the same name of TSource, if there is one.
+//
+// # Returns
+//
+//	result Queryable[TResult]
+//
+// A query whose elements are values of TResult, a struct type or a pointer to one.
+func Select[TSource any, TResult any](source Queryable[TSource], assignments ...Assignment) (result Queryable[TResult]) {
+	operation := SelectOperation{
+		Assignments: slices.Clone(assignments),
+		ResultType:  reflect.TypeFor[TResult](),
+	}
+	return Queryable[TResult]{
+		provider: source.provider,
+		query: Query{
+			ElementType: source.query.ElementType,
+			Operations:  append(slices.Clip(source.query.Operations), operation),
+		},
+	}
+}
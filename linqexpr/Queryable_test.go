@@ -0,0 +1,216 @@
+package linqexpr
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+var accounts = []account{
+	{Name: "Alice", Age: 34, Score: 9.5, Admin: true, Created: day(3)},
+	{Name: "Bob", Email: text("bob@example.com"), Age: 25, Score: 7, Created: day(1)},
+	{Name: "Anna", Age: 41, Score: 8.25, Created: day(5)},
+	{Name: "Carol", Email: text("carol@example.com"), Age: 30, Score: 7, Admin: true, Created: day(2)},
+	{Name: "Adam", Age: 19, Score: 6.5, Created: day(4)},
+}
+
+func names(t *testing.T, source linq.ErrorIterator[account]) []string {
+	t.Helper()
+	items, err := source.ToSlice()
+	if err != nil {
+		t.Fatalf("Queryable.Iterator() error = %v", err)
+	}
+	result := make([]string, len(items))
+	for i, item := range items {
+		result[i] = item.Name
+	}
+	return result
+}
+
+func TestQueryable(t *testing.T) {
+	source := AsQueryable(linq.FromSlice(accounts))
+	tests := []struct {
+		name  string
+		query Queryable[account]
+		want  []string
+	}{
+		{
+			name:  "no operations",
+			query: source,
+			want:  []string{"Alice", "Bob", "Anna", "Carol", "Adam"},
+		},
+		{
+			name:  "where, order and take",
+			query: source.Where(Field("Age").Gt(20).And(Field("Name").HasPrefix("A"))).OrderByDescending(Field("Created")).Take(10),
+			want:  []string{"Anna", "Alice"},
+		},
+		{
+			name:  "then by",
+			query: source.OrderBy(Field("Score")).ThenByDescending(Field("Name")),
+			want:  []string{"Adam", "Carol", "Bob", "Anna", "Alice"},
+		},
+		{
+			name:  "then by after where",
+			query: source.OrderBy(Field("Score")).Where(Field("Age").Gt(20)).ThenBy(Field("Name")),
+			want:  []string{"Bob", "Carol", "Anna", "Alice"},
+		},
+		{
+			name:  "order by replaces the ordering",
+			query: source.OrderBy(Field("Score")).OrderBy(Field("Age")),
+			want:  []string{"Adam", "Bob", "Carol", "Alice", "Anna"},
+		},
+		{
+			name:  "nil keys sort first",
+			query: source.OrderBy(Field("Email")).ThenBy(Field("Name")),
+			want:  []string{"Adam", "Alice", "Anna", "Bob", "Carol"},
+		},
+		{
+			name:  "skip and take",
+			query: source.OrderBy(Field("Name")).Skip(1).Take(3),
+			want:  []string{"Alice", "Anna", "Bob"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(t, tt.query.Iterator(context.Background())); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Queryable.Iterator() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryable_immutable(t *testing.T) {
+	source := AsQueryable(linq.FromSlice(accounts)).Where(Field("Admin"))
+	first := source.OrderBy(Field("Name"))
+	second := source.OrderByDescending(Field("Name"))
+	if got, want := first.String(), `Where(Admin).OrderBy(Name)`; got != want {
+		t.Errorf("Queryable.String() = %v, want %v", got, want)
+	}
+	if got, want := second.String(), `Where(Admin).OrderByDescending(Name)`; got != want {
+		t.Errorf("Queryable.String() = %v, want %v", got, want)
+	}
+	query := first.Query()
+	query.Operations[0] = TakeOperation{}
+	if got, want := first.String(), `Where(Admin).OrderBy(Name)`; got != want {
+		t.Errorf("Queryable.Query() shares its operations: %v", got)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	source := AsQueryable(linq.FromSlice(accounts)).Where(Field("Email").Ne(nil))
+	query := Select[account, contact](source, Field("Name").As("Name"), Field("Email").As("Email"), Field("Age").As("Years"))
+	got, err := query.OrderBy(Field("Years")).Iterator(context.Background()).ToSlice()
+	if err != nil {
+		t.Fatalf("Queryable.Iterator() error = %v", err)
+	}
+	want := []contact{
+		{Name: "Bob", Email: text("bob@example.com"), Years: 25},
+		{Name: "Carol", Email: text("carol@example.com"), Years: 30},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Queryable.Iterator() = %v, want %v", got, want)
+	}
+	if got, want := query.String(), `Where(Email != nil).Select[linqexpr.contact](Name as Name, Email as Email, Age as Years)`; got != want {
+		t.Errorf("Queryable.String() = %v, want %v", got, want)
+	}
+}
+
+func TestQueryable_errors(t *testing.T) {
+	source := AsQueryable(linq.FromSlice(accounts))
+	tests := []struct {
+		name  string
+		query Queryable[account]
+		want  error
+	}{
+		{name: "unknown field", query: source.Where(Field("Agee").Gt(1)), want: ErrUnknownField},
+		{name: "not a condition", query: source.Where(Field("Age")), want: ErrType},
+		{name: "unordered key", query: source.OrderBy(Constant(nil)), want: ErrType},
+		{name: "field of the result", query: Select[account, account](source.Take(1), Field("Name").As("Name")).Where(Field("Age").Gt(1)), want: nil},
+		{name: "field of the source after select", query: Select[contact, account](AsQueryable(linq.FromSlice([]contact{})).Where(Field("Years").Gt(1))), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.query.Iterator(context.Background()).ToSlice(); !errors.Is(err, tt.want) {
+				t.Errorf("Queryable.Iterator() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	projected := Select[account, contact](source).Where(Field("Age").Gt(1))
+	if _, err := projected.Iterator(context.Background()).ToSlice(); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Queryable.Iterator() error = %v, want %v", err, ErrUnknownField)
+	}
+}
+
+func TestQueryable_context(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := AsQueryable(linq.FromSlice(accounts)).Iterator(ctx).ToSlice(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Queryable.Iterator() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+// A provider that runs a fixed number of operations in memory, as a translating provider would at the source.
+type partial struct {
+	source   []account
+	executed int
+	err      error
+	queries  []Query
+}
+
+func (provider *partial) Execute(ctx context.Context, query Query) (result linq.ErrorIterator[any], executed int) {
+	provider.queries = append(provider.queries, query)
+	executed = min(provider.executed, len(query.Operations))
+	values, err := apply(linq.Select(linq.FromSlice(provider.source), func(item account) any { return item }), Query{ElementType: query.ElementType, Operations: query.Operations[:executed]}, 0)
+	return func(yield func(value any, err error) bool) {
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for item := range values {
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if provider.err != nil {
+			yield(nil, provider.err)
+		}
+	}, executed
+}
+
+func TestQueryable_fallback(t *testing.T) {
+	query := func(provider Provider) Queryable[contact] {
+		source := NewQueryable[account](provider).
+			Where(Field("Age").Ge(20)).
+			OrderBy(Field("Score")).
+			ThenBy(Field("Name")).
+			Skip(1)
+		return Select[account, contact](source, Field("Name").As("Name"), Field("Age").As("Years")).Take(2)
+	}
+	want := []contact{{Name: "Carol", Years: 30}, {Name: "Anna", Years: 41}}
+	for executed := range 7 {
+		provider := &partial{source: accounts, executed: executed}
+		got, err := query(provider).Iterator(context.Background()).ToSlice()
+		if err != nil {
+			t.Fatalf("Queryable.Iterator() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Queryable.Iterator() with %d operations at the source = %v, want %v", executed, got, want)
+		}
+		if len(provider.queries) != 1 || provider.queries[0].ElementType != reflect.TypeFor[account]() || len(provider.queries[0].Operations) != 6 {
+			t.Errorf("Provider.Execute() got %v, want the whole query", provider.queries)
+		}
+	}
+
+	failure := errors.New("connection lost")
+	provider := &partial{source: accounts, executed: 1, err: failure}
+	got, err := query(provider).Iterator(context.Background()).ToSlice()
+	if !errors.Is(err, failure) {
+		t.Errorf("Queryable.Iterator() error = %v, want %v", err, failure)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Queryable.Iterator() = %v, want %v before the error", got, want)
+	}
+}
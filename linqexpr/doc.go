@@ -0,0 +1,12 @@
+// Package linqexpr describes queries as data, so that they can run in memory or be translated for a data source.
+//
+// Expressions such as
+//
+//	linqexpr.Field("Age").Gt(30).And(linqexpr.Field("Name").HasPrefix("A"))
+//
+// can be evaluated against structs with Evaluate, Predicate and Selector, and inspected by a type switch on their nodes.
+//
+// A Queryable[T] records Where, OrderBy, ThenBy, Skip, Take and Select as operations and hands them to a Provider when it
+// is enumerated. The provider runs at the source the operations it can translate, and the rest run in memory with the
+// operators of the linq package. AsQueryable runs everything in memory; the linqsql package translates queries to SQL.
+package linqexpr
@@ -0,0 +1,64 @@
+package linqquery
+
+import (
+	"container/list"
+	"reflect"
+	"sync"
+)
+
+// The greatest number of compiled queries Compile keeps. When the cache is full, the query used least recently is dropped.
+const CacheSize = 256
+
+type cacheKey struct {
+	typ  reflect.Type
+	text string
+}
+
+type cacheEntry struct {
+	key   cacheKey
+	query any
+}
+
+// A least recently used cache of compiled queries, keyed by element type and text.
+type cache struct {
+	mutex    sync.Mutex
+	capacity int
+	entries  map[cacheKey]*list.Element
+	order    *list.List
+}
+
+var compiled = newCache(CacheSize)
+
+func newCache(capacity int) *cache {
+	return &cache{
+		capacity: capacity,
+		entries:  make(map[cacheKey]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *cache) load(key cacheKey) (query any, ok bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*cacheEntry).query, true
+}
+
+func (c *cache) store(key cacheKey, query any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key, query})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
@@ -0,0 +1,56 @@
+package linqquery
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+var ErrSyntax = errors.New("syntax error")
+var ErrUnknownField = errors.New("unknown field")
+var ErrType = errors.New("type mismatch")
+var ErrParameter = errors.New("invalid parameter")
+var ErrElementType = errors.New("the element type is not a struct or a pointer to a struct")
+
+// An error in a query, located at the position of the text that caused it.
+type Error struct {
+
+	// The byte offset of the text that caused the error in the query, starting at 0.
+	Offset int
+
+	// The column of the text that caused the error in the query, counted in characters, not bytes, starting at 1.
+	Column int
+
+	// What went wrong, for example `"Agee" is not a field of User`.
+	Message string
+
+	// The kind of the error: ErrSyntax, ErrUnknownField, ErrType or ErrParameter.
+	Err error
+}
+
+// Returns the error formatted as "<kind> at column <Column>: <Message>".
+func (err *Error) Error() string {
+	return fmt.Sprintf("%v at column %d: %s", err.Err, err.Column, err.Message)
+}
+
+// Returns the kind of the error, so that errors.Is(err, ErrType) and the like report it.
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// Sets the Column of err from its Offset in text, if err is an *Error, and returns err.
+func located(err error, text string) error {
+	var failure *Error
+	if errors.As(err, &failure) {
+		failure.Column = utf8.RuneCountInString(text[:failure.Offset]) + 1
+	}
+	return err
+}
+
+func errorAt(offset int, kind error, format string, args ...any) *Error {
+	return &Error{
+		Offset:  offset,
+		Message: fmt.Sprintf(format, args...),
+		Err:     kind,
+	}
+}
@@ -0,0 +1,109 @@
+package linqquery
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenIdentifier
+	tokenNumber
+	tokenString
+	tokenParameter
+	tokenSymbol
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+// The symbols of the language, longest first so that "<=" is not read as "<".
+var symbols = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", ","}
+
+// Splits a query into tokens.
+func tokenize(text string) (tokens []token, err error) {
+	for offset := 0; ; {
+		for offset < len(text) {
+			r, size := utf8.DecodeRuneInString(text[offset:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			offset += size
+		}
+		if offset == len(text) {
+			return append(tokens, token{kind: tokenEnd, offset: offset}), nil
+		}
+		start := offset
+		r, _ := utf8.DecodeRuneInString(text[offset:])
+		switch {
+		case r == '"':
+			end := start + 1
+			for end < len(text) && text[end] != '"' {
+				if text[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(text) {
+				return nil, errorAt(start, ErrSyntax, "unterminated string")
+			}
+			value, err := strconv.Unquote(text[start : end+1])
+			if err != nil {
+				return nil, errorAt(start, ErrSyntax, "invalid string %s", text[start:end+1])
+			}
+			tokens = append(tokens, token{kind: tokenString, text: value, offset: start})
+			offset = end + 1
+		case r == '@':
+			offset = scanWord(text, offset+1)
+			if offset == start+1 {
+				return nil, errorAt(start, ErrSyntax, "missing parameter name after @")
+			}
+			tokens = append(tokens, token{kind: tokenParameter, text: text[start+1 : offset], offset: start})
+		case isDigit(r) || (r == '-' && start+1 < len(text) && isDigit(rune(text[start+1]))):
+			offset++
+			for offset < len(text) && (isDigit(rune(text[offset])) || text[offset] == '.') {
+				offset++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text[start:offset], offset: start})
+		case r == '_' || unicode.IsLetter(r):
+			offset = scanWord(text, offset)
+			tokens = append(tokens, token{kind: tokenIdentifier, text: text[start:offset], offset: start})
+		default:
+			symbol := ""
+			for _, s := range symbols {
+				if strings.HasPrefix(text[offset:], s) {
+					symbol = s
+					break
+				}
+			}
+			if symbol == "" {
+				return nil, errorAt(start, ErrSyntax, "unexpected character %q", r)
+			}
+			tokens = append(tokens, token{kind: tokenSymbol, text: symbol, offset: start})
+			offset += len(symbol)
+		}
+	}
+}
+
+// Returns the offset after the letters, digits and underscores that start at offset.
+func scanWord(text string, offset int) int {
+	for offset < len(text) {
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		offset += size
+	}
+	return offset
+}
+
+func isDigit(r rune) bool {
+	return '0' <= r && r <= '9'
+}
@@ -0,0 +1,454 @@
+package linqquery
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thereisnoplanb/linq/internal/ordering"
+)
+
+// The words of the language. They cannot be used as field names; give such a field another name with a linq tag.
+var keywords = map[string]bool{
+	"where": true, "orderby": true, "asc": true, "desc": true, "skip": true, "take": true, "select": true,
+	"and": true, "or": true, "not": true, "contains": true, "startswith": true, "endswith": true, "true": true, "false": true,
+}
+
+var comparisons = map[string]bool{"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true}
+
+// The formats a string literal compared with a time.Time field may have.
+var timeLayouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+
+// A value in a condition: a field of the element, a literal or a parameter.
+type operand struct {
+	offset    int
+	text      string
+	kind      kind
+	field     *field
+	value     reflect.Value
+	parameter int
+}
+
+func (o *operand) get(element reflect.Value, arguments []reflect.Value) reflect.Value {
+	switch {
+	case o.field != nil:
+		return element.FieldByIndex(o.field.index)
+	case o.parameter >= 0:
+		return arguments[o.parameter]
+	}
+	return o.value
+}
+
+// A compiled where clause.
+type condition interface {
+	test(element reflect.Value, arguments []reflect.Value) bool
+}
+
+type and struct {
+	left, right condition
+}
+
+func (c and) test(element reflect.Value, arguments []reflect.Value) bool {
+	return c.left.test(element, arguments) && c.right.test(element, arguments)
+}
+
+type or struct {
+	left, right condition
+}
+
+func (c or) test(element reflect.Value, arguments []reflect.Value) bool {
+	return c.left.test(element, arguments) || c.right.test(element, arguments)
+}
+
+type not struct {
+	operand condition
+}
+
+func (c not) test(element reflect.Value, arguments []reflect.Value) bool {
+	return !c.operand.test(element, arguments)
+}
+
+// A bool operand used as a condition on its own.
+type truth struct {
+	operand *operand
+}
+
+func (c truth) test(element reflect.Value, arguments []reflect.Value) bool {
+	return c.operand.get(element, arguments).Bool()
+}
+
+type comparison struct {
+	operator    string
+	left, right *operand
+}
+
+func (c comparison) test(element reflect.Value, arguments []reflect.Value) bool {
+	x, y := c.left.get(element, arguments), c.right.get(element, arguments)
+	switch c.operator {
+	case "contains":
+		return strings.Contains(x.String(), y.String())
+	case "startswith":
+		return strings.HasPrefix(x.String(), y.String())
+	case "endswith":
+		return strings.HasSuffix(x.String(), y.String())
+	}
+	order := ordering.Compare(x, y)
+	switch c.operator {
+	case "==":
+		return order == 0
+	case "!=":
+		return order != 0
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	case ">":
+		return order > 0
+	}
+	return order >= 0
+}
+
+// A named placeholder for a value supplied when the query is run.
+type parameter struct {
+	name   string
+	offset int
+	kind   kind
+}
+
+type orderKey struct {
+	field      *field
+	descending bool
+}
+
+// The clauses of a query, checked against the fields of the element type.
+type plan struct {
+	where      condition
+	order      []orderKey
+	skip       *operand
+	take       *operand
+	columns    []*field
+	parameters []parameter
+}
+
+type parser struct {
+	tokens   []token
+	position int
+	typ      reflect.Type
+	fields   map[string]*field
+	all      []*field
+	plan     plan
+	named    map[string]int
+}
+
+// Parses a query over the elements of a struct type and checks it against the fields of the type.
+func parse(text string, typ reflect.Type) (result plan, err error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return result, err
+	}
+	p := &parser{
+		tokens: tokens,
+		typ:    typ,
+		fields: make(map[string]*field),
+		named:  make(map[string]int),
+	}
+	for _, f := range fieldsOf(typ) {
+		p.fields[f.name] = &f
+		p.all = append(p.all, &f)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			failure, ok := r.(*Error)
+			if !ok {
+				panic(r)
+			}
+			err = failure
+		}
+	}()
+	p.parseQuery()
+	return p.plan, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.position]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.position]
+	if t.kind != tokenEnd {
+		p.position++
+	}
+	return t
+}
+
+func (p *parser) fail(offset int, kind error, format string, args ...any) {
+	panic(errorAt(offset, kind, format, args...))
+}
+
+// Reports whether the next token is the keyword, and consumes it if so.
+func (p *parser) acceptKeyword(keyword string) bool {
+	if t := p.peek(); t.kind == tokenIdentifier && strings.EqualFold(t.text, keyword) {
+		p.position++
+		return true
+	}
+	return false
+}
+
+// Reports whether the next token is the symbol, and consumes it if so.
+func (p *parser) acceptSymbol(symbol string) bool {
+	if t := p.peek(); t.kind == tokenSymbol && t.text == symbol {
+		p.position++
+		return true
+	}
+	return false
+}
+
+func describe(t token) string {
+	switch t.kind {
+	case tokenEnd:
+		return "end of query"
+	case tokenString:
+		return strconv.Quote(t.text)
+	case tokenParameter:
+		return "@" + t.text
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func (p *parser) parseQuery() {
+	if p.acceptKeyword("where") {
+		p.plan.where = p.parseOr()
+	}
+	if p.acceptKeyword("orderby") {
+		for {
+			key := orderKey{field: p.parseField()}
+			if key.field.kind == kindNone {
+				p.fail(p.tokens[p.position-1].offset, ErrType, "%s of type %v is not ordered", key.field.name, key.field.typ)
+			}
+			if p.acceptKeyword("desc") {
+				key.descending = true
+			} else {
+				p.acceptKeyword("asc")
+			}
+			p.plan.order = append(p.plan.order, key)
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+	}
+	if p.acceptKeyword("skip") {
+		p.plan.skip = p.parseCount()
+	}
+	if p.acceptKeyword("take") {
+		p.plan.take = p.parseCount()
+	}
+	if p.acceptKeyword("select") {
+		for {
+			p.plan.columns = append(p.plan.columns, p.parseField())
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+	} else {
+		p.plan.columns = p.all
+	}
+	if t := p.peek(); t.kind != tokenEnd {
+		p.fail(t.offset, ErrSyntax, "unexpected %s", describe(t))
+	}
+}
+
+func (p *parser) parseField() *field {
+	t := p.next()
+	if t.kind != tokenIdentifier || keywords[strings.ToLower(t.text)] {
+		p.fail(t.offset, ErrSyntax, "expected a field name, found %s", describe(t))
+	}
+	f, ok := p.fields[t.text]
+	if !ok {
+		p.fail(t.offset, ErrUnknownField, "%q is not a field of %v", t.text, p.typ)
+	}
+	return f
+}
+
+func (p *parser) parseCount() *operand {
+	t := p.peek()
+	if t.kind != tokenNumber && t.kind != tokenParameter {
+		p.fail(t.offset, ErrSyntax, "expected a count, found %s", describe(t))
+	}
+	count := p.parseOperand()
+	if count.kind == kindNone {
+		p.typeParameter(count, kindInt)
+	}
+	if count.kind != kindInt {
+		p.fail(t.offset, ErrType, "the count must be an integer, found %s", count.text)
+	}
+	return count
+}
+
+func (p *parser) parseOr() condition {
+	left := p.parseAnd()
+	for p.acceptSymbol("||") || p.acceptKeyword("or") {
+		left = or{left, p.parseAnd()}
+	}
+	return left
+}
+
+func (p *parser) parseAnd() condition {
+	left := p.parseNot()
+	for p.acceptSymbol("&&") || p.acceptKeyword("and") {
+		left = and{left, p.parseNot()}
+	}
+	return left
+}
+
+func (p *parser) parseNot() condition {
+	if p.acceptSymbol("!") || p.acceptKeyword("not") {
+		return not{p.parseNot()}
+	}
+	if p.acceptSymbol("(") {
+		inner := p.parseOr()
+		if t := p.next(); t.kind != tokenSymbol || t.text != ")" {
+			p.fail(t.offset, ErrSyntax, "expected \")\", found %s", describe(t))
+		}
+		return inner
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() condition {
+	left := p.parseOperand()
+	t := p.peek()
+	operator := ""
+	switch {
+	case t.kind == tokenSymbol && comparisons[t.text]:
+		operator = t.text
+	case t.kind == tokenIdentifier:
+		switch lower := strings.ToLower(t.text); lower {
+		case "contains", "startswith", "endswith":
+			operator = lower
+		}
+	}
+	if operator == "" {
+		if left.kind == kindNone {
+			p.typeParameter(left, kindBool)
+		}
+		if left.kind != kindBool {
+			p.fail(left.offset, ErrType, "%s is a %s, not a condition", left.text, left.kind)
+		}
+		return truth{left}
+	}
+	p.position++
+	right := p.parseOperand()
+	p.check(t, operator, left, right)
+	return comparison{operator, left, right}
+}
+
+// Checks that the operands of a comparison have compatible types, and gives parameters and time literals their types.
+func (p *parser) check(t token, operator string, left *operand, right *operand) {
+	for _, o := range []*operand{left, right} {
+		if o.field != nil && o.kind == kindNone {
+			p.fail(o.offset, ErrType, "%s of type %v cannot be compared", o.text, o.field.typ)
+		}
+	}
+	switch {
+	case left.kind == kindNone && right.kind == kindNone:
+		p.fail(left.offset, ErrType, "cannot infer the types of %s and %s", left.text, right.text)
+	case left.kind == kindNone:
+		p.typeParameter(left, right.kind)
+	case right.kind == kindNone:
+		p.typeParameter(right, left.kind)
+	}
+	p.convertTime(left, right)
+	p.convertTime(right, left)
+	if !left.kind.compatible(right.kind) {
+		p.fail(t.offset, ErrType, "cannot compare %s (%s) with %s (%s)", left.text, left.kind, right.text, right.kind)
+	}
+	switch operator {
+	case "contains", "startswith", "endswith":
+		if left.kind != kindString {
+			p.fail(t.offset, ErrType, "%s applies to strings, not to %s (%s)", t.text, left.text, left.kind)
+		}
+	case "<", "<=", ">", ">=":
+		if left.kind == kindBool {
+			p.fail(t.offset, ErrType, "%s does not apply to bool values", t.text)
+		}
+	}
+}
+
+// Parses a string literal compared with a time as a time.
+func (p *parser) convertTime(literal *operand, other *operand) {
+	if literal.kind != kindString || other.kind != kindTime || literal.field != nil || literal.parameter >= 0 {
+		return
+	}
+	for _, layout := range timeLayouts {
+		if value, err := time.Parse(layout, literal.value.String()); err == nil {
+			literal.kind = kindTime
+			literal.value = reflect.ValueOf(value)
+			return
+		}
+	}
+	p.fail(literal.offset, ErrType, "cannot parse %s as a time", literal.text)
+}
+
+// Gives an untyped parameter a type, and checks that every use of a parameter has the same type.
+func (p *parser) typeParameter(o *operand, k kind) {
+	declared := &p.plan.parameters[o.parameter]
+	if declared.kind != kindNone && !declared.kind.compatible(k) {
+		p.fail(o.offset, ErrType, "%s is used as a %s and as a %s", o.text, declared.kind, k)
+	}
+	if declared.kind == kindNone || k == kindFloat {
+		declared.kind = k
+	}
+	o.kind = k
+}
+
+func (p *parser) parseOperand() *operand {
+	t := p.next()
+	o := &operand{
+		offset:    t.offset,
+		text:      t.text,
+		parameter: -1,
+	}
+	switch t.kind {
+	case tokenNumber:
+		if strings.Contains(t.text, ".") {
+			value, err := strconv.ParseFloat(t.text, 64)
+			if err != nil {
+				p.fail(t.offset, ErrSyntax, "invalid number %s", t.text)
+			}
+			o.kind, o.value = kindFloat, reflect.ValueOf(value)
+		} else {
+			value, err := strconv.ParseInt(t.text, 10, 64)
+			if err != nil {
+				p.fail(t.offset, ErrSyntax, "invalid integer %s", t.text)
+			}
+			o.kind, o.value = kindInt, reflect.ValueOf(value)
+		}
+	case tokenString:
+		o.text = strconv.Quote(t.text)
+		o.kind, o.value = kindString, reflect.ValueOf(t.text)
+	case tokenParameter:
+		o.text = "@" + t.text
+		index, ok := p.named[t.text]
+		if !ok {
+			index = len(p.plan.parameters)
+			p.named[t.text] = index
+			p.plan.parameters = append(p.plan.parameters, parameter{name: t.text, offset: t.offset})
+		}
+		o.parameter = index
+		o.kind = p.plan.parameters[index].kind
+	case tokenIdentifier:
+		switch strings.ToLower(t.text) {
+		case "true", "false":
+			o.kind, o.value = kindBool, reflect.ValueOf(strings.EqualFold(t.text, "true"))
+			return o
+		}
+		p.position--
+		o.field = p.parseField()
+		o.kind = o.field.kind
+	default:
+		p.fail(t.offset, ErrSyntax, "expected a field, a value or a parameter, found %s", describe(t))
+	}
+	return o
+}
@@ -0,0 +1,279 @@
+package linqquery
+
+import (
+	"reflect"
+
+	"github.com/thereisnoplanb/linq"
+	"github.com/thereisnoplanb/linq/internal/ordering"
+)
+
+// The values of the parameters of a query, by name without the leading @.
+type Parameters map[string]any
+
+// The values of the selected fields of an element, in the order of the select clause.
+type Row []any
+
+// A compiled query over sequences of TSource, a struct type or a pointer to a struct type.
+//
+// A Query is immutable and safe for concurrent use. Its parameters are bound each time it is run.
+type Query[TSource any] struct {
+	text    string
+	pointer bool
+	plan    plan
+}
+
+// Compiles a query over sequences of TSource.
+//
+// # Parameters
+//
+//	text string
+//
+// The query, for example `where Age > @age && Name startsWith "A" orderby Created desc take 10 select Name, Email`.
+//
+// # Returns
+//
+//	result *Query[TSource]
+//
+// The compiled query.
+//
+//	err error
+//
+// An *Error that locates the first syntax or type error in text, or ErrElementType if TSource is neither a struct nor a pointer to one.
+//
+// # Remarks
+//
+// A query has up to five clauses, in this order, each of them optional:
+//
+//	where <condition>                the elements for which the condition holds
+//	orderby <field> [asc|desc], ...  sorted by the fields, ascending unless desc is given
+//	skip <count>                     without the first count elements
+//	take <count>                     at most count elements
+//	select <field>, ...              the values of the fields; all fields if the clause is missing
+//
+// A condition compares fields, literals and parameters with ==, !=, <, <=, >, >=, and strings with contains, startsWith and endsWith.
+// Conditions combine with && (and), || (or), ! (not) and parentheses. A bool field is a condition on its own.
+// Literals are numbers, double-quoted strings with Go escapes, and true and false; a string compared with a time.Time field
+// is parsed as RFC 3339, "2006-01-02 15:04:05" or "2006-01-02". Keywords are case-insensitive, field names are not.
+//
+// A field is named after its linq tag, or after the field if it has none; a field tagged `linq:"-"` is hidden.
+//
+// Values that come from users belong in parameters, written @name, rather than in the text: they are bound when the query is run,
+// are never parsed as part of the query, and must have the type the query compares them with.
+//
+// Compiled queries are cached by element type and text, so compiling the same query again costs a map lookup.
+func Compile[TSource any](text string) (result *Query[TSource], err error) {
+	typ := reflect.TypeFor[TSource]()
+	key := cacheKey{typ, text}
+	if query, ok := compiled.load(key); ok {
+		return query.(*Query[TSource]), nil
+	}
+	result = &Query[TSource]{
+		text: text,
+	}
+	if typ.Kind() == reflect.Pointer {
+		result.pointer = true
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, ErrElementType
+	}
+	if result.plan, err = parse(text, typ); err != nil {
+		return nil, located(err, text)
+	}
+	compiled.store(key, result)
+	return result, nil
+}
+
+// Returns the text of the query.
+func (query *Query[TSource]) String() string {
+	return query.text
+}
+
+// Returns the names of the selected fields, in the order of the values in a Row.
+func (query *Query[TSource]) Columns() (result []string) {
+	result = make([]string, len(query.plan.columns))
+	for i, column := range query.plan.columns {
+		result[i] = column.name
+	}
+	return result
+}
+
+// Filters, sorts and limits a sequence as the query says, without the select clause.
+//
+// # Parameters
+//
+//	source linq.Iterator[TSource]
+//
+// The sequence to query.
+//
+//	parameters Parameters
+//
+// The values of the parameters of the query.
+//
+// # Returns
+//
+//	result linq.Iterator[TSource]
+//
+// The elements of source that the query selects, built from Where, Order, Skip and Take. Nothing is enumerated yet.
+//
+//	err error
+//
+// An *Error wrapping ErrParameter if a parameter has no value or a value of the wrong type.
+//
+// # Remarks
+//
+// Nil elements never satisfy a where clause and sort before all other elements.
+func (query *Query[TSource]) Apply(source linq.Iterator[TSource], parameters Parameters) (result linq.Iterator[TSource], err error) {
+	arguments, err := query.bind(parameters)
+	if err != nil {
+		return nil, located(err, query.text)
+	}
+	result = source
+	if where := query.plan.where; where != nil {
+		result = result.Where(func(item TSource) bool {
+			element, ok := query.element(item)
+			return ok && where.test(element, arguments)
+		})
+	}
+	if order := query.plan.order; len(order) > 0 {
+		result = result.Order(func(x, y TSource) int {
+			return query.compare(x, y)
+		})
+	}
+	if skip := query.plan.skip; skip != nil {
+		result = result.Skip(count(skip.get(reflect.Value{}, arguments)))
+	}
+	if take := query.plan.take; take != nil {
+		result = result.Take(count(take.get(reflect.Value{}, arguments)))
+	}
+	return result, nil
+}
+
+// Runs the query on a sequence.
+//
+// # Parameters
+//
+//	source linq.Iterator[TSource]
+//
+// The sequence to query.
+//
+//	parameters Parameters
+//
+// The values of the parameters of the query.
+//
+// # Returns
+//
+//	result linq.Iterator[Row]
+//
+// The selected fields of the elements that Apply returns, one Row per element. Nothing is enumerated yet.
+//
+//	err error
+//
+// An *Error wrapping ErrParameter if a parameter has no value or a value of the wrong type.
+//
+// # Example
+//
+//	query, err := linqquery.Compile[User](`where Age > @age orderby Name select Name, Email`)
+//	if err != nil {
+//		return err
+//	}
+//	rows, err := query.Rows(linq.FromSlice(users), linqquery.Parameters{"age": 30})
+//	if err != nil {
+//		return err
+//	}
+//	for row := range rows {
+//		fmt.Println(row[0], row[1])
+//	}
+//
+// # Remarks
+//
+// The values of a nil element are all nil.
+func (query *Query[TSource]) Rows(source linq.Iterator[TSource], parameters Parameters) (result linq.Iterator[Row], err error) {
+	selected, err := query.Apply(source, parameters)
+	if err != nil {
+		return nil, err
+	}
+	columns := query.plan.columns
+	return linq.Select(selected, func(item TSource) Row {
+		row := make(Row, len(columns))
+		if element, ok := query.element(item); ok {
+			for i, column := range columns {
+				row[i] = element.FieldByIndex(column.index).Interface()
+			}
+		}
+		return row
+	}), nil
+}
+
+// Returns the struct an element is or points to, or false if the element is a nil pointer.
+func (query *Query[TSource]) element(item TSource) (result reflect.Value, ok bool) {
+	result = reflect.ValueOf(&item).Elem()
+	if query.pointer {
+		if result.IsNil() {
+			return result, false
+		}
+		result = result.Elem()
+	}
+	return result, true
+}
+
+// Compares two elements by the fields of the orderby clause.
+func (query *Query[TSource]) compare(x TSource, y TSource) int {
+	first, xok := query.element(x)
+	second, yok := query.element(y)
+	switch {
+	case !xok || !yok:
+		return ordering.BoolRank(xok) - ordering.BoolRank(yok)
+	}
+	for _, key := range query.plan.order {
+		order := ordering.Compare(first.FieldByIndex(key.field.index), second.FieldByIndex(key.field.index))
+		if key.descending {
+			order = -order
+		}
+		if order != 0 {
+			return order
+		}
+	}
+	return 0
+}
+
+// Checks the values of the parameters against the types the query compares them with.
+func (query *Query[TSource]) bind(parameters Parameters) (arguments []reflect.Value, err error) {
+	arguments = make([]reflect.Value, len(query.plan.parameters))
+	for i, declared := range query.plan.parameters {
+		value, ok := parameters[declared.name]
+		if !ok {
+			return nil, errorAt(declared.offset, ErrParameter, "missing value for @%s", declared.name)
+		}
+		argument := reflect.ValueOf(value)
+		if !argument.IsValid() {
+			return nil, errorAt(declared.offset, ErrParameter, "@%s is nil, want a %s", declared.name, declared.kind)
+		}
+		k := kindOf(argument.Type())
+		if query.counts(i) && k != kindInt && k != kindUint {
+			return nil, errorAt(declared.offset, ErrParameter, "@%s is a %T, want an integer", declared.name, value)
+		}
+		if !k.compatible(declared.kind) {
+			return nil, errorAt(declared.offset, ErrParameter, "@%s is a %T, want a %s", declared.name, value, declared.kind)
+		}
+		arguments[i] = argument
+	}
+	return arguments, nil
+}
+
+// Reports whether a parameter is the count of a skip or take clause.
+func (query *Query[TSource]) counts(parameter int) bool {
+	for _, o := range []*operand{query.plan.skip, query.plan.take} {
+		if o != nil && o.parameter == parameter {
+			return true
+		}
+	}
+	return false
+}
+
+func count(value reflect.Value) int {
+	if kindOf(value.Type()) == kindUint {
+		return int(min(value.Uint(), uint64(^uint(0)>>1)))
+	}
+	return int(value.Int())
+}
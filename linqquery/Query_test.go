@@ -0,0 +1,317 @@
+package linqquery
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+type user struct {
+	Name    string
+	Email   string `linq:"email"`
+	Age     int
+	Score   float64
+	Admin   bool
+	Created time.Time
+	secret  string
+	Hidden  string `linq:"-"`
+}
+
+func day(d int) time.Time {
+	return time.Date(2024, time.January, d, 0, 0, 0, 0, time.UTC)
+}
+
+var users = []user{
+	{Name: "Alice", Email: "alice@example.com", Age: 34, Score: 9.5, Admin: true, Created: day(3)},
+	{Name: "Bob", Email: "bob@example.com", Age: 25, Score: 7, Created: day(1)},
+	{Name: "Anna", Email: "anna@example.com", Age: 41, Score: 8.25, Created: day(5)},
+	{Name: "Carol", Email: "carol@example.com", Age: 30, Score: 7, Admin: true, Created: day(2)},
+	{Name: "Adam", Email: "adam@example.com", Age: 19, Score: 6.5, Created: day(4)},
+}
+
+func names(source linq.Iterator[user]) (result []string) {
+	result = []string{}
+	for user := range source {
+		result = append(result, user.Name)
+	}
+	return result
+}
+
+func TestQuery_Apply(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		parameters Parameters
+		want       []string
+	}{
+		{
+			name: "empty",
+			text: "",
+			want: []string{"Alice", "Bob", "Anna", "Carol", "Adam"},
+		},
+		{
+			name: "request example",
+			text: `where Age > 30 && Name startsWith "A" orderby Created desc take 10 select Name, email`,
+			want: []string{"Anna", "Alice"},
+		},
+		{
+			name: "or and not",
+			text: `where !(Age < 30) or Name == "Bob" orderby Name`,
+			want: []string{"Alice", "Anna", "Bob", "Carol"},
+		},
+		{
+			name: "keywords are case-insensitive",
+			text: `WHERE Age >= 30 AND NOT Admin ORDERBY Age DESC`,
+			want: []string{"Anna"},
+		},
+		{
+			name: "bool field",
+			text: `where Admin orderby Name`,
+			want: []string{"Alice", "Carol"},
+		},
+		{
+			name: "contains and endsWith",
+			text: `where email contains "a" && Name endsWith "a"`,
+			want: []string{"Anna"},
+		},
+		{
+			name: "float field with an int literal",
+			text: `where Score == 7 orderby Age`,
+			want: []string{"Bob", "Carol"},
+		},
+		{
+			name: "several keys",
+			text: `orderby Score desc, Name asc`,
+			want: []string{"Alice", "Anna", "Bob", "Carol", "Adam"},
+		},
+		{
+			name: "time literal",
+			text: `where Created >= "2024-01-03" orderby Created`,
+			want: []string{"Alice", "Adam", "Anna"},
+		},
+		{
+			name: "skip and take",
+			text: `orderby Age skip 1 take 2`,
+			want: []string{"Bob", "Carol"},
+		},
+		{
+			name:       "parameters",
+			text:       `where Age > @age && Created < @before orderby Age skip @skip take @take`,
+			parameters: Parameters{"age": 20, "before": day(5), "skip": uint8(1), "take": 2},
+			want:       []string{"Carol", "Alice"},
+		},
+		{
+			name:       "a parameter used twice",
+			text:       `where Score > @limit || Age > @limit`,
+			parameters: Parameters{"limit": 9},
+			want:       []string{"Alice", "Bob", "Anna", "Carol", "Adam"},
+		},
+		{
+			name:       "parameters are values, not query text",
+			text:       `where Name == @name`,
+			parameters: Parameters{"name": `Bob" || Name != "`},
+			want:       []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := Compile[user](tt.text)
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			result, err := query.Apply(linq.FromSlice(users), tt.parameters)
+			if err != nil {
+				t.Fatalf("Query.Apply() error = %v", err)
+			}
+			if got := names(result); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Query.Apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_Rows(t *testing.T) {
+	query, err := Compile[*user](`where Age < @age orderby Age select Name, email, Age`)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if got, want := query.Columns(), []string{"Name", "email", "Age"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Query.Columns() = %v, want %v", got, want)
+	}
+	source := linq.FromSlice([]*user{&users[0], nil, &users[1], &users[4]})
+	rows, err := query.Rows(source, Parameters{"age": 30})
+	if err != nil {
+		t.Fatalf("Query.Rows() error = %v", err)
+	}
+	want := []Row{
+		{"Adam", "adam@example.com", 19},
+		{"Bob", "bob@example.com", 25},
+	}
+	if got := rows.ToSlice(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Query.Rows() = %v, want %v", got, want)
+	}
+
+	query, err = Compile[*user](`orderby Name`)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if got, want := query.Columns(), []string{"Name", "email", "Age", "Score", "Admin", "Created"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Query.Columns() = %v, want %v", got, want)
+	}
+	rows, err = query.Rows(linq.FromSlice([]*user{&users[1], nil}), nil)
+	if err != nil {
+		t.Fatalf("Query.Rows() error = %v", err)
+	}
+	want = []Row{
+		make(Row, 6),
+		{"Bob", "bob@example.com", 25, 7.0, false, day(1)},
+	}
+	if got := rows.ToSlice(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Query.Rows() = %v, want %v", got, want)
+	}
+}
+
+func TestCompile_errors(t *testing.T) {
+	tests := []struct {
+		text   string
+		kind   error
+		offset int
+	}{
+		{text: `where Agee > 30`, kind: ErrUnknownField, offset: 6},
+		{text: `where secret == "x"`, kind: ErrUnknownField, offset: 6},
+		{text: `where Hidden == "x"`, kind: ErrUnknownField, offset: 6},
+		{text: `where Email == "x"`, kind: ErrUnknownField, offset: 6},
+		{text: `where Age > "thirty"`, kind: ErrType, offset: 10},
+		{text: `where Age startsWith "3"`, kind: ErrType, offset: 10},
+		{text: `where Admin > false`, kind: ErrType, offset: 12},
+		{text: `where Age`, kind: ErrType, offset: 6},
+		{text: `where Created > "yesterday"`, kind: ErrType, offset: 16},
+		{text: `where @a == @b`, kind: ErrType, offset: 6},
+		{text: `where Age > @x && Name == @x`, kind: ErrType, offset: 23},
+		{text: `take 1.5`, kind: ErrType, offset: 5},
+		{text: `where Age > 30 &&`, kind: ErrSyntax, offset: 17},
+		{text: `where (Age > 30`, kind: ErrSyntax, offset: 15},
+		{text: `where Age > 30 take`, kind: ErrSyntax, offset: 19},
+		{text: `where Name == "Bob`, kind: ErrSyntax, offset: 14},
+		{text: `where Age = 30`, kind: ErrSyntax, offset: 10},
+		{text: `take 10 where Age > 30`, kind: ErrSyntax, offset: 8},
+		{text: `select Name,`, kind: ErrSyntax, offset: 12},
+		{text: `orderby take`, kind: ErrSyntax, offset: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			_, err := Compile[user](tt.text)
+			if !errors.Is(err, tt.kind) {
+				t.Fatalf("Compile() error = %v, want %v", err, tt.kind)
+			}
+			var failure *Error
+			if !errors.As(err, &failure) {
+				t.Fatalf("Compile() error = %T, want *Error", err)
+			}
+			if failure.Offset != tt.offset {
+				t.Errorf("Compile() error = %v, want offset %d", err, tt.offset)
+			}
+		})
+	}
+}
+
+func TestError_column(t *testing.T) {
+	tests := []struct {
+		text   string
+		offset int
+		column int
+	}{
+		{text: `where Agee > 30`, offset: 6, column: 7},
+		{text: `where Name == "Zoë" && Agee > 30`, offset: 24, column: 24},
+		{text: `where Name == "日本語" || Agee > 30`, offset: 29, column: 24},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			_, err := Compile[user](tt.text)
+			var failure *Error
+			if !errors.As(err, &failure) {
+				t.Fatalf("Compile() error = %v, want *Error", err)
+			}
+			if failure.Offset != tt.offset || failure.Column != tt.column {
+				t.Errorf("Compile() error at offset %d, column %d, want offset %d, column %d", failure.Offset, failure.Column, tt.offset, tt.column)
+			}
+			if want := fmt.Sprintf("at column %d:", tt.column); !strings.Contains(err.Error(), want) {
+				t.Errorf("Compile() error = %q, want it to contain %q", err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestCompile_elementType(t *testing.T) {
+	if _, err := Compile[int](""); !errors.Is(err, ErrElementType) {
+		t.Errorf("Compile[int]() error = %v, want %v", err, ErrElementType)
+	}
+	if _, err := Compile[**user](""); !errors.Is(err, ErrElementType) {
+		t.Errorf("Compile[**user]() error = %v, want %v", err, ErrElementType)
+	}
+}
+
+func TestQuery_bind(t *testing.T) {
+	query, err := Compile[user](`where Name == @name && Age > @age take @take`)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	tests := []struct {
+		name       string
+		parameters Parameters
+		offset     int
+	}{
+		{name: "missing", parameters: Parameters{"name": "Bob", "age": 1}, offset: 39},
+		{name: "nil", parameters: Parameters{"name": nil, "age": 1, "take": 1}, offset: 14},
+		{name: "wrong type", parameters: Parameters{"name": "Bob", "age": "1", "take": 1}, offset: 29},
+		{name: "float count", parameters: Parameters{"name": "Bob", "age": 1, "take": 1.0}, offset: 39},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := query.Apply(linq.FromSlice(users), tt.parameters)
+			var failure *Error
+			if !errors.Is(err, ErrParameter) || !errors.As(err, &failure) || failure.Offset != tt.offset {
+				t.Errorf("Query.Apply() error = %v, want %v at offset %d", err, ErrParameter, tt.offset)
+			}
+		})
+	}
+}
+
+func TestCompile_cache(t *testing.T) {
+	text := `where Age > 30 orderby Name`
+	first, err := Compile[user](text)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	second, _ := Compile[user](text)
+	if first != second {
+		t.Errorf("Compile() = %p, want the cached %p", second, first)
+	}
+	if pointer, _ := Compile[*user](text); any(pointer) == any(first) {
+		t.Errorf("Compile[*user]() returned the query compiled for user")
+	}
+}
+
+func TestCache(t *testing.T) {
+	c := newCache(2)
+	key := func(text string) cacheKey {
+		return cacheKey{reflect.TypeFor[user](), text}
+	}
+	c.store(key("a"), 1)
+	c.store(key("b"), 2)
+	c.load(key("a"))
+	c.store(key("c"), 3)
+	if _, ok := c.load(key("b")); ok {
+		t.Errorf("cache.load(b) found the least recently used entry")
+	}
+	for text, want := range map[string]int{"a": 1, "c": 3} {
+		if got, ok := c.load(key(text)); !ok || got != want {
+			t.Errorf("cache.load(%s) = %v, %v, want %v, true", text, got, ok, want)
+		}
+	}
+}
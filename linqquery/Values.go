@@ -0,0 +1,96 @@
+package linqquery
+
+import (
+	"reflect"
+	"time"
+)
+
+// The types of values the language can compare.
+type kind int
+
+const (
+	kindNone kind = iota
+	kindBool
+	kindInt
+	kindUint
+	kindFloat
+	kindString
+	kindTime
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+func kindOf(t reflect.Type) kind {
+	if t == timeType {
+		return kindTime
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return kindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return kindInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return kindUint
+	case reflect.Float32, reflect.Float64:
+		return kindFloat
+	case reflect.String:
+		return kindString
+	}
+	return kindNone
+}
+
+func (k kind) numeric() bool {
+	return k == kindInt || k == kindUint || k == kindFloat
+}
+
+// Reports whether values of the two kinds can be compared with each other.
+func (k kind) compatible(other kind) bool {
+	return k == other || (k.numeric() && other.numeric())
+}
+
+func (k kind) String() string {
+	switch k {
+	case kindBool:
+		return "bool"
+	case kindInt, kindUint, kindFloat:
+		return "number"
+	case kindString:
+		return "string"
+	case kindTime:
+		return "time"
+	}
+	return "value"
+}
+
+// An exported field of the element type, named after its linq tag or, without one, after the field.
+type field struct {
+	name  string
+	index []int
+	typ   reflect.Type
+	kind  kind
+}
+
+// Returns the fields of a struct type that queries can refer to, in declaration order.
+func fieldsOf(t reflect.Type) (fields []field) {
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || (f.Anonymous && f.Type.Kind() == reflect.Struct && f.Type != timeType) {
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("linq"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		fields = append(fields, field{
+			name:  name,
+			index: f.Index,
+			typ:   f.Type,
+			kind:  kindOf(f.Type),
+		})
+	}
+	return fields
+}
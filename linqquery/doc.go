@@ -0,0 +1,19 @@
+// Package linqquery compiles queries written as text into linq pipelines over sequences of structs.
+//
+// A query such as
+//
+//	where Age > @age && Name startsWith "A" orderby Created desc take 10 select Name, Email
+//
+// is checked against the exported fields of the element type when it is compiled, and runs as Where, Order, Skip, Take
+// and Select on a linq.Iterator. Errors are reported as an *Error with the byte offset and the column of the text that caused them.
+//
+// Values that come from users are passed as parameters, never spliced into the text:
+//
+//	query, err := linqquery.Compile[User](`where Name == @name`)
+//	if err != nil {
+//		return err
+//	}
+//	matches, err := query.Apply(linq.FromSlice(users), linqquery.Parameters{"name": name})
+//
+// See Compile for the grammar.
+package linqquery
@@ -0,0 +1,146 @@
+package linqsql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+var ErrNilElement = errors.New("the element is nil")
+
+// The BeginTx method of *sql.DB and *sql.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// Returns a function that gives the arguments of a statement for an element.
+func argumentsOf[TSource any]() func(item TSource) ([]any, error) {
+	t := reflect.TypeFor[TSource]()
+	if t == reflect.TypeFor[[]any]() {
+		return func(item TSource) ([]any, error) {
+			return any(item).([]any), nil
+		}
+	}
+	if scalar(t) {
+		return func(item TSource) ([]any, error) {
+			return []any{item}, nil
+		}
+	}
+	columns := columnsOf(structOf(t))
+	return func(item TSource) ([]any, error) {
+		value := reflect.ValueOf(item)
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				return nil, ErrNilElement
+			}
+			value = value.Elem()
+		}
+		args := make([]any, len(columns))
+		for i, c := range columns {
+			args[i] = value.FieldByIndex(c.index).Interface()
+		}
+		return args, nil
+	}
+}
+
+// Executes a statement once for each element of a sequence, in transactions of batchSize elements.
+//
+// # Parameters
+//
+//	ctx context.Context
+//
+// The context of the transactions.
+//
+//	db TxBeginner
+//
+// The *sql.DB or *sql.Conn to run the transactions on.
+//
+//	stmt string
+//
+// The statement, for example "INSERT INTO users (id, name, email) VALUES (?, ?, ?)". It is prepared once per transaction.
+//
+//	source linq.Iterator[TSource]
+//
+// The elements to execute the statement for.
+//
+//	batchSize int
+//
+// The greatest number of elements executed in one transaction.
+//
+// # Returns
+//
+//	result int64
+//
+// The number of rows affected by the committed transactions, as their drivers report them.
+//
+// # Error
+//
+//	err error
+//
+// ErrSizeIsBelowOne if batchSize is below 1, ErrNilElement if an element is a nil pointer,
+// or the error of the transaction, the statement or the commit that failed.
+//
+// # Example
+//
+//	inserted, err := linqsql.ExecBatch(ctx, db, "INSERT INTO users (id, name, email) VALUES (?, ?, ?)", linq.FromSlice(users), 500)
+//
+// # Remarks
+//
+// The elements are split with linq.Chunk. Each chunk is executed in a transaction of its own, which is committed before the
+// next chunk is read. When an element fails, its transaction is rolled back and ExecBatch returns; the chunks committed before
+// stay committed, and the rest of source is not read.
+//
+// The arguments of an element that is a struct, or a pointer to one, are its fields that have a column, in the order
+// of the struct, as FromRows maps them. An element that is a []any is the arguments. Any other element is the only argument.
+func ExecBatch[TSource any](ctx context.Context, db TxBeginner, stmt string, source linq.Iterator[TSource], batchSize int) (result int64, err error) {
+	if batchSize < 1 {
+		return 0, linq.ErrSizeIsBelowOne
+	}
+	arguments := argumentsOf[TSource]()
+	for chunk := range linq.Chunk(source, batchSize) {
+		affected, err := execChunk(ctx, db, stmt, chunk, arguments)
+		if err != nil {
+			return result, err
+		}
+		result += affected
+	}
+	return result, nil
+}
+
+// Executes a statement for each element of a chunk in one transaction.
+func execChunk[TSource any](ctx context.Context, db TxBeginner, stmt string, chunk []TSource, arguments func(item TSource) ([]any, error)) (result int64, err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	prepared, err := tx.PrepareContext(ctx, stmt)
+	if err != nil {
+		return 0, err
+	}
+	defer prepared.Close()
+	for _, item := range chunk {
+		args, err := arguments(item)
+		if err != nil {
+			return 0, err
+		}
+		executed, err := prepared.ExecContext(ctx, args...)
+		if err != nil {
+			return 0, err
+		}
+		if affected, err := executed.RowsAffected(); err == nil {
+			result += affected
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
@@ -0,0 +1,100 @@
+package linqsql
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+const insertUser = "INSERT INTO users (id, name, email, age, is_admin) VALUES (?, ?, ?, ?, ?)"
+
+var batchUsers = []user{
+	{ID: 1, Name: "Alice", Email: text("alice@example.com"), Age: 34, Admin: true},
+	{ID: 2, Name: "Bob", Age: 25},
+	{ID: 3, Name: "Anna", Age: 41},
+	{ID: 4, Name: "Carol", Age: 30},
+	{ID: 5, Name: "Adam", Age: 19, Note: "not stored"},
+}
+
+func TestExecBatch(t *testing.T) {
+	db, server := newFakeDB(nil)
+	defer db.Close()
+	got, err := ExecBatch(context.Background(), db, insertUser, linq.FromSlice(batchUsers), 2)
+	if err != nil || got != 5 {
+		t.Fatalf("ExecBatch() = %v, %v, want 5, nil", got, err)
+	}
+	want := []fakeExec{
+		{1, insertUser, []any{int64(1), "Alice", "alice@example.com", int64(34), true}},
+		{1, insertUser, []any{int64(2), "Bob", nil, int64(25), false}},
+		{2, insertUser, []any{int64(3), "Anna", nil, int64(41), false}},
+		{2, insertUser, []any{int64(4), "Carol", nil, int64(30), false}},
+		{3, insertUser, []any{int64(5), "Adam", nil, int64(19), false}},
+	}
+	if !reflect.DeepEqual(server.execs, want) {
+		t.Errorf("execs = %v, want %v", server.execs, want)
+	}
+	if server.transactions != 3 || server.commits != 3 || server.rollbacks != 0 {
+		t.Errorf("transactions, commits, rollbacks = %d, %d, %d, want 3, 3, 0", server.transactions, server.commits, server.rollbacks)
+	}
+}
+
+func TestExecBatch_elements(t *testing.T) {
+	db, server := newFakeDB(nil)
+	defer db.Close()
+	rows := linq.FromSlice([][]any{{1, "Alice"}, {2, "Bob"}})
+	if got, err := ExecBatch(context.Background(), db, "INSERT INTO names VALUES (?, ?)", rows, 10); err != nil || got != 2 {
+		t.Errorf("ExecBatch() = %v, %v, want 2, nil", got, err)
+	}
+	if got, err := ExecBatch(context.Background(), db, "DELETE FROM users WHERE id = ?", linq.Range(1, 3), 10); err != nil || got != 3 {
+		t.Errorf("ExecBatch() = %v, %v, want 3, nil", got, err)
+	}
+	if got, err := ExecBatch(context.Background(), db, insertUser, linq.FromSlice([]*user{&batchUsers[1]}), 10); err != nil || got != 1 {
+		t.Errorf("ExecBatch() = %v, %v, want 1, nil", got, err)
+	}
+	want := []any{int64(2), "Bob"}
+	if got := server.execs[1].args; !reflect.DeepEqual(got, want) {
+		t.Errorf("args = %v, want %v", got, want)
+	}
+	want = []any{int64(3)}
+	if got := server.execs[4].args; !reflect.DeepEqual(got, want) {
+		t.Errorf("args = %v, want %v", got, want)
+	}
+	want = []any{int64(2), "Bob", nil, int64(25), false}
+	if got := server.execs[5].args; !reflect.DeepEqual(got, want) {
+		t.Errorf("args = %v, want %v", got, want)
+	}
+}
+
+func TestExecBatch_errors(t *testing.T) {
+	failure := errors.New("duplicate key")
+	db, server := newFakeDB(nil)
+	defer db.Close()
+	server.failExec = func(args []any) error {
+		if args[0] == int64(3) {
+			return failure
+		}
+		return nil
+	}
+	pulled := 0
+	source := linq.FromSlice(batchUsers).Tap(func(user) { pulled++ })
+	got, err := ExecBatch(context.Background(), db, insertUser, source, 2)
+	if !errors.Is(err, failure) || got != 2 {
+		t.Errorf("ExecBatch() = %v, %v, want 2, %v", got, err, failure)
+	}
+	if server.transactions != 2 || server.commits != 1 || server.rollbacks != 1 {
+		t.Errorf("transactions, commits, rollbacks = %d, %d, %d, want 2, 1, 1", server.transactions, server.commits, server.rollbacks)
+	}
+	if pulled != 4 {
+		t.Errorf("ExecBatch() read %d elements, want 4", pulled)
+	}
+
+	if _, err := ExecBatch(context.Background(), db, insertUser, linq.FromSlice(batchUsers), 0); !errors.Is(err, linq.ErrSizeIsBelowOne) {
+		t.Errorf("ExecBatch() error = %v, want %v", err, linq.ErrSizeIsBelowOne)
+	}
+	if _, err := ExecBatch(context.Background(), db, insertUser, linq.FromSlice([]*user{nil}), 1); !errors.Is(err, ErrNilElement) {
+		t.Errorf("ExecBatch() error = %v, want %v", err, ErrNilElement)
+	}
+}
@@ -0,0 +1,50 @@
+package linqsql
+
+import (
+	"reflect"
+	"strings"
+)
+
+// A field of a struct and the column it is stored in.
+type column struct {
+	name  string
+	field string
+	index []int
+}
+
+// Returns the columns of the exported fields of a struct type, named after their db tag or, without one, after the field.
+// Fields tagged `db:"-"` and embedded structs have no column; the fields of embedded structs are promoted.
+func columnsOf(t reflect.Type) (result []column) {
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous && f.Type.Kind() == reflect.Struct {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("db"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		result = append(result, column{name: name, field: f.Name, index: f.Index})
+	}
+	return result
+}
+
+// Returns the column of the field with the given name.
+func columnOf(columns []column, field string) (result column, ok bool) {
+	for _, c := range columns {
+		if c.field == field {
+			return c, true
+		}
+	}
+	return column{}, false
+}
+
+// Returns the struct type of elements of a struct type or a pointer to one.
+func structOf(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
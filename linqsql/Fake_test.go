@@ -0,0 +1,191 @@
+package linqsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// A database that answers queries with canned rows and records what it was asked.
+type fakeServer struct {
+	mutex   sync.Mutex
+	results map[string]fakeResult
+	queries []fakeQuery
+	open    int
+
+	// The statements executed in transactions, the numbers of transactions begun, committed and rolled back,
+	// and the error to fail a statement with, if any.
+	execs        []fakeExec
+	transactions int
+	commits      int
+	rollbacks    int
+	failExec     func(args []any) error
+}
+
+// The rows of a canned answer, and the error to fail with after them.
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+	err     error
+}
+
+type fakeQuery struct {
+	text string
+	args []any
+}
+
+type fakeExec struct {
+	transaction int
+	text        string
+	args        []any
+}
+
+var errUnexpectedQuery = errors.New("unexpected query")
+
+func newFakeDB(results map[string]fakeResult) (*sql.DB, *fakeServer) {
+	server := &fakeServer{results: results}
+	return sql.OpenDB(server), server
+}
+
+func (server *fakeServer) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeConn{server}, nil
+}
+
+func (server *fakeServer) Driver() driver.Driver {
+	return server
+}
+
+func (server *fakeServer) Open(name string) (driver.Conn, error) {
+	return &fakeConn{server}, nil
+}
+
+// Returns the number of rows that are still open.
+func (server *fakeServer) openRows() int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.open
+}
+
+type fakeConn struct {
+	server *fakeServer
+}
+
+func (conn *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn.server, query}, nil
+}
+
+func (conn *fakeConn) Close() error {
+	return nil
+}
+
+func (conn *fakeConn) Begin() (driver.Tx, error) {
+	conn.server.mutex.Lock()
+	defer conn.server.mutex.Unlock()
+	conn.server.transactions++
+	return &fakeTx{conn.server}, nil
+}
+
+type fakeTx struct {
+	server *fakeServer
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.server.mutex.Lock()
+	defer tx.server.mutex.Unlock()
+	tx.server.commits++
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.server.mutex.Lock()
+	defer tx.server.mutex.Unlock()
+	tx.server.rollbacks++
+	return nil
+}
+
+type fakeStmt struct {
+	server *fakeServer
+	text   string
+}
+
+func (stmt *fakeStmt) Close() error {
+	return nil
+}
+
+func (stmt *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (stmt *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	server := stmt.server
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+	if server.failExec != nil {
+		if err := server.failExec(values); err != nil {
+			return nil, err
+		}
+	}
+	server.execs = append(server.execs, fakeExec{server.transactions, stmt.text, values})
+	return driver.RowsAffected(1), nil
+}
+
+func (stmt *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("queries are not supported by prepared statements")
+}
+
+func (conn *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	server := conn.server
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	server.queries = append(server.queries, fakeQuery{query, values})
+	result, ok := server.results[query]
+	if !ok {
+		return nil, errUnexpectedQuery
+	}
+	server.open++
+	return &fakeRows{server: server, result: result}, nil
+}
+
+type fakeRows struct {
+	server *fakeServer
+	result fakeResult
+	next   int
+	closed bool
+}
+
+func (rows *fakeRows) Columns() []string {
+	return rows.result.columns
+}
+
+func (rows *fakeRows) Close() error {
+	if !rows.closed {
+		rows.closed = true
+		rows.server.mutex.Lock()
+		rows.server.open--
+		rows.server.mutex.Unlock()
+	}
+	return nil
+}
+
+func (rows *fakeRows) Next(dest []driver.Value) error {
+	if rows.next == len(rows.result.rows) {
+		if rows.result.err != nil {
+			return rows.result.err
+		}
+		return io.EOF
+	}
+	copy(dest, rows.result.rows[rows.next])
+	rows.next++
+	return nil
+}
@@ -0,0 +1,313 @@
+package linqsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/thereisnoplanb/linq"
+	"github.com/thereisnoplanb/linq/linqexpr"
+)
+
+// The options of a Provider.
+type Options struct {
+
+	// Returns the placeholder of the parameter at an index, starting at 1. If nil, QuestionMark is used.
+	Placeholder func(index int) string
+
+	// The LIMIT clause written before OFFSET when a query skips elements without taking, for example "LIMIT -1" for SQLite
+	// or "LIMIT 18446744073709551615" for MySQL. If empty, OFFSET is written alone, as PostgreSQL accepts.
+	NoLimit string
+
+	// The escape character of the LIKE patterns that contains, hasPrefix and hasSuffix are translated to. If zero, \ is used,
+	// as PostgreSQL and SQLite accept. MySQL reads \ in a string literal as an escape itself, so use another character
+	// for MySQL, for example '!'. It must not be % or _.
+	LikeEscape rune
+
+	// True if LIKE is case-sensitive, as it is in PostgreSQL. If false, as LIKE ignores case in MySQL with its default
+	// collations and in SQLite for ASCII letters, a Where with contains, hasPrefix or hasSuffix is run in SQL only to narrow
+	// the rows and is applied again in memory, where they are case-sensitive, and they are not translated under NOT.
+	CaseSensitiveLike bool
+}
+
+// Returns "?", the placeholder of MySQL and SQLite.
+func QuestionMark(index int) string {
+	return "?"
+}
+
+// Returns "$1", "$2" and so on, the placeholders of PostgreSQL.
+func DollarNumber(index int) string {
+	return "$" + strconv.Itoa(index)
+}
+
+// A linqexpr.Provider that runs queries against a table as parameterized SQL.
+type Provider struct {
+	db      *sql.DB
+	table   string
+	options Options
+
+	// Escapes the wildcards of LIKE patterns with options.LikeEscape.
+	likeEscaper *strings.Replacer
+}
+
+// Creates a Provider for a table.
+//
+// # Parameters
+//
+//	db *sql.DB
+//
+// The database the table is in.
+//
+//	table string
+//
+// The name of the table, as written in SQL. It is not quoted or escaped, so it must not come from users.
+//
+//	options ...Options
+//
+// The options of the provider. Optional.
+//
+// # Returns
+//
+//	result *Provider
+//
+// A provider whose elements are the rows of the table.
+func NewProvider(db *sql.DB, table string, options ...Options) (result *Provider) {
+	result = &Provider{
+		db:    db,
+		table: table,
+	}
+	if len(options) > 0 {
+		result.options = options[0]
+	}
+	if result.options.Placeholder == nil {
+		result.options.Placeholder = QuestionMark
+	}
+	if result.options.LikeEscape == 0 {
+		result.options.LikeEscape = '\\'
+	}
+	escape := string(result.options.LikeEscape)
+	result.likeEscaper = strings.NewReplacer(escape, escape+escape, "%", escape+"%", "_", escape+"_")
+	return result
+}
+
+// Creates a linqexpr.Queryable over the rows of a table.
+//
+// # Parameters
+//
+//	db *sql.DB
+//
+// The database the table is in.
+//
+//	table string
+//
+// The name of the table, as written in SQL. It is not quoted or escaped, so it must not come from users.
+//
+//	options ...Options
+//
+// The options of the provider. Optional.
+//
+// # Returns
+//
+//	result linqexpr.Queryable[TSource]
+//
+// A query over the rows of the table, scanned into TSource, a struct type or a pointer to one.
+// Each exported field is read from the column named by its db tag or, without one, after the field.
+//
+// # Example
+//
+//	users := linqsql.Table[User](db, "users").
+//		Where(linqexpr.Field("Age").Gt(30)).
+//		OrderByDescending(linqexpr.Field("Created")).
+//		Take(10)
+//	for user, err := range users.Iterator(ctx) {
+//		...
+//	}
+//
+// runs SELECT ... FROM users WHERE age > ? ORDER BY created DESC LIMIT 10 with the argument 30.
+func Table[TSource any](db *sql.DB, table string, options ...Options) (result linqexpr.Queryable[TSource]) {
+	return linqexpr.NewQueryable[TSource](NewProvider(db, table, options...))
+}
+
+// The SQL of the translated prefix of a query, and how to scan its rows.
+type statement struct {
+	text     string
+	args     []any
+	executed int
+	element  reflect.Type
+	targets  [][]int
+}
+
+// Translates the longest prefix of the operations of a query that SQL can express.
+//
+// # Parameters
+//
+//	query linqexpr.Query
+//
+// The query to translate.
+//
+// # Returns
+//
+//	text string
+//
+// The SELECT statement.
+//
+//	args []any
+//
+// The arguments of the placeholders in text. Every constant of the query is an argument; none is written into text.
+//
+//	executed int
+//
+// The number of operations text runs. The others are left to be applied in memory, including a Where that text runs only
+// to narrow the rows (see Options.CaseSensitiveLike).
+//
+// # Remarks
+//
+// SQL expresses Where, OrderBy, ThenBy, Skip, Take and Select over fields and constants, in the order of a SELECT statement:
+// a Where or an OrderBy that follows Skip, Take or Select, and a second Select, end the prefix, and so does an expression
+// the provider cannot translate, such as a contains whose argument is a field.
+//
+// Comparisons with NULL columns are false, as in memory. Under NOT, a comparison of a pointer field is written
+// as (column IS NOT NULL AND comparison), so that its negation is true for a NULL column, as it is for a nil field in memory.
+// An ordering by a pointer field is written as column IS NOT NULL, column, so that NULLs sort first, and last when descending,
+// as nil fields do in memory, whichever the database's default.
+func (provider *Provider) Translate(query linqexpr.Query) (text string, args []any, executed int) {
+	s := provider.translate(query)
+	return s.text, s.args, s.executed
+}
+
+func (provider *Provider) translate(query linqexpr.Query) (result statement) {
+	t := translator{
+		provider: provider,
+		element:  structOf(query.ElementType),
+	}
+	t.columns = columnsOf(t.element)
+	result.element = query.ElementType
+	selected := make([]string, 0)
+	for _, c := range t.columns {
+		selected = append(selected, c.name)
+		result.targets = append(result.targets, c.index)
+	}
+	var where, order []string
+	limit, offset := -1, 0
+	projected := false
+loop:
+	for _, operation := range query.Operations {
+		switch operation := operation.(type) {
+		case linqexpr.WhereOperation:
+			if projected || limit >= 0 || offset > 0 {
+				break loop
+			}
+			mark := len(t.args)
+			t.narrows = false
+			condition, ok := t.condition(operation.Condition)
+			if !ok {
+				t.args = t.args[:mark]
+				break loop
+			}
+			where = append(where, condition)
+			if t.narrows {
+				break loop
+			}
+		case linqexpr.OrderOperation:
+			field, ok := operation.Key.(linqexpr.FieldExpression)
+			if !ok || projected || limit >= 0 || offset > 0 {
+				break loop
+			}
+			key, ok := t.operand(field)
+			if !ok {
+				break loop
+			}
+			direction := ""
+			if operation.Descending {
+				direction = " DESC"
+			}
+			if !operation.Then {
+				order = order[:0]
+			}
+			if name, ok := t.nullable(field); ok {
+				order = append(order, name+" IS NOT NULL"+direction)
+			}
+			order = append(order, key+direction)
+		case linqexpr.SkipOperation:
+			count := max(operation.Count, 0)
+			if limit >= 0 {
+				limit = max(limit-count, 0)
+			}
+			offset += count
+		case linqexpr.TakeOperation:
+			count := max(operation.Count, 0)
+			if limit < 0 || count < limit {
+				limit = count
+			}
+		case linqexpr.SelectOperation:
+			names, targets, ok := t.projection(operation)
+			if !ok || projected {
+				break loop
+			}
+			selected, result.targets, result.element = names, targets, operation.ResultType
+			projected = true
+		default:
+			break loop
+		}
+		result.executed++
+	}
+	var text strings.Builder
+	fmt.Fprintf(&text, "SELECT %s FROM %s", strings.Join(selected, ", "), provider.table)
+	if len(where) > 0 {
+		fmt.Fprintf(&text, " WHERE %s", strings.Join(where, " AND "))
+	}
+	if len(order) > 0 {
+		fmt.Fprintf(&text, " ORDER BY %s", strings.Join(order, ", "))
+	}
+	switch {
+	case limit >= 0:
+		fmt.Fprintf(&text, " LIMIT %d", limit)
+	case offset > 0 && provider.options.NoLimit != "":
+		fmt.Fprintf(&text, " %s", provider.options.NoLimit)
+	}
+	if offset > 0 {
+		fmt.Fprintf(&text, " OFFSET %d", offset)
+	}
+	result.text = text.String()
+	result.args = t.args
+	return result
+}
+
+// Runs the translated prefix of a query and scans the rows.
+//
+// The enumeration fails with the error of the query or of a scan, and closes the rows when it ends.
+func (provider *Provider) Execute(ctx context.Context, query linqexpr.Query) (result linq.ErrorIterator[any], executed int) {
+	s := provider.translate(query)
+	return func(yield func(value any, err error) bool) {
+		rows, err := provider.db.QueryContext(ctx, s.text, s.args...)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer rows.Close()
+		element := structOf(s.element)
+		targets := make([]any, len(s.targets))
+		for rows.Next() {
+			item := reflect.New(element)
+			for i, index := range s.targets {
+				targets[i] = item.Elem().FieldByIndex(index).Addr().Interface()
+			}
+			if err := rows.Scan(targets...); err != nil {
+				yield(nil, err)
+				return
+			}
+			if s.element.Kind() != reflect.Pointer {
+				item = item.Elem()
+			}
+			if !yield(item.Interface(), nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}, s.executed
+}
@@ -0,0 +1,416 @@
+package linqsql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/thereisnoplanb/linq"
+	"github.com/thereisnoplanb/linq/linqexpr"
+)
+
+type user struct {
+	ID    int64   `db:"id"`
+	Name  string  `db:"name"`
+	Email *string `db:"email"`
+	Age   int     `db:"age"`
+	Admin bool    `db:"is_admin"`
+	Note  string  `db:"-"`
+}
+
+type contact struct {
+	Name  string
+	Years int
+}
+
+func text(s string) *string {
+	return &s
+}
+
+const selectUsers = "SELECT id, name, email, age, is_admin FROM users"
+
+func TestProvider_Translate(t *testing.T) {
+	name, email, age := linqexpr.Field("Name"), linqexpr.Field("Email"), linqexpr.Field("Age")
+	users := Table[user](nil, "users")
+	tests := []struct {
+		name     string
+		query    linqexpr.Query
+		options  Options
+		text     string
+		args     []any
+		executed int
+	}{
+		{
+			name:  "no operations",
+			query: users.Query(),
+			text:  selectUsers,
+		},
+		{
+			name:     "where, order and take",
+			query:    users.Where(age.Gt(30).And(name.HasPrefix("A"))).OrderByDescending(age).Take(10).Query(),
+			options:  Options{CaseSensitiveLike: true},
+			text:     selectUsers + ` WHERE (age > ? AND name LIKE ? ESCAPE '\') ORDER BY age DESC LIMIT 10`,
+			args:     []any{30, "A%"},
+			executed: 3,
+		},
+		{
+			name:     "nil and like escaping",
+			query:    users.Where(linqexpr.Or(email.Eq(nil), linqexpr.Not(email.Contains(`50%_off\`)))).Query(),
+			options:  Options{CaseSensitiveLike: true},
+			text:     selectUsers + ` WHERE (email IS NULL OR NOT ((email IS NOT NULL AND email LIKE ? ESCAPE '\')))`,
+			args:     []any{`%50\%\_off\\%`},
+			executed: 1,
+		},
+		{
+			name:     "like that ignores case narrows the rows",
+			query:    users.Where(age.Gt(30)).Where(name.HasPrefix("A")).OrderByDescending(age).Take(10).Query(),
+			text:     selectUsers + ` WHERE age > ? AND name LIKE ? ESCAPE '\'`,
+			args:     []any{30, "A%"},
+			executed: 1,
+		},
+		{
+			name:     "like that ignores case under not",
+			query:    users.Where(linqexpr.Not(name.HasSuffix("a"))).Query(),
+			text:     selectUsers,
+			executed: 0,
+		},
+		{
+			name:     "like escape character",
+			query:    users.Where(email.Contains(`50%_off!'`)).Query(),
+			options:  Options{LikeEscape: '!', CaseSensitiveLike: true},
+			text:     selectUsers + ` WHERE email LIKE ? ESCAPE '!'`,
+			args:     []any{`%50!%!_off!!'%`},
+			executed: 1,
+		},
+		{
+			name:     "like escape character that is a quote",
+			query:    users.Where(name.HasPrefix(`O'`)).Query(),
+			options:  Options{LikeEscape: '\'', CaseSensitiveLike: true},
+			text:     selectUsers + ` WHERE name LIKE ? ESCAPE ''''`,
+			args:     []any{`O''%`},
+			executed: 1,
+		},
+		{
+			name:     "order by nullable columns",
+			query:    users.OrderBy(email).ThenByDescending(email).ThenBy(age).Query(),
+			text:     selectUsers + ` ORDER BY email IS NOT NULL, email, email IS NOT NULL DESC, email DESC, age`,
+			executed: 3,
+		},
+		{
+			name:     "not over nullable and non-nullable columns",
+			query:    users.Where(linqexpr.Not(linqexpr.Or(email.Eq("a@example.com"), email.Ne(name)))).Where(linqexpr.Not(age.Lt(18))).Query(),
+			text:     selectUsers + ` WHERE NOT (((email IS NOT NULL AND email = ?) OR (email IS NOT NULL AND email <> name))) AND NOT (age < ?)`,
+			args:     []any{"a@example.com", 18},
+			executed: 2,
+		},
+		{
+			name:     "several wheres and a bool field",
+			query:    users.Where(linqexpr.Field("Admin")).Where(email.Ne(nil)).Where(age.Le(age)).Query(),
+			text:     selectUsers + ` WHERE is_admin = ? AND email IS NOT NULL AND age <= age`,
+			args:     []any{true},
+			executed: 3,
+		},
+		{
+			name:     "order by replaces the ordering",
+			query:    users.OrderBy(name).OrderBy(age).ThenByDescending(linqexpr.Field("ID")).Where(age.Gt(1)).Query(),
+			text:     selectUsers + ` WHERE age > ? ORDER BY age, id DESC`,
+			args:     []any{1},
+			executed: 4,
+		},
+		{
+			name:     "skip and take",
+			query:    users.Skip(5).Take(10).Skip(2).Take(20).Query(),
+			text:     selectUsers + ` LIMIT 8 OFFSET 7`,
+			executed: 4,
+		},
+		{
+			name:     "where after take",
+			query:    users.Take(10).Where(age.Gt(1)).Query(),
+			text:     selectUsers + ` LIMIT 10`,
+			executed: 1,
+		},
+		{
+			name:     "untranslatable condition",
+			query:    users.Where(age.Gt(1).And(name.Contains(email))).Take(1).Query(),
+			text:     selectUsers,
+			executed: 0,
+		},
+		{
+			name:     "field without a column",
+			query:    users.Where(age.Gt(1)).OrderBy(linqexpr.Field("Note")).Query(),
+			text:     selectUsers + ` WHERE age > ?`,
+			args:     []any{1},
+			executed: 1,
+		},
+		{
+			name:     "select",
+			query:    linqexpr.Select[user, contact](users.Where(age.Ge(18)), name.As("Name"), age.As("Years")).Take(3).Query(),
+			text:     `SELECT name, age FROM users WHERE age >= ? LIMIT 3`,
+			args:     []any{18},
+			executed: 3,
+		},
+		{
+			name:     "select by name",
+			query:    linqexpr.Select[user, contact](users).Query(),
+			text:     `SELECT name FROM users`,
+			executed: 1,
+		},
+		{
+			name:     "where after select",
+			query:    linqexpr.Select[user, user](users, name.As("Name")).Where(name.Eq("A")).Query(),
+			text:     `SELECT name FROM users`,
+			executed: 1,
+		},
+		{
+			name:     "options",
+			query:    users.Where(age.Gt(1).And(age.Lt(9))).Skip(2).Query(),
+			options:  Options{Placeholder: DollarNumber, NoLimit: "LIMIT -1"},
+			text:     selectUsers + ` WHERE (age > $1 AND age < $2) LIMIT -1 OFFSET 2`,
+			args:     []any{1, 9},
+			executed: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, args, executed := NewProvider(nil, "users", tt.options).Translate(tt.query)
+			if text != tt.text {
+				t.Errorf("Provider.Translate() text = %v, want %v", text, tt.text)
+			}
+			if len(args) != 0 || len(tt.args) != 0 {
+				if !reflect.DeepEqual(args, tt.args) {
+					t.Errorf("Provider.Translate() args = %v, want %v", args, tt.args)
+				}
+			}
+			if executed != tt.executed {
+				t.Errorf("Provider.Translate() executed = %v, want %v", executed, tt.executed)
+			}
+		})
+	}
+}
+
+var userColumns = []string{"id", "name", "email", "age", "is_admin"}
+
+var userRows = [][]driver.Value{
+	{int64(1), "Alice", "alice@example.com", int64(34), true},
+	{int64(2), "Bob", nil, int64(25), false},
+	{int64(3), "Anna", "anna@example.com", int64(41), false},
+}
+
+func TestTable(t *testing.T) {
+	db, server := newFakeDB(map[string]fakeResult{
+		selectUsers + ` WHERE age > ? ORDER BY name`: {columns: userColumns, rows: userRows},
+	})
+	defer db.Close()
+	query := Table[*user](db, "users").Where(linqexpr.Field("Age").Gt(20)).OrderBy(linqexpr.Field("Name"))
+	got, err := query.Iterator(context.Background()).ToSlice()
+	if err != nil {
+		t.Fatalf("Queryable.Iterator() error = %v", err)
+	}
+	want := []*user{
+		{ID: 1, Name: "Alice", Email: text("alice@example.com"), Age: 34, Admin: true},
+		{ID: 2, Name: "Bob", Age: 25},
+		{ID: 3, Name: "Anna", Email: text("anna@example.com"), Age: 41},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Queryable.Iterator() = %v, want %v", got, want)
+	}
+	if want := []fakeQuery{{selectUsers + ` WHERE age > ? ORDER BY name`, []any{int64(20)}}}; !reflect.DeepEqual(server.queries, want) {
+		t.Errorf("queries = %v, want %v", server.queries, want)
+	}
+	if open := server.openRows(); open != 0 {
+		t.Errorf("%d rows are still open", open)
+	}
+}
+
+func TestTable_notNull(t *testing.T) {
+	// The rows a database returns for the query: NOT over a false comparison keeps Bob, whose email is NULL.
+	db, _ := newFakeDB(map[string]fakeResult{
+		selectUsers + ` WHERE NOT ((email IS NOT NULL AND email = ?))`: {columns: userColumns, rows: [][]driver.Value{userRows[1], userRows[2]}},
+	})
+	defer db.Close()
+	condition := linqexpr.Not(linqexpr.Field("Email").Eq("alice@example.com"))
+	got, err := Table[user](db, "users").Where(condition).Iterator(context.Background()).ToSlice()
+	if err != nil {
+		t.Fatalf("Queryable.Iterator() error = %v", err)
+	}
+	users := []user{
+		{ID: 1, Name: "Alice", Email: text("alice@example.com"), Age: 34, Admin: true},
+		{ID: 2, Name: "Bob", Age: 25},
+		{ID: 3, Name: "Anna", Email: text("anna@example.com"), Age: 41},
+	}
+	want, err := linqexpr.AsQueryable(linq.FromSlice(users)).Where(condition).Iterator(context.Background()).ToSlice()
+	if err != nil {
+		t.Fatalf("Queryable.Iterator() in memory error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Queryable.Iterator() = %v, want %v as in memory", got, want)
+	}
+}
+
+func TestTable_fallback(t *testing.T) {
+	db, server := newFakeDB(map[string]fakeResult{
+		selectUsers + ` WHERE age > ?`: {columns: userColumns, rows: userRows},
+	})
+	defer db.Close()
+	name := linqexpr.Field("Name")
+	users := Table[user](db, "users").
+		Where(linqexpr.Field("Age").Gt(20)).
+		Where(linqexpr.Field("Email").Contains(linqexpr.Field("Note"))).
+		OrderByDescending(name)
+	query := linqexpr.Select[user, contact](users, name.As("Name"), linqexpr.Field("Age").As("Years"))
+	if _, _, executed := NewProvider(db, "users").Translate(query.Query()); executed != 1 {
+		t.Errorf("Provider.Translate() executed = %v, want 1", executed)
+	}
+	got, err := query.Iterator(context.Background()).ToSlice()
+	if err != nil {
+		t.Fatalf("Queryable.Iterator() error = %v", err)
+	}
+	if want := []contact{{Name: "Anna", Years: 41}, {Name: "Alice", Years: 34}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Queryable.Iterator() = %v, want %v", got, want)
+	}
+	if len(server.queries) != 1 {
+		t.Errorf("queries = %v, want one", server.queries)
+	}
+}
+
+func TestTable_errors(t *testing.T) {
+	failure := errors.New("connection reset")
+	db, server := newFakeDB(map[string]fakeResult{
+		selectUsers:               {columns: userColumns, rows: userRows, err: failure},
+		selectUsers + " LIMIT 1":  {columns: userColumns, rows: [][]driver.Value{{"one", "Alice", nil, int64(34), true}}},
+		selectUsers + " LIMIT 10": {columns: userColumns, rows: userRows},
+	})
+	defer db.Close()
+	users := Table[user](db, "users")
+
+	got, err := users.Iterator(context.Background()).ToSlice()
+	if !errors.Is(err, failure) || len(got) != len(userRows) {
+		t.Errorf("Queryable.Iterator() = %v, %v, want %d users and %v", got, err, len(userRows), failure)
+	}
+	if _, err := users.Take(1).Iterator(context.Background()).ToSlice(); err == nil {
+		t.Errorf("Queryable.Iterator() error = nil, want a scan error")
+	}
+	if _, err := users.Take(2).Iterator(context.Background()).ToSlice(); !errors.Is(err, errUnexpectedQuery) {
+		t.Errorf("Queryable.Iterator() error = %v, want %v", err, errUnexpectedQuery)
+	}
+	for range users.Take(10).Iterator(context.Background()) {
+		break
+	}
+	if open := server.openRows(); open != 0 {
+		t.Errorf("%d rows are still open", open)
+	}
+}
+
+// The rows of userRows as users, to evaluate queries in memory.
+var tableUsers = []user{
+	{ID: 1, Name: "Alice", Email: text("alice@example.com"), Age: 34, Admin: true},
+	{ID: 2, Name: "Bob", Age: 25},
+	{ID: 3, Name: "Anna", Email: text("anna@example.com"), Age: 41},
+}
+
+// Returns the result of a query over tableUsers in memory.
+func inMemory(t *testing.T, query func(users linqexpr.Queryable[user]) linqexpr.Queryable[user]) []user {
+	t.Helper()
+	result, err := query(linqexpr.AsQueryable(linq.FromSlice(tableUsers))).Iterator(context.Background()).ToSlice()
+	if err != nil {
+		t.Fatalf("Queryable.Iterator() in memory error = %v", err)
+	}
+	return result
+}
+
+func TestTable_like(t *testing.T) {
+	query := func(users linqexpr.Queryable[user]) linqexpr.Queryable[user] {
+		return users.Where(linqexpr.Field("Name").HasPrefix("a")).OrderBy(linqexpr.Field("Age")).Take(1)
+	}
+	// The rows a database whose LIKE ignores case returns for the query: Alice and Anna match 'a%'.
+	db, server := newFakeDB(map[string]fakeResult{
+		selectUsers + ` WHERE name LIKE ? ESCAPE '\'`: {columns: userColumns, rows: [][]driver.Value{userRows[0], userRows[2]}},
+	})
+	defer db.Close()
+	got, err := query(Table[user](db, "users")).Iterator(context.Background()).ToSlice()
+	if err != nil {
+		t.Fatalf("Queryable.Iterator() error = %v", err)
+	}
+	if want := inMemory(t, query); !reflect.DeepEqual(got, want) {
+		t.Errorf("Queryable.Iterator() = %v, want %v as in memory", got, want)
+	}
+	if want := []fakeQuery{{selectUsers + ` WHERE name LIKE ? ESCAPE '\'`, []any{"a%"}}}; !reflect.DeepEqual(server.queries, want) {
+		t.Errorf("queries = %v, want %v", server.queries, want)
+	}
+}
+
+func TestTable_nullOrder(t *testing.T) {
+	email := linqexpr.Field("Email")
+	tests := []struct {
+		name  string
+		query func(users linqexpr.Queryable[user]) linqexpr.Queryable[user]
+		text  string
+		rows  [][]driver.Value
+	}{
+		{
+			name: "ascending",
+			query: func(users linqexpr.Queryable[user]) linqexpr.Queryable[user] {
+				return users.OrderBy(email)
+			},
+			text: selectUsers + ` ORDER BY email IS NOT NULL, email`,
+			rows: [][]driver.Value{userRows[1], userRows[0], userRows[2]},
+		},
+		{
+			name: "descending",
+			query: func(users linqexpr.Queryable[user]) linqexpr.Queryable[user] {
+				return users.OrderByDescending(email)
+			},
+			text: selectUsers + ` ORDER BY email IS NOT NULL DESC, email DESC`,
+			rows: [][]driver.Value{userRows[2], userRows[0], userRows[1]},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The rows any database returns for the statement, whatever its default position of NULLs.
+			db, _ := newFakeDB(map[string]fakeResult{
+				tt.text: {columns: userColumns, rows: tt.rows},
+			})
+			defer db.Close()
+			got, err := tt.query(Table[user](db, "users")).Iterator(context.Background()).ToSlice()
+			if err != nil {
+				t.Fatalf("Queryable.Iterator() error = %v", err)
+			}
+			if want := inMemory(t, tt.query); !reflect.DeepEqual(got, want) {
+				t.Errorf("Queryable.Iterator() = %v, want %v as in memory", got, want)
+			}
+		})
+	}
+}
+
+func TestTable_thenByAfterWhere(t *testing.T) {
+	type pair struct {
+		A int `db:"a"`
+		B int `db:"b"`
+	}
+	a, b := linqexpr.Field("A"), linqexpr.Field("B")
+	query := func(pairs linqexpr.Queryable[pair]) linqexpr.Queryable[pair] {
+		return pairs.OrderBy(a).Where(a.Gt(0)).ThenBy(b)
+	}
+	const statement = `SELECT a, b FROM pairs WHERE a > ? ORDER BY a, b`
+	// The rows a database returns for the statement.
+	db, server := newFakeDB(map[string]fakeResult{
+		statement: {columns: []string{"a", "b"}, rows: [][]driver.Value{{int64(1), int64(1)}, {int64(1), int64(2)}, {int64(2), int64(1)}, {int64(2), int64(2)}}},
+	})
+	defer db.Close()
+	got, err := query(Table[pair](db, "pairs")).Iterator(context.Background()).ToSlice()
+	if err != nil {
+		t.Fatalf("Queryable.Iterator() error = %v", err)
+	}
+	want, err := query(linqexpr.AsQueryable(linq.FromSlice([]pair{{1, 2}, {2, 1}, {1, 1}, {2, 2}}))).Iterator(context.Background()).ToSlice()
+	if err != nil {
+		t.Fatalf("Queryable.Iterator() in memory error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Queryable.Iterator() = %v, want %v as in memory", got, want)
+	}
+	if len(server.queries) != 1 || server.queries[0].text != statement {
+		t.Errorf("queries = %v, want %v", server.queries, statement)
+	}
+}
@@ -0,0 +1,196 @@
+package linqsql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+var ErrUnmappedColumn = errors.New("the column has no field to scan into")
+var ErrColumnCount = errors.New("a value that is not a struct is scanned from exactly one column")
+
+// The QueryContext method of *sql.DB, *sql.Conn and *sql.Tx.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+var scannerType = reflect.TypeFor[sql.Scanner]()
+var timeType = reflect.TypeFor[time.Time]()
+
+// Reports whether values of a type are scanned from one column rather than field by field.
+func scalar(t reflect.Type) bool {
+	base := structOf(t)
+	return base.Kind() != reflect.Struct || base == timeType || reflect.PointerTo(base).Implements(scannerType)
+}
+
+// Returns the column of a struct with the given name, matched exactly or, failing that, case-insensitively.
+func columnNamed(columns []column, name string) (result column, ok bool) {
+	for _, c := range columns {
+		if c.name == name {
+			return c, true
+		}
+	}
+	for _, c := range columns {
+		if strings.EqualFold(c.name, name) {
+			return c, true
+		}
+	}
+	return column{}, false
+}
+
+// Returns a function that scans the current row into a TSource, matching the columns of the rows to its fields by name.
+func scannerOf[TSource any](rows *sql.Rows) (scan func() (TSource, error), err error) {
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	t := reflect.TypeFor[TSource]()
+	if scalar(t) {
+		if len(names) != 1 {
+			return nil, fmt.Errorf("%w: %v from %d columns", ErrColumnCount, t, len(names))
+		}
+		return func() (item TSource, err error) {
+			err = rows.Scan(&item)
+			return item, err
+		}, nil
+	}
+	element := structOf(t)
+	columns := columnsOf(element)
+	indices := make([][]int, len(names))
+	for i, name := range names {
+		c, ok := columnNamed(columns, name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s of %v", ErrUnmappedColumn, name, element)
+		}
+		indices[i] = c.index
+	}
+	targets := make([]any, len(names))
+	return func() (item TSource, err error) {
+		value := reflect.New(element)
+		for i, index := range indices {
+			targets[i] = value.Elem().FieldByIndex(index).Addr().Interface()
+		}
+		if err := rows.Scan(targets...); err != nil {
+			return item, err
+		}
+		if t.Kind() != reflect.Pointer {
+			value = value.Elem()
+		}
+		return value.Interface().(TSource), nil
+	}, nil
+}
+
+// Creates an ErrorIterator[TSource] from the rows of a query.
+//
+// # Parameters
+//
+//	rows *sql.Rows
+//
+// The rows to scan.
+//
+// # Returns
+//
+//	result linq.ErrorIterator[TSource]
+//
+// An ErrorIterator[TSource] that contains a TSource scanned from each row.
+//
+// # Error
+//
+// The enumeration fails with ErrUnmappedColumn if a column has no field, with ErrColumnCount if TSource is not a struct
+// and there is more than one column, with the error of a scan, or with rows.Err().
+//
+// # Remarks
+//
+// If TSource is a struct type or a pointer to one, each column is scanned into the exported field named by its db tag or,
+// without one, after the field; names are matched exactly first, then case-insensitively. Fields tagged `db:"-"` are never
+// scanned, and the fields of embedded structs are promoted. Pointer fields and fields of the sql.Null types are nil or
+// invalid when the column is NULL. Any other TSource, including time.Time and types that implement sql.Scanner,
+// is scanned from a single column.
+//
+// The rows are closed when the enumeration ends, fails or is stopped. Because rows can be read only once,
+// the result is one-shot: it can be enumerated only once. FromQuery runs the query again on each enumeration.
+func FromRows[TSource any](rows *sql.Rows) (result linq.ErrorIterator[TSource]) {
+	return func(yield func(value TSource, err error) bool) {
+		defer rows.Close()
+		var zero TSource
+		scan, err := scannerOf[TSource](rows)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		for rows.Next() {
+			item, err := scan()
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// Creates an ErrorIterator[TSource] that runs a query and scans its rows.
+//
+// # Parameters
+//
+//	ctx context.Context
+//
+// The context of the query.
+//
+//	db Queryer
+//
+// The *sql.DB, *sql.Conn or *sql.Tx to run the query on.
+//
+//	query string
+//
+// The query.
+//
+//	args ...any
+//
+// The arguments of the placeholders in query.
+//
+// # Returns
+//
+//	result linq.ErrorIterator[TSource]
+//
+// An ErrorIterator[TSource] that contains a TSource scanned from each row, as FromRows scans them.
+//
+// # Error
+//
+// The enumeration fails with the error of the query, or as FromRows fails.
+//
+// # Example
+//
+//	adults := linqsql.FromQuery[User](ctx, db, "SELECT id, name, email FROM users WHERE age >= ?", 18)
+//	for user, err := range adults {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// # Remarks
+//
+// The query runs each time the result is enumerated, and its rows are closed when the enumeration ends, fails or is stopped.
+func FromQuery[TSource any](ctx context.Context, db Queryer, query string, args ...any) (result linq.ErrorIterator[TSource]) {
+	return func(yield func(value TSource, err error) bool) {
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			var zero TSource
+			yield(zero, err)
+			return
+		}
+		FromRows[TSource](rows)(yield)
+	}
+}
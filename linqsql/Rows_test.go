@@ -0,0 +1,141 @@
+package linqsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type profile struct {
+	ID       int64
+	Name     string         `db:"full_name"`
+	Email    sql.NullString `db:"email"`
+	Age      *int           `db:"age"`
+	Nickname *string
+	Note     string `db:"-"`
+}
+
+func number(n int) *int {
+	return &n
+}
+
+const selectProfiles = "SELECT email, ID, full_name, age, nickname FROM profiles"
+
+var profileResult = fakeResult{
+	columns: []string{"email", "ID", "full_name", "age", "nickname"},
+	rows: [][]driver.Value{
+		{"alice@example.com", int64(1), "Alice", int64(34), nil},
+		{nil, int64(2), "Bob", nil, "bobby"},
+	},
+}
+
+var profiles = []profile{
+	{ID: 1, Name: "Alice", Email: sql.NullString{String: "alice@example.com", Valid: true}, Age: number(34)},
+	{ID: 2, Name: "Bob", Nickname: text("bobby")},
+}
+
+func TestFromQuery(t *testing.T) {
+	db, server := newFakeDB(map[string]fakeResult{
+		selectProfiles + " WHERE ID > ?": profileResult,
+	})
+	defer db.Close()
+	query := FromQuery[profile](context.Background(), db, selectProfiles+" WHERE ID > ?", 0)
+	for range 2 {
+		got, err := query.ToSlice()
+		if err != nil {
+			t.Fatalf("FromQuery() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, profiles) {
+			t.Errorf("FromQuery() = %v, want %v", got, profiles)
+		}
+	}
+	if len(server.queries) != 2 || !reflect.DeepEqual(server.queries[1].args, []any{int64(0)}) {
+		t.Errorf("queries = %v, want the query twice with the argument 0", server.queries)
+	}
+
+	pointers, err := FromQuery[*profile](context.Background(), db, selectProfiles+" WHERE ID > ?", 0).ToSlice()
+	if err != nil {
+		t.Fatalf("FromQuery() error = %v", err)
+	}
+	if len(pointers) != 2 || !reflect.DeepEqual(*pointers[1], profiles[1]) {
+		t.Errorf("FromQuery() = %v, want pointers to %v", pointers, profiles)
+	}
+	if open := server.openRows(); open != 0 {
+		t.Errorf("%d rows are still open", open)
+	}
+}
+
+func TestFromQuery_scalar(t *testing.T) {
+	db, _ := newFakeDB(map[string]fakeResult{
+		"SELECT age FROM profiles": {columns: []string{"age"}, rows: [][]driver.Value{{int64(34)}, {nil}}},
+		selectProfiles:             profileResult,
+	})
+	defer db.Close()
+	ages, err := FromQuery[sql.NullInt64](context.Background(), db, "SELECT age FROM profiles").ToSlice()
+	if want := []sql.NullInt64{{Int64: 34, Valid: true}, {}}; err != nil || !reflect.DeepEqual(ages, want) {
+		t.Errorf("FromQuery() = %v, %v, want %v", ages, err, want)
+	}
+	pointers, err := FromQuery[*int](context.Background(), db, "SELECT age FROM profiles").ToSlice()
+	if err != nil || len(pointers) != 2 || *pointers[0] != 34 || pointers[1] != nil {
+		t.Errorf("FromQuery() = %v, %v, want 34 and nil", pointers, err)
+	}
+	if _, err := FromQuery[int](context.Background(), db, selectProfiles).ToSlice(); !errors.Is(err, ErrColumnCount) {
+		t.Errorf("FromQuery() error = %v, want %v", err, ErrColumnCount)
+	}
+}
+
+func TestFromQuery_errors(t *testing.T) {
+	failure := errors.New("connection reset")
+	db, server := newFakeDB(map[string]fakeResult{
+		selectProfiles:            {columns: profileResult.columns, rows: profileResult.rows, err: failure},
+		"SELECT extra FROM users": {columns: []string{"extra"}, rows: [][]driver.Value{{int64(1)}}},
+		"SELECT age FROM users":   {columns: []string{"age"}, rows: [][]driver.Value{{"old"}}},
+	})
+	defer db.Close()
+	ctx := context.Background()
+
+	got, err := FromQuery[profile](ctx, db, selectProfiles).ToSlice()
+	if !errors.Is(err, failure) || !reflect.DeepEqual(got, profiles) {
+		t.Errorf("FromQuery() = %v, %v, want %v and %v", got, err, profiles, failure)
+	}
+	if _, err := FromQuery[profile](ctx, db, "SELECT extra FROM users").ToSlice(); !errors.Is(err, ErrUnmappedColumn) {
+		t.Errorf("FromQuery() error = %v, want %v", err, ErrUnmappedColumn)
+	}
+	if _, err := FromQuery[profile](ctx, db, "SELECT age FROM users").ToSlice(); err == nil {
+		t.Errorf("FromQuery() error = nil, want a scan error")
+	}
+	if _, err := FromQuery[profile](ctx, db, "SELECT 1").ToSlice(); !errors.Is(err, errUnexpectedQuery) {
+		t.Errorf("FromQuery() error = %v, want %v", err, errUnexpectedQuery)
+	}
+	for range FromQuery[profile](ctx, db, selectProfiles) {
+		break
+	}
+	if open := server.openRows(); open != 0 {
+		t.Errorf("%d rows are still open", open)
+	}
+}
+
+func TestFromRows(t *testing.T) {
+	db, server := newFakeDB(map[string]fakeResult{
+		selectProfiles: profileResult,
+	})
+	defer db.Close()
+	rows, err := db.QueryContext(context.Background(), selectProfiles)
+	if err != nil {
+		t.Fatalf("QueryContext() error = %v", err)
+	}
+	source := FromRows[profile](rows)
+	got, err := source.ToSlice()
+	if err != nil || !reflect.DeepEqual(got, profiles) {
+		t.Errorf("FromRows() = %v, %v, want %v", got, err, profiles)
+	}
+	if open := server.openRows(); open != 0 {
+		t.Errorf("%d rows are still open", open)
+	}
+	if got, err := source.ToSlice(); err == nil || len(got) != 0 {
+		t.Errorf("FromRows() enumerated twice = %v, %v, want an error", got, err)
+	}
+}
@@ -0,0 +1,222 @@
+package linqsql
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/thereisnoplanb/linq/linqexpr"
+)
+
+var operators = map[linqexpr.Operator]string{
+	linqexpr.Equal:          "=",
+	linqexpr.NotEqual:       "<>",
+	linqexpr.Less:           "<",
+	linqexpr.LessOrEqual:    "<=",
+	linqexpr.Greater:        ">",
+	linqexpr.GreaterOrEqual: ">=",
+}
+
+// Translates expressions to SQL, collecting the arguments of the placeholders.
+type translator struct {
+	provider *Provider
+	element  reflect.Type
+	columns  []column
+	args     []any
+
+	// The number of NOT the expression being translated is under.
+	negated int
+
+	// True if the condition being translated holds for more rows in SQL than in memory, so that it must be applied again.
+	narrows bool
+}
+
+// Returns a placeholder for an argument.
+func (t *translator) argument(value any) string {
+	t.args = append(t.args, value)
+	return t.provider.options.Placeholder(len(t.args))
+}
+
+// Translates a condition, or returns false if SQL cannot express it.
+func (t *translator) condition(expression linqexpr.Expression) (result string, ok bool) {
+	switch expression := expression.(type) {
+	case linqexpr.BinaryExpression:
+		switch expression.Operator {
+		case linqexpr.AndAlso, linqexpr.OrElse:
+			left, ok := t.condition(expression.Left)
+			if !ok {
+				return "", false
+			}
+			right, ok := t.condition(expression.Right)
+			if !ok {
+				return "", false
+			}
+			if expression.Operator == linqexpr.AndAlso {
+				return "(" + left + " AND " + right + ")", true
+			}
+			return "(" + left + " OR " + right + ")", true
+		}
+		return t.comparison(expression)
+	case linqexpr.NotExpression:
+		t.negated++
+		operand, ok := t.condition(expression.Operand)
+		t.negated--
+		if !ok {
+			return "", false
+		}
+		return "NOT (" + operand + ")", true
+	case linqexpr.FieldExpression:
+		field, ok := t.operand(expression)
+		if !ok {
+			return "", false
+		}
+		return t.guard(field+" = "+t.argument(true), expression), true
+	case linqexpr.ConstantExpression:
+		if value, ok := expression.Value.(bool); ok {
+			if value {
+				return "1 = 1", true
+			}
+			return "1 = 0", true
+		}
+	}
+	return "", false
+}
+
+func (t *translator) comparison(expression linqexpr.BinaryExpression) (result string, ok bool) {
+	left, right := expression.Left, expression.Right
+	if isNil(left) {
+		left, right = right, left
+	}
+	if isNil(right) {
+		operand, ok := t.operand(left)
+		if !ok {
+			return "", false
+		}
+		if expression.Operator == linqexpr.Equal {
+			return operand + " IS NULL", true
+		}
+		return operand + " IS NOT NULL", true
+	}
+	switch expression.Operator {
+	case linqexpr.Contains, linqexpr.HasPrefix, linqexpr.HasSuffix:
+		constant, ok := right.(linqexpr.ConstantExpression)
+		if !ok {
+			return "", false
+		}
+		operand, ok := t.operand(left)
+		if !ok {
+			return "", false
+		}
+		if !t.provider.options.CaseSensitiveLike {
+			if t.negated > 0 {
+				return "", false
+			}
+			t.narrows = true
+		}
+		pattern := t.provider.likeEscaper.Replace(reflect.Indirect(reflect.ValueOf(constant.Value)).String())
+		switch expression.Operator {
+		case linqexpr.Contains:
+			pattern = "%" + pattern + "%"
+		case linqexpr.HasPrefix:
+			pattern = pattern + "%"
+		case linqexpr.HasSuffix:
+			pattern = "%" + pattern
+		}
+		escape := strings.ReplaceAll(string(t.provider.options.LikeEscape), "'", "''")
+		return t.guard(operand+" LIKE "+t.argument(pattern)+" ESCAPE '"+escape+"'", left), true
+	}
+	operator, ok := operators[expression.Operator]
+	if !ok {
+		return "", false
+	}
+	first, ok := t.operand(left)
+	if !ok {
+		return "", false
+	}
+	second, ok := t.operand(right)
+	if !ok {
+		return "", false
+	}
+	return t.guard(first+" "+operator+" "+second, left, right), true
+}
+
+// Makes a comparison false instead of NULL when a nullable column it reads is NULL, if the comparison is negated.
+//
+// In memory, a comparison with a nil field is false, so its negation is true. In SQL, it is NULL, and so is its negation,
+// which drops the row. Outside NOT, NULL drops a row as false does, and the comparison is returned unchanged.
+func (t *translator) guard(comparison string, operands ...linqexpr.Expression) (result string) {
+	if t.negated == 0 {
+		return comparison
+	}
+	guards := ""
+	for _, operand := range operands {
+		if name, ok := t.nullable(operand); ok {
+			guards += name + " IS NOT NULL AND "
+		}
+	}
+	if guards == "" {
+		return comparison
+	}
+	return "(" + guards + comparison + ")"
+}
+
+// Returns the column of a field that can be nil, or false for other expressions.
+func (t *translator) nullable(expression linqexpr.Expression) (name string, ok bool) {
+	field, ok := expression.(linqexpr.FieldExpression)
+	if !ok {
+		return "", false
+	}
+	c, ok := columnOf(t.columns, field.Name)
+	if !ok || t.element.FieldByIndex(c.index).Type.Kind() != reflect.Pointer {
+		return "", false
+	}
+	return c.name, true
+}
+
+// Translates a field to its column and a constant to a placeholder, or returns false for other expressions.
+func (t *translator) operand(expression linqexpr.Expression) (result string, ok bool) {
+	switch expression := expression.(type) {
+	case linqexpr.FieldExpression:
+		c, ok := columnOf(t.columns, expression.Name)
+		return c.name, ok
+	case linqexpr.ConstantExpression:
+		value := reflect.ValueOf(expression.Value)
+		for value.Kind() == reflect.Pointer && !value.IsNil() {
+			value = value.Elem()
+		}
+		return t.argument(value.Interface()), true
+	}
+	return "", false
+}
+
+// Translates the assignments of a Select to the columns to read and the fields of the result to scan them into.
+func (t *translator) projection(operation linqexpr.SelectOperation) (names []string, targets [][]int, ok bool) {
+	result := structOf(operation.ResultType)
+	assignments := operation.Assignments
+	if len(assignments) == 0 {
+		for _, f := range reflect.VisibleFields(result) {
+			if source, ok := t.element.FieldByName(f.Name); ok && source.IsExported() && f.IsExported() && !f.Anonymous {
+				assignments = append(assignments, linqexpr.Field(f.Name).As(f.Name))
+			}
+		}
+	}
+	for _, assignment := range assignments {
+		field, ok := assignment.Value.(linqexpr.FieldExpression)
+		if !ok {
+			return nil, nil, false
+		}
+		c, ok := columnOf(t.columns, field.Name)
+		if !ok {
+			return nil, nil, false
+		}
+		f, _ := result.FieldByName(assignment.Name)
+		names = append(names, c.name)
+		targets = append(targets, f.Index)
+	}
+	return names, targets, true
+}
+
+// Reports whether an expression is the constant nil.
+func isNil(expression linqexpr.Expression) bool {
+	constant, ok := expression.(linqexpr.ConstantExpression)
+	return ok && constant.Value == nil
+}
@@ -0,0 +1,17 @@
+// Package linqsql runs linqexpr queries against database/sql tables.
+//
+// Table returns a linqexpr.Queryable whose Provider translates Where, OrderBy, ThenBy, Skip, Take and Select into a
+// parameterized SELECT statement, scans the rows into structs, and leaves whatever SQL cannot express to run in memory:
+//
+//	adults := linqsql.Table[User](db, "users", linqsql.Options{Placeholder: linqsql.DollarNumber, CaseSensitiveLike: true}).
+//		Where(linqexpr.Field("Age").Ge(18).And(linqexpr.Field("Name").HasPrefix(prefix))).
+//		OrderBy(linqexpr.Field("Name")).
+//		Take(20)
+//
+// runs SELECT id, name, age FROM users WHERE (age >= $1 AND name LIKE $2 ESCAPE '\') ORDER BY name LIMIT 20
+// with the arguments 18 and prefix + "%" on PostgreSQL. Constants are always passed as arguments, never written into the statement.
+// The Options describe the SQL dialect: the placeholders, the LIKE escape character and case sensitivity, and how to skip without a limit.
+//
+// FromQuery and FromRows scan the rows of any query into structs by column name, and report the errors of the query
+// through a linq.ErrorIterator. ExecBatch executes a statement for each element of a sequence, in batched transactions.
+package linqsql
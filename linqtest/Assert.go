@@ -0,0 +1,81 @@
+package linqtest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+// Checks that a sequence yields the expected elements in order.
+//
+// # Parameters
+//
+//	t testing.TB
+//
+// The test to report a failure to.
+//
+//	got linq.Iterator[TSource]
+//
+// The sequence to check. It is enumerated once.
+//
+//	want []TSource
+//
+// The expected elements.
+//
+// # Returns
+//
+//	result bool
+//
+// True if the sequence yielded the expected elements; otherwise, false, and the test is marked as failed.
+//
+// # Remarks
+//
+// Elements are compared with reflect.DeepEqual. An empty sequence matches both a nil and an empty want.
+func AssertSequence[TSource any](t testing.TB, got linq.Iterator[TSource], want []TSource) (result bool) {
+	t.Helper()
+	elements := got.ToSlice()
+	if len(elements) == 0 && len(want) == 0 {
+		return true
+	}
+	if !reflect.DeepEqual(elements, want) {
+		t.Errorf("sequence = %v, want %v", elements, want)
+		return false
+	}
+	return true
+}
+
+// Checks that a sequence yields the expected elements in any order.
+//
+// # Parameters
+//
+//	t testing.TB
+//
+// The test to report a failure to.
+//
+//	got linq.Iterator[TSource]
+//
+// The sequence to check. It is enumerated once.
+//
+//	want []TSource
+//
+// The expected elements. Repeated elements must be yielded as many times as they occur.
+//
+// # Returns
+//
+//	result bool
+//
+// True if the sequence yielded the expected elements; otherwise, false, and the test is marked as failed.
+//
+// # Remarks
+//
+// Elements are compared with reflect.DeepEqual.
+func AssertUnordered[TSource any](t testing.TB, got linq.Iterator[TSource], want []TSource) (result bool) {
+	t.Helper()
+	elements := got.ToSlice()
+	if len(elements) != len(want) || !contains(want, elements) {
+		t.Errorf("sequence = %v, want %v in any order", elements, want)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package linqtest
+
+import (
+	"testing"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+func TestAssertSequence(t *testing.T) {
+	tests := []struct {
+		name string
+		got  linq.Iterator[int]
+		want []int
+		ok   bool
+	}{
+		{
+			name: "equal",
+			got:  linq.Range(1, 3),
+			want: []int{1, 2, 3},
+			ok:   true,
+		},
+		{
+			name: "empty, nil want",
+			got:  linq.Range(1, 0),
+			ok:   true,
+		},
+		{
+			name: "other order",
+			got:  linq.Range(1, 3).Reverse(),
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "shorter",
+			got:  linq.Range(1, 2),
+			want: []int{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			if got := AssertSequence(r, tt.got, tt.want); got != tt.ok || (len(r.failures) == 0) != tt.ok {
+				t.Errorf("AssertSequence() = %v with failures %q, want %v", got, r.failures, tt.ok)
+			}
+		})
+	}
+}
+
+func TestAssertUnordered(t *testing.T) {
+	tests := []struct {
+		name string
+		got  linq.Iterator[int]
+		want []int
+		ok   bool
+	}{
+		{
+			name: "other order",
+			got:  linq.FromSlice([]int{3, 1, 2, 1}),
+			want: []int{1, 1, 2, 3},
+			ok:   true,
+		},
+		{
+			name: "repeated element missing",
+			got:  linq.FromSlice([]int{3, 1, 2, 2}),
+			want: []int{1, 1, 2, 3},
+		},
+		{
+			name: "longer",
+			got:  linq.FromSlice([]int{1, 2, 3}),
+			want: []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			if got := AssertUnordered(r, tt.got, tt.want); got != tt.ok || (len(r.failures) == 0) != tt.ok {
+				t.Errorf("AssertUnordered() = %v with failures %q, want %v", got, r.failures, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package linqtest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+// Changes what CheckIterator expects from a sequence.
+type Option func(options *options)
+
+type options struct {
+	unordered  bool
+	singlePass bool
+}
+
+// Compares the elements without regard to their order, for sequences backed by maps or hashing.
+// An early stop must then yield a part of the expected elements rather than a prefix of them.
+func Unordered() Option {
+	return func(options *options) {
+		options.unordered = true
+	}
+}
+
+// Marks a sequence that can be enumerated only once, such as the branches of Tee or Share.
+// CheckIterator then creates a new sequence for every pass instead of enumerating the same one again.
+func SinglePass() Option {
+	return func(options *options) {
+		options.singlePass = true
+	}
+}
+
+// Checks that a sequence keeps the contract of linq.Iterator.
+//
+// # Parameters
+//
+//	t testing.TB
+//
+// The test to report failures to.
+//
+//	newSource func() linq.Iterator[TSource]
+//
+// A function that creates the sequence to check. Unless SinglePass is given, it is called once and the sequence it returns is enumerated on every pass.
+//
+//	want []TSource
+//
+// The elements the sequence is expected to yield.
+//
+//	options ...Option
+//
+// Optional. Unordered, SinglePass.
+//
+// # Remarks
+//
+// The sequence is enumerated to the end, enumerated to the end again, stopped after each of its elements in turn,
+// and enumerated to the end once more. Every pass must yield the expected elements, or, if it is stopped, the ones before the stop.
+// No pass may call yield after yield has returned false. Elements are compared with reflect.DeepEqual.
+func CheckIterator[TSource any](t testing.TB, newSource func() linq.Iterator[TSource], want []TSource, options ...Option) {
+	t.Helper()
+	c := checker[TSource]{
+		t:         t,
+		newSource: newSource,
+		want:      want,
+	}
+	for _, option := range options {
+		option(&c.options)
+	}
+	c.source = newSource()
+	if !c.pass("first enumeration", c.source, len(want)+1) {
+		return
+	}
+	if !c.pass("second enumeration", c.next(), len(want)+1) {
+		return
+	}
+	for stop := 1; stop <= len(want); stop++ {
+		if !c.pass(fmt.Sprintf("enumeration stopped after %d of %d elements", stop, len(want)), c.next(), stop) {
+			return
+		}
+	}
+	c.pass("enumeration after the early stops", c.next(), len(want)+1)
+}
+
+type checker[TSource any] struct {
+	t         testing.TB
+	newSource func() linq.Iterator[TSource]
+	source    linq.Iterator[TSource]
+	want      []TSource
+	options   options
+}
+
+// Returns the sequence to enumerate on the next pass.
+func (c *checker[TSource]) next() linq.Iterator[TSource] {
+	if c.options.singlePass {
+		return c.newSource()
+	}
+	return c.source
+}
+
+// Enumerates source until it ends or stop elements have been yielded, and reports whether it kept the contract.
+func (c *checker[TSource]) pass(name string, source linq.Iterator[TSource], stop int) bool {
+	c.t.Helper()
+	got := make([]TSource, 0, len(c.want))
+	stopped := false
+	late := 0
+	source(func(value TSource) bool {
+		if stopped {
+			late++
+			return false
+		}
+		got = append(got, value)
+		if len(got) == stop {
+			stopped = true
+		}
+		return !stopped
+	})
+	if late > 0 {
+		c.t.Errorf("%s: yield called %d more times after it returned false", name, late)
+		return false
+	}
+	want := c.want
+	if stop <= len(want) {
+		want = want[:stop]
+	}
+	if c.options.unordered {
+		if len(got) != len(want) || !contains(c.want, got) {
+			c.t.Errorf("%s = %v, want %d of %v in any order", name, got, len(want), c.want)
+			return false
+		}
+		return true
+	}
+	if !reflect.DeepEqual(got, want) && (len(got) != 0 || len(want) != 0) {
+		c.t.Errorf("%s = %v, want %v", name, got, want)
+		return false
+	}
+	return true
+}
+
+// Reports whether every element of part occurs in whole, counting repeated elements.
+func contains[TSource any](whole []TSource, part []TSource) bool {
+	used := make([]bool, len(whole))
+	for _, item := range part {
+		found := false
+		for i, candidate := range whole {
+			if !used[i] && reflect.DeepEqual(item, candidate) {
+				used[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+package linqtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+// Records the failures CheckIterator reports instead of failing the test.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestCheckIterator(t *testing.T) {
+	tests := []struct {
+		name      string
+		newSource func() linq.Iterator[int]
+		want      []int
+		options   []Option
+		fails     bool
+	}{
+		{
+			name: "conforming",
+			newSource: func() linq.Iterator[int] {
+				return linq.Range(1, 3)
+			},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "wrong elements",
+			newSource: func() linq.Iterator[int] {
+				return linq.Range(1, 3)
+			},
+			want:  []int{1, 2, 4},
+			fails: true,
+		},
+		{
+			name: "consumed by the first enumeration",
+			newSource: func() linq.Iterator[int] {
+				count := 3
+				return func(yield func(value int) bool) {
+					for ; count > 0; count-- {
+						if !yield(count) {
+							return
+						}
+					}
+				}
+			},
+			want:  []int{3, 2, 1},
+			fails: true,
+		},
+		{
+			name: "yields after yield returned false",
+			newSource: func() linq.Iterator[int] {
+				return func(yield func(value int) bool) {
+					for i := range 3 {
+						yield(i)
+					}
+				}
+			},
+			want:  []int{0, 1, 2},
+			fails: true,
+		},
+		{
+			name: "one-shot",
+			newSource: func() linq.Iterator[int] {
+				return linq.FromSlice([]int{1, 2, 3}).Share()
+			},
+			want:    []int{1, 2, 3},
+			options: []Option{SinglePass()},
+		},
+		{
+			name: "unordered",
+			newSource: func() linq.Iterator[int] {
+				return linq.FromSlice([]int{3, 1, 2})
+			},
+			want:    []int{1, 2, 3},
+			options: []Option{Unordered()},
+		},
+		{
+			name: "unordered, wrong elements",
+			newSource: func() linq.Iterator[int] {
+				return linq.FromSlice([]int{3, 3, 2})
+			},
+			want:    []int{1, 2, 3},
+			options: []Option{Unordered()},
+			fails:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			CheckIterator(r, tt.newSource, tt.want, tt.options...)
+			if got := len(r.failures) > 0; got != tt.fails {
+				t.Errorf("CheckIterator() failures = %q, want failures %v", r.failures, tt.fails)
+			}
+		})
+	}
+}
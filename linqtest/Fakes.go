@@ -0,0 +1,86 @@
+package linqtest
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+// The value Panicking panics with.
+var ErrInjectedPanic = errors.New("linqtest: injected panic")
+
+// Wraps a sequence so that the test fails if it is enumerated more than once.
+//
+// # Parameters
+//
+//	t testing.TB
+//
+// The test to report the failure to.
+//
+//	source linq.Iterator[TSource]
+//
+// The sequence to wrap.
+//
+// # Returns
+//
+//	result linq.Iterator[TSource]
+//
+// A linq.Iterator[TSource] that yields the elements of source on its first enumeration and nothing on the following ones.
+//
+// # Remarks
+//
+// Use it as the input of an operator that claims to enumerate its source at most once, such as Memoize, Tee or Share.
+// The failure is reported with t.Errorf, so the wrapper can be enumerated from other goroutines.
+func OneShot[TSource any](t testing.TB, source linq.Iterator[TSource]) (result linq.Iterator[TSource]) {
+	var used atomic.Bool
+	return func(yield func(value TSource) bool) {
+		if used.Swap(true) {
+			t.Errorf("OneShot: sequence enumerated more than once")
+			return
+		}
+		for item := range source {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Wraps a sequence so that it panics instead of yielding one of its elements.
+//
+// # Parameters
+//
+//	source linq.Iterator[TSource]
+//
+// The sequence to wrap.
+//
+//	n int
+//
+// The zero-based index of the element to panic at.
+//
+// # Returns
+//
+//	result linq.Iterator[TSource]
+//
+// A linq.Iterator[TSource] that yields the first n elements of source, then panics with ErrInjectedPanic.
+// If source has n elements or fewer, the result ends with it and does not panic.
+//
+// # Remarks
+//
+// Use it to check that an operator releases what it holds, such as files, goroutines or pulled iterators, when its source panics.
+func Panicking[TSource any](source linq.Iterator[TSource], n int) (result linq.Iterator[TSource]) {
+	return func(yield func(value TSource) bool) {
+		i := 0
+		for item := range source {
+			if i == n {
+				panic(ErrInjectedPanic)
+			}
+			i++
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package linqtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+func TestOneShot(t *testing.T) {
+	r := &recorder{TB: t}
+	memoized := OneShot(r, linq.Range(1, 3)).Memoize()
+	AssertSequence(t, memoized, []int{1, 2, 3})
+	AssertSequence(t, memoized, []int{1, 2, 3})
+	if len(r.failures) > 0 {
+		t.Errorf("OneShot() reported %q after Memoize, want no failures", r.failures)
+	}
+	source := OneShot(r, linq.Range(1, 3))
+	source.Count()
+	if got := source.Count(); got != 0 || len(r.failures) != 1 {
+		t.Errorf("OneShot() second enumeration = %v elements with failures %q, want 0 with one failure", got, r.failures)
+	}
+}
+
+func TestPanicking(t *testing.T) {
+	AssertSequence(t, Panicking(linq.Range(1, 3), 3), []int{1, 2, 3})
+	AssertSequence(t, Panicking(linq.Range(1, 3), 5).Take(2), []int{1, 2})
+	got := make([]int, 0)
+	func() {
+		defer func() {
+			if r := recover(); !errors.Is(r.(error), ErrInjectedPanic) {
+				t.Errorf("Panicking() panicked with %v, want %v", r, ErrInjectedPanic)
+			}
+		}()
+		for item := range Panicking(linq.Range(1, 3), 2) {
+			got = append(got, item)
+		}
+	}()
+	if len(got) != 2 {
+		t.Errorf("Panicking() yielded %v before panicking, want 2 elements", got)
+	}
+}
@@ -0,0 +1,219 @@
+package linqtest
+
+import (
+	"testing"
+
+	"github.com/thereisnoplanb/generic"
+	"github.com/thereisnoplanb/linq"
+)
+
+// Checks that Where(predicate).Count() equals Count(predicate) for random sequences.
+//
+// # Parameters
+//
+//	t testing.TB
+//
+// The test to report a counterexample to.
+//
+//	elements Generator[[]TSource]
+//
+// The generator of the elements of the sequences.
+//
+//	source func(elements []TSource) linq.Iterator[TSource]
+//
+// A function that builds the sequence under test from the generated elements, for example linq.FromSlice[[]TSource] or a custom source.
+//
+//	predicate generic.Predicate[TSource]
+//
+// The condition to count by.
+//
+// # Returns
+//
+//	result bool
+//
+// True if the law held for every input; otherwise, false, and the elements of the first counterexample are reported.
+//
+// # Remarks
+//
+// The sequences of this and the other Check functions of this file are enumerated more than once, so source must return
+// a re-enumerable sequence.
+func CheckWhereCount[TSource any](t testing.TB, elements Generator[[]TSource], source func(elements []TSource) linq.Iterator[TSource], predicate generic.Predicate[TSource]) (result bool) {
+	t.Helper()
+	return ForAll(t, elements, func(values []TSource) bool {
+		sequence := source(values)
+		return sequence.Where(predicate).Count() == sequence.Count(predicate)
+	})
+}
+
+// Checks that Reverse().Reverse() yields the elements of random sequences unchanged.
+//
+// # Parameters
+//
+//	t testing.TB
+//
+// The test to report a counterexample to.
+//
+//	elements Generator[[]TSource]
+//
+// The generator of the elements of the sequences.
+//
+//	source func(elements []TSource) linq.Iterator[TSource]
+//
+// A function that builds the sequence under test from the generated elements.
+//
+// # Returns
+//
+//	result bool
+//
+// True if the law held for every input; otherwise, false, and the elements of the first counterexample are reported.
+func CheckReverseTwice[TSource any](t testing.TB, elements Generator[[]TSource], source func(elements []TSource) linq.Iterator[TSource]) (result bool) {
+	t.Helper()
+	return ForAll(t, elements, func(values []TSource) bool {
+		sequence := source(values)
+		return sequence.Reverse().Reverse().SequenceEqual(sequence)
+	})
+}
+
+// Checks that Take(n).Concat(Skip(n)) yields the elements of random sequences unchanged, for random counts n.
+//
+// # Parameters
+//
+//	t testing.TB
+//
+// The test to report a counterexample to.
+//
+//	elements Generator[[]TSource]
+//
+// The generator of the elements of the sequences.
+//
+//	source func(elements []TSource) linq.Iterator[TSource]
+//
+// A function that builds the sequence under test from the generated elements.
+//
+//	counts Generator[int]
+//
+// The generator of n. Counts greater than the length of the sequence are worth checking too.
+//
+// # Returns
+//
+//	result bool
+//
+// True if the law held for every input; otherwise, false, and the elements and count of the first counterexample are reported.
+func CheckTakeSkip[TSource any](t testing.TB, elements Generator[[]TSource], source func(elements []TSource) linq.Iterator[TSource], counts Generator[int]) (result bool) {
+	t.Helper()
+	return ForAll(t, Pair(elements, counts), func(input generic.ValuePair[[]TSource, int]) bool {
+		sequence := source(input.Item1)
+		return sequence.Take(input.Item2).Concat(sequence.Skip(input.Item2)).SequenceEqual(sequence)
+	})
+}
+
+// Checks that TakeLast(n) equals Reverse().Take(n).Reverse() and that SkipLast(n).Concat(TakeLast(n)) yields the elements
+// of random sequences unchanged, for random counts n.
+//
+// # Parameters
+//
+//	t testing.TB
+//
+// The test to report a counterexample to.
+//
+//	elements Generator[[]TSource]
+//
+// The generator of the elements of the sequences.
+//
+//	source func(elements []TSource) linq.Iterator[TSource]
+//
+// A function that builds the sequence under test from the generated elements.
+//
+//	counts Generator[int]
+//
+// The generator of n.
+//
+// # Returns
+//
+//	result bool
+//
+// True if both laws held for every input; otherwise, false, and the elements and count of the first counterexample are reported.
+func CheckTakeLastSkipLast[TSource any](t testing.TB, elements Generator[[]TSource], source func(elements []TSource) linq.Iterator[TSource], counts Generator[int]) (result bool) {
+	t.Helper()
+	return ForAll(t, Pair(elements, counts), func(input generic.ValuePair[[]TSource, int]) bool {
+		sequence, n := source(input.Item1), input.Item2
+		return sequence.TakeLast(n).SequenceEqual(sequence.Reverse().Take(n).Reverse()) &&
+			sequence.SkipLast(n).Concat(sequence.TakeLast(n)).SequenceEqual(sequence)
+	})
+}
+
+// Checks that Order(compare) yields the elements of random sequences in ascending order and that ordering them again
+// changes nothing.
+//
+// # Parameters
+//
+//	t testing.TB
+//
+// The test to report a counterexample to.
+//
+//	elements Generator[[]TSource]
+//
+// The generator of the elements of the sequences.
+//
+//	source func(elements []TSource) linq.Iterator[TSource]
+//
+// A function that builds the sequence under test from the generated elements.
+//
+//	compare generic.Comparison[TSource]
+//
+// The comparison to order by, for example cmp.Compare[int].
+//
+// # Returns
+//
+//	result bool
+//
+// True if the law held for every input; otherwise, false, and the elements of the first counterexample are reported.
+func CheckOrderTwice[TSource any](t testing.TB, elements Generator[[]TSource], source func(elements []TSource) linq.Iterator[TSource], compare generic.Comparison[TSource]) (result bool) {
+	t.Helper()
+	return ForAll(t, elements, func(values []TSource) bool {
+		sequence := source(values)
+		ordered := sequence.Order(compare)
+		previous, first := *new(TSource), true
+		for item := range ordered {
+			if !first && compare(previous, item) > 0 {
+				return false
+			}
+			previous, first = item, false
+		}
+		return ordered.Count() == sequence.Count() && sequence.All(func(item TSource) bool {
+			return ordered.Contains(item)
+		}) && ordered.Order(compare).SequenceEqual(ordered)
+	})
+}
+
+// Checks that Distinct() yields every element of random sequences once and that applying it again changes nothing.
+//
+// # Parameters
+//
+//	t testing.TB
+//
+// The test to report a counterexample to.
+//
+//	elements Generator[[]TSource]
+//
+// The generator of the elements of the sequences.
+//
+//	source func(elements []TSource) linq.Iterator[TSource]
+//
+// A function that builds the sequence under test from the generated elements.
+//
+// # Returns
+//
+//	result bool
+//
+// True if the law held for every input; otherwise, false, and the elements of the first counterexample are reported.
+func CheckDistinctTwice[TSource any](t testing.TB, elements Generator[[]TSource], source func(elements []TSource) linq.Iterator[TSource]) (result bool) {
+	t.Helper()
+	return ForAll(t, elements, func(values []TSource) bool {
+		sequence := source(values)
+		distinct := sequence.Distinct()
+		return distinct.Distinct().SequenceEqual(distinct) && sequence.All(func(item TSource) bool {
+			return distinct.Contains(item)
+		})
+	})
+}
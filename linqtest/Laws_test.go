@@ -0,0 +1,77 @@
+package linqtest
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+// The ways the law tests build a sequence: through random access and through an opaque sequence.
+var sources = map[string]func(values []int) linq.Iterator[int]{
+	"FromSlice": linq.FromSlice[[]int],
+	"Spy": func(values []int) linq.Iterator[int] {
+		return NewSpy(linq.FromSlice(values)).Iterator()
+	},
+}
+
+func TestCheckLaws(t *testing.T) {
+	numbers := SliceOf(IntN(10), 20)
+	counts := IntN(25)
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			CheckWhereCount(t, numbers, source, isEven)
+			CheckReverseTwice(t, numbers, source)
+			CheckTakeSkip(t, numbers, source, counts)
+			CheckTakeLastSkipLast(t, numbers, source, counts)
+			CheckOrderTwice(t, numbers, source, cmp.Compare[int])
+			CheckDistinctTwice(t, numbers, source)
+		})
+	}
+}
+
+func TestCheckLaws_counterexample(t *testing.T) {
+	numbers := SliceOf(IntN(10), 20)
+	counts := IntN(25)
+	// Yields one element fewer on every enumeration, as a sequence that is consumed by enumerating it does.
+	shrinking := func(values []int) linq.Iterator[int] {
+		enumerations := 0
+		return func(yield func(value int) bool) {
+			skipped := min(enumerations, len(values))
+			enumerations++
+			for _, item := range values[skipped:] {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+	laws := map[string]func(t testing.TB) bool{
+		"CheckWhereCount": func(t testing.TB) bool {
+			return CheckWhereCount(t, numbers, shrinking, isEven)
+		},
+		"CheckReverseTwice": func(t testing.TB) bool {
+			return CheckReverseTwice(t, numbers, shrinking)
+		},
+		"CheckTakeSkip": func(t testing.TB) bool {
+			return CheckTakeSkip(t, numbers, shrinking, counts)
+		},
+		"CheckTakeLastSkipLast": func(t testing.TB) bool {
+			return CheckTakeLastSkipLast(t, numbers, shrinking, counts)
+		},
+		"CheckOrderTwice": func(t testing.TB) bool {
+			return CheckOrderTwice(t, numbers, shrinking, cmp.Compare[int])
+		},
+		"CheckDistinctTwice": func(t testing.TB) bool {
+			return CheckDistinctTwice(t, numbers, shrinking)
+		},
+	}
+	for name, law := range laws {
+		t.Run(name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			if law(r) || len(r.failures) != 1 {
+				t.Errorf("%s() failures = %q, want one counterexample", name, r.failures)
+			}
+		})
+	}
+}
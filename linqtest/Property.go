@@ -0,0 +1,135 @@
+package linqtest
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/thereisnoplanb/generic"
+)
+
+// The number of random inputs ForAll checks a property on.
+const DefaultRuns = 100
+
+// Generates a random value.
+type Generator[TValue any] func(rng *rand.Rand) TValue
+
+// Generates integers in [0, n).
+//
+// # Parameters
+//
+//	n int
+//
+// The upper bound, exclusive. It must be greater than zero.
+//
+// # Returns
+//
+//	result Generator[int]
+//
+// A Generator[int] that draws uniformly from [0, n).
+func IntN(n int) (result Generator[int]) {
+	return func(rng *rand.Rand) int {
+		return rng.IntN(n)
+	}
+}
+
+// Generates slices of random length.
+//
+// # Parameters
+//
+//	element Generator[TValue]
+//
+// The generator of the elements.
+//
+//	maxLength int
+//
+// The greatest length of a generated slice. Lengths are drawn uniformly from [0, maxLength].
+//
+// # Returns
+//
+//	result Generator[[]TValue]
+//
+// A Generator[[]TValue] that draws a length, then that many elements.
+func SliceOf[TValue any](element Generator[TValue], maxLength int) (result Generator[[]TValue]) {
+	return func(rng *rand.Rand) []TValue {
+		values := make([]TValue, rng.IntN(maxLength+1))
+		for i := range values {
+			values[i] = element(rng)
+		}
+		return values
+	}
+}
+
+// Generates pairs of independent random values.
+//
+// # Parameters
+//
+//	first Generator[TFirst]
+//
+// The generator of Item1.
+//
+//	second Generator[TSecond]
+//
+// The generator of Item2.
+//
+// # Returns
+//
+//	result Generator[generic.ValuePair[TFirst, TSecond]]
+//
+// A Generator that draws Item1, then Item2.
+func Pair[TFirst any, TSecond any](first Generator[TFirst], second Generator[TSecond]) (result Generator[generic.ValuePair[TFirst, TSecond]]) {
+	return func(rng *rand.Rand) generic.ValuePair[TFirst, TSecond] {
+		return generic.ValuePair[TFirst, TSecond]{
+			Item1: first(rng),
+			Item2: second(rng),
+		}
+	}
+}
+
+// Checks that a property holds for random inputs.
+//
+// # Parameters
+//
+//	t testing.TB
+//
+// The test to report a counterexample to.
+//
+//	generator Generator[TValue]
+//
+// The generator of the inputs.
+//
+//	property func(value TValue) bool
+//
+// The law to check. It returns false for an input that violates it.
+//
+// # Returns
+//
+//	result bool
+//
+// True if the property held for DefaultRuns inputs; otherwise, false, and the first counterexample is reported.
+//
+// # Remarks
+//
+// The inputs are drawn from a source seeded with the name of the test, so a failing test fails the same way on every run.
+//
+// # Example
+//
+//	linqtest.ForAll(t, linqtest.SliceOf(linqtest.IntN(10), 20), func(values []int) bool {
+//		source := linq.FromSlice(values)
+//		return source.Reverse().Reverse().SequenceEqual(source)
+//	})
+func ForAll[TValue any](t testing.TB, generator Generator[TValue], property func(value TValue) bool) (result bool) {
+	t.Helper()
+	hash := fnv.New64a()
+	hash.Write([]byte(t.Name()))
+	seed := hash.Sum64()
+	rng := rand.New(rand.NewPCG(seed, seed))
+	for run := range DefaultRuns {
+		value := generator(rng)
+		if !property(value) {
+			t.Errorf("property does not hold for %v (run %d of %d)", value, run+1, DefaultRuns)
+			return false
+		}
+	}
+	return true
+}
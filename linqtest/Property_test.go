@@ -0,0 +1,42 @@
+package linqtest
+
+import (
+	"testing"
+
+	"github.com/thereisnoplanb/generic"
+	"github.com/thereisnoplanb/linq"
+)
+
+func isEven(item int) bool {
+	return item%2 == 0
+}
+
+func TestForAll(t *testing.T) {
+	r := &recorder{TB: t}
+	if ForAll(r, IntN(10), func(value int) bool {
+		return value < 9
+	}) || len(r.failures) != 1 {
+		t.Errorf("ForAll() failures = %q, want one counterexample", r.failures)
+	}
+	if !ForAll(t, SliceOf(IntN(10), 5), func(values []int) bool {
+		return len(values) <= 5
+	}) {
+		t.Errorf("ForAll() = false, want true")
+	}
+}
+
+func TestLaws(t *testing.T) {
+	numbers := SliceOf(IntN(10), 20)
+	t.Run("TopK(k) == OrderDescending().Take(k)", func(t *testing.T) {
+		ForAll(t, Pair(numbers, IntN(25)), func(input generic.ValuePair[[]int, int]) bool {
+			source := linq.FromSlice(input.Item1)
+			return linq.TopK(source, input.Item2).SequenceEqual(source.OrderDescending().Take(input.Item2))
+		})
+	})
+	t.Run("Memoize(x) == x", func(t *testing.T) {
+		ForAll(t, numbers, func(values []int) bool {
+			memoized := OneShot(t, linq.FromSlice(values)).Memoize()
+			return memoized.Skip(1).Count() == max(len(values)-1, 0) && memoized.SequenceEqual(linq.FromSlice(values))
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package linqtest
+
+import (
+	"sync/atomic"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+// Wraps a sequence and counts how its consumers enumerate it.
+type Spy[TSource any] struct {
+	source       linq.Iterator[TSource]
+	enumerations atomic.Int64
+	pulls        atomic.Int64
+	stops        atomic.Int64
+}
+
+// Creates a Spy on a sequence.
+//
+// # Parameters
+//
+//	source linq.Iterator[TSource]
+//
+// The sequence to observe.
+//
+// # Returns
+//
+//	result *Spy[TSource]
+//
+// A Spy whose Iterator yields the elements of source.
+//
+// # Example
+//
+//	spy := linqtest.NewSpy(linq.Range(1, 10))
+//	spy.Iterator().Where(isEven).First()
+//	// spy.Enumerations() == 1, spy.Pulls() == 2, spy.Stops() == 1
+func NewSpy[TSource any](source linq.Iterator[TSource]) (result *Spy[TSource]) {
+	return &Spy[TSource]{
+		source: source,
+	}
+}
+
+// Returns the observed sequence. Every enumeration of it is counted.
+//
+// # Returns
+//
+//	result linq.Iterator[TSource]
+//
+// A linq.Iterator[TSource] that yields the elements of the source.
+//
+// # Remarks
+//
+// The result is opaque: operators cannot see through it to the source, so they enumerate it rather than index it.
+// The counters are safe to read while the result is enumerated from other goroutines.
+func (spy *Spy[TSource]) Iterator() (result linq.Iterator[TSource]) {
+	return func(yield func(value TSource) bool) {
+		spy.enumerations.Add(1)
+		for item := range spy.source {
+			spy.pulls.Add(1)
+			if !yield(item) {
+				spy.stops.Add(1)
+				return
+			}
+		}
+	}
+}
+
+// Returns the number of times the sequence was enumerated.
+func (spy *Spy[TSource]) Enumerations() int {
+	return int(spy.enumerations.Load())
+}
+
+// Returns the number of elements the sequence yielded, over all enumerations.
+func (spy *Spy[TSource]) Pulls() int {
+	return int(spy.pulls.Load())
+}
+
+// Returns the number of enumerations stopped by the consumer before the sequence ended.
+func (spy *Spy[TSource]) Stops() int {
+	return int(spy.stops.Load())
+}
@@ -0,0 +1,29 @@
+package linqtest
+
+import (
+	"testing"
+
+	"github.com/thereisnoplanb/linq"
+)
+
+func TestSpy(t *testing.T) {
+	spy := NewSpy(linq.Range(1, 10))
+	isEven := func(item int) bool {
+		return item%2 == 0
+	}
+	if got, err := spy.Iterator().Where(isEven).First(); got != 2 || err != nil {
+		t.Fatalf("First() = %v, %v, want 2, nil", got, err)
+	}
+	if got := spy.Iterator().Count(); got != 10 {
+		t.Fatalf("Count() = %v, want 10", got)
+	}
+	if got, want := spy.Enumerations(), 2; got != want {
+		t.Errorf("Spy.Enumerations() = %v, want %v", got, want)
+	}
+	if got, want := spy.Pulls(), 12; got != want {
+		t.Errorf("Spy.Pulls() = %v, want %v", got, want)
+	}
+	if got, want := spy.Stops(), 1; got != want {
+		t.Errorf("Spy.Stops() = %v, want %v", got, want)
+	}
+}
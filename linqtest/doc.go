@@ -0,0 +1,21 @@
+// Package linqtest helps to test sequences, operators and sources built on the linq package.
+//
+// CheckIterator checks that a sequence keeps the contract of linq.Iterator: it yields the same elements every time it is
+// enumerated, stops as soon as yield returns false, and never calls yield again after that.
+//
+// Spy counts the enumerations, pulled elements and early stops of a sequence. OneShot fails the test when a sequence is
+// enumerated more than once, and Panicking panics at a given element. AssertSequence and AssertUnordered compare the
+// elements of a sequence with the expected ones.
+//
+// ForAll checks a property, such as an algebraic law between operators, on inputs drawn from a Generator:
+//
+//	linqtest.ForAll(t, linqtest.SliceOf(linqtest.IntN(10), 20), func(values []int) bool {
+//		source := linq.FromSlice(values)
+//		return source.Where(isEven).Count() == source.Count(isEven)
+//	})
+//
+// CheckWhereCount, CheckReverseTwice, CheckTakeSkip, CheckTakeLastSkipLast, CheckOrderTwice and CheckDistinctTwice check
+// ready-made laws on sequences built by a function, so that a custom source can be checked against them:
+//
+//	linqtest.CheckReverseTwice(t, linqtest.SliceOf(linqtest.IntN(10), 20), newSource)
+package linqtest